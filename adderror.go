@@ -0,0 +1,82 @@
+package serrors
+
+import (
+	"fmt"
+	"log/slog"
+	"os"
+	"time"
+)
+
+// Attr keys set by AddError
+const (
+	// ErrKey holds the error itself, so Unwrap can return it. It renders as the error's message.
+	ErrKey = "err"
+	// ErrTypeKey holds the concrete type name of the error, such as *fs.PathError
+	ErrTypeKey = "err_type"
+)
+
+// AddError adds an Error level record whose message is err's message, with err under ErrKey and
+// its type name under ErrTypeKey, followed by attrs. Pass WithStack in attrs to capture the stack
+// trace at the call site. Nothing is added if err is nil.
+func (e *SErrors) AddError(t time.Time, err error, attrs ...slog.Attr) {
+	if err == nil {
+		return
+	}
+
+	e.add(errorRecord(e.pc(), t, err, attrs))
+}
+
+// errorRecord returns the record AddError adds for err
+func errorRecord(pc uintptr, t time.Time, err error, attrs []slog.Attr) slog.Record {
+	r := slog.NewRecord(t, slog.LevelError, err.Error(), pc)
+	r.AddAttrs(slog.Any(ErrKey, err), slog.String(ErrTypeKey, fmt.Sprintf("%T", err)))
+	r.AddAttrs(attrs...)
+	return r
+}
+
+// ErrDepthKey is the attr key FromError records how deeply an error was joined or wrapped with
+const ErrDepthKey = "err_depth"
+
+// FromError converts err into a collection with a record at level l for each error joined in it,
+// as AddError would add them, to move code built on errors.Join or multierror packages to
+// structured records. Errors with an Unwrap() []error method are split into the errors they join,
+// recursively, and Unwrap() error chains are followed to find joined errors inside them. Each
+// record has ErrDepthKey set to the number of joins and wraps above its error. The collection uses
+// the JSON handler writing to os.Stderr, like ParseJSON; use Append to move the records into a
+// configured collection. It is empty if err is nil.
+func FromError(err error, l slog.Level) *SErrors {
+	e := New(os.Stderr, nil)
+	e.addErrorTree(e.now(), l, err, 0)
+	return e
+}
+
+// addErrorTree adds a record for err, or for each error joined in it, at the given depth
+func (e *SErrors) addErrorTree(t time.Time, l slog.Level, err error, depth int) {
+	if err == nil {
+		return
+	}
+
+	// Follow the wraps of err down to an error joining others, if any.
+	for w, d := err, depth; w != nil; d++ {
+		if j, ok := w.(interface{ Unwrap() []error }); ok {
+			for _, child := range j.Unwrap() {
+				e.addErrorTree(t, l, child, d+1)
+			}
+			return
+		}
+
+		u, ok := w.(interface{ Unwrap() error })
+		if !ok {
+			break
+		}
+		w = u.Unwrap()
+	}
+
+	r := slog.NewRecord(t, l, err.Error(), 0)
+	r.AddAttrs(
+		slog.Any(ErrKey, err),
+		slog.String(ErrTypeKey, fmt.Sprintf("%T", err)),
+		slog.Int(ErrDepthKey, depth),
+	)
+	e.add(r)
+}
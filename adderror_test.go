@@ -0,0 +1,70 @@
+package serrors
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"log/slog"
+	"os"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestSErrorsAddError(t *testing.T) {
+	e := NewTextHandler(nil, nil)
+	_, err := os.Open("/does/not/exist")
+	e.AddError(testTime, err, slog.String("op", "load"))
+	e.AddError(testTime, errors.New("plain"))
+	e.AddError(testTime, nil)
+
+	want := "time=2000-01-02T03:04:05.000Z level=ERROR msg=\"open /does/not/exist: no such file or directory\" " +
+		"err=\"open /does/not/exist: no such file or directory\" err_type=*fs.PathError op=load\n" +
+		"time=2000-01-02T03:04:05.000Z level=ERROR msg=plain err=plain err_type=*errors.errorString\n"
+	if got := e.String(); got != want {
+		t.Fatalf("\ngot  %s\nwant %s", got, want)
+	}
+
+	if !errors.Is(e.Err(), fs.ErrNotExist) {
+		t.Fatal("errors.Is did not find fs.ErrNotExist")
+	}
+}
+
+func TestSErrorsAddErrorStack(t *testing.T) {
+	e := New(nil, nil)
+	e.AddError(testTime, errors.New("m"), WithStack())
+
+	v, ok := GetAttrPath(e.First(), StackKey)
+	if !ok || !strings.Contains(v.String(), "TestSErrorsAddErrorStack") {
+		t.Fatalf("\ngot  %s\nwant the caller's stack", v)
+	}
+}
+
+func TestFromError(t *testing.T) {
+	err := errors.Join(
+		errors.New("a"),
+		fmt.Errorf("ctx: %w", errors.Join(errors.New("b"), fs.ErrNotExist)),
+		fmt.Errorf("wrap: %w", io.EOF),
+	)
+
+	e := FromError(err, slog.LevelWarn)
+	var got []string
+	for _, r := range e.Records() {
+		depth, _ := GetAttrPath(r, ErrDepthKey)
+		got = append(got, fmt.Sprintf("%s %s %s", r.Level, r.Message, depth))
+	}
+
+	want := []string{"WARN a 1", "WARN b 3", "WARN file does not exist 3", "WARN wrap: EOF 1"}
+	if !slices.Equal(got, want) {
+		t.Fatalf("\ngot  %q\nwant %q", got, want)
+	}
+
+	if !errors.Is(e.Err(), io.EOF) || !errors.Is(e.Err(), fs.ErrNotExist) {
+		t.Fatal("errors.Is did not find the joined errors")
+	}
+
+	if n := FromError(nil, slog.LevelError).Count(); n != 0 {
+		t.Fatalf("\ngot  %d\nwant 0", n)
+	}
+}
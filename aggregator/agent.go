@@ -0,0 +1,147 @@
+package aggregator
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/chadeldridge/serrors"
+	"github.com/chadeldridge/serrors/grpcship"
+)
+
+// maxBatch limits the encoded size of the records in one batch, well under maxBody
+const maxBatch = maxBody / 2
+
+// Agent ships the records of a collection to a Server's /ingest endpoint. Each Push only sends the
+// records added since the last successful Push.
+type Agent struct {
+	mu     sync.Mutex
+	url    string
+	source string
+	client *http.Client
+	// sent is the sequence number of the newest record taken from the collection, see SErrors.Since
+	sent uint64
+	seq  uint64
+	// pending holds the records taken from the collection that no batch has delivered yet
+	pending []grpcship.Record
+	// batchSize limits the encoded size of the records in a batch, maxBatch if 0
+	batchSize int
+}
+
+// NewAgent creates an Agent shipping as source to the Server whose Handler is at baseURL. If client
+// is nil, http.DefaultClient is used.
+func NewAgent(baseURL, source string, client *http.Client) *Agent {
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	return &Agent{url: strings.TrimSuffix(baseURL, "/") + "/ingest", source: source, client: client}
+}
+
+// Push sends the records, including spilled ones, that entered e since the last successful Push.
+// Records removed, expired or dropped before a Push are not sent. The records are sent in batches
+// the Server accepts, so a large backlog takes several requests; if one fails, the batches already
+// delivered are not sent again and the rest are retried by the next Push. It must always be called
+// with the same collection.
+func (a *Agent) Push(ctx context.Context, e *serrors.SErrors) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	rs, sent, err := e.Since(a.sent)
+	if err != nil {
+		return err
+	}
+
+	for _, r := range rs {
+		a.pending = append(a.pending, grpcship.FromRecord(r))
+	}
+	a.sent = sent
+
+	for len(a.pending) > 0 {
+		n, err := a.batchLen()
+		if err != nil {
+			return err
+		}
+
+		b := grpcship.Batch{Source: a.source, Seq: a.seq + 1, Records: a.pending[:n]}
+		if err := a.post(ctx, &b); err != nil {
+			return err
+		}
+
+		a.seq = b.Seq
+		a.pending = a.pending[n:]
+	}
+
+	a.pending = nil
+	return nil
+}
+
+// batchLen returns the number of pending records that fit in the next batch. A record too large
+// for any batch is dropped with an error, so it does not hold back the records after it.
+func (a *Agent) batchLen() (int, error) {
+	limit := a.batchSize
+	if limit <= 0 {
+		limit = maxBatch
+	}
+
+	size := 0
+	for i, r := range a.pending {
+		b, err := json.Marshal(r)
+		if err != nil {
+			return 0, err
+		}
+
+		size += len(b) + 1
+		if size <= limit {
+			continue
+		}
+
+		if i > 0 {
+			return i, nil
+		}
+
+		a.pending = a.pending[1:]
+		return 0, fmt.Errorf("aggregator: record of %d bytes exceeds the batch limit of %d", len(b), limit)
+	}
+
+	return len(a.pending), nil
+}
+
+// post sends b and checks the Ack
+func (a *Agent) post(ctx context.Context, b *grpcship.Batch) error {
+	body, err := json.Marshal(b)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, a.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	res, err := a.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return fmt.Errorf("aggregator: push failed: %s", res.Status)
+	}
+
+	ack := grpcship.Ack{}
+	if err := json.NewDecoder(res.Body).Decode(&ack); err != nil {
+		return err
+	}
+
+	if ack.Error != "" {
+		return fmt.Errorf("aggregator: batch %d rejected: %s", ack.Seq, ack.Error)
+	}
+
+	return nil
+}
@@ -0,0 +1,213 @@
+// Package aggregator is a small fleet-wide error hub. A Server receives batches of records from
+// many sources over HTTP or gRPC, merges them into one collection per source and a global
+// collection, and serves the serrors dashboard and stream endpoints over them. Agent is the HTTP
+// client that ships a collection to a Server.
+package aggregator
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/chadeldridge/serrors"
+	"github.com/chadeldridge/serrors/grpcship"
+	"google.golang.org/grpc"
+)
+
+// SourceKey is the attr added to records in the global collection naming where they came from
+const SourceKey = "source"
+
+// maxBody limits the size of a batch posted to /ingest
+const maxBody = 32 << 20
+
+// Server merges received records into per-source and global collections
+type Server struct {
+	mu sync.RWMutex
+	// newCollector creates the collection for a new source
+	newCollector func() *serrors.SErrors
+	global       *serrors.SErrors
+	sources      map[string]*serrors.SErrors
+}
+
+// New creates a Server. newCollector creates the global collection and the collection of each new
+// source, so it sets the handlers used by the dashboards and Log. If newCollector is nil,
+// collections use serrors.New(io.Discard, nil).
+func New(newCollector func() *serrors.SErrors) *Server {
+	if newCollector == nil {
+		newCollector = func() *serrors.SErrors { return serrors.New(io.Discard, nil) }
+	}
+
+	return &Server{
+		newCollector: newCollector,
+		global:       newCollector(),
+		sources:      map[string]*serrors.SErrors{},
+	}
+}
+
+// Receive adds rs to the collection of source and to the global collection with a source attr and
+// a serrors.Provenance naming source as the collector. It implements grpcship.Receiver.
+func (s *Server) Receive(_ context.Context, source string, rs []slog.Record) error {
+	src := s.source(source)
+	for _, r := range rs {
+		src.AddRecord(r)
+
+		g := r.Clone()
+		g.AddAttrs(slog.String(SourceKey, source))
+		s.global.AddFrom("", source, g)
+	}
+
+	return nil
+}
+
+// source returns the collection of name, creating it if needed
+func (s *Server) source(name string) *serrors.SErrors {
+	s.mu.RLock()
+	e, ok := s.sources[name]
+	s.mu.RUnlock()
+	if ok {
+		return e
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if e, ok := s.sources[name]; ok {
+		return e
+	}
+
+	e = s.newCollector()
+	s.sources[name] = e
+	return e
+}
+
+// Global returns the collection of records from every source
+func (s *Server) Global() *serrors.SErrors { return s.global }
+
+// Source returns the collection of name and false if nothing has been received from it
+func (s *Server) Source(name string) (*serrors.SErrors, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	e, ok := s.sources[name]
+	return e, ok
+}
+
+// Sources returns the names of every source in order
+func (s *Server) Sources() []string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	names := make([]string, 0, len(s.sources))
+	for name := range s.sources {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+
+	return names
+}
+
+// RegisterGRPC registers the grpcship service on g so clients can ship with grpcship.Client
+func (s *Server) RegisterGRPC(g grpc.ServiceRegistrar) {
+	grpcship.Register(g, grpcship.NewServer(s))
+}
+
+// sourceInfo is an entry of the /sources response
+type sourceInfo struct {
+	Name  string `json:"name"`
+	Count int    `json:"count"`
+	Level string `json:"level"`
+}
+
+// Handler returns the HTTP endpoints of the Server
+//
+//	POST /ingest                    a grpcship.Batch in JSON, as sent by Agent
+//	GET  /sources                   the sources with their record count and highest level
+//	     /dashboard/                the dashboard of the global collection
+//	     /stream                    the stream of the global collection
+//	     /sources/{name}/dashboard/ the dashboard of one source
+//	     /sources/{name}/stream     the stream of one source
+func (s *Server) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/ingest", s.ingest)
+	mux.Handle("/dashboard/", http.StripPrefix("/dashboard", s.global.DashboardHandler()))
+	mux.Handle("/stream", s.global.StreamHandler())
+	mux.HandleFunc("/sources", func(w http.ResponseWriter, _ *http.Request) {
+		infos := []sourceInfo{}
+		for _, name := range s.Sources() {
+			e, _ := s.Source(name)
+			info := sourceInfo{Name: name, Count: e.Count()}
+			if info.Count > 0 {
+				info.Level = e.Level().String()
+			}
+			infos = append(infos, info)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(infos)
+	})
+
+	mux.HandleFunc("/sources/", func(w http.ResponseWriter, r *http.Request) {
+		name, rest, _ := strings.Cut(strings.TrimPrefix(r.URL.Path, "/sources/"), "/")
+		e, ok := s.Source(name)
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+
+		switch {
+		case rest == "stream":
+			e.StreamHandler().ServeHTTP(w, r)
+		case rest == "dashboard" || strings.HasPrefix(rest, "dashboard/"):
+			http.StripPrefix("/sources/"+name+"/dashboard", e.DashboardHandler()).ServeHTTP(w, r)
+		default:
+			http.NotFound(w, r)
+		}
+	})
+
+	return mux
+}
+
+// ingest receives a batch posted by Agent
+func (s *Server) ingest(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	b := &grpcship.Batch{}
+	d := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxBody))
+	d.UseNumber()
+	if err := d.Decode(b); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if b.Source == "" {
+		http.Error(w, "missing source", http.StatusBadRequest)
+		return
+	}
+
+	rs := make([]slog.Record, len(b.Records))
+	for i, wr := range b.Records {
+		rec, err := wr.ToRecord()
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		rs[i] = rec
+	}
+
+	ack := &grpcship.Ack{Seq: b.Seq, Accepted: len(rs)}
+	if err := s.Receive(r.Context(), b.Source, rs); err != nil {
+		ack.Accepted = 0
+		ack.Error = err.Error()
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(ack)
+}
@@ -0,0 +1,193 @@
+package aggregator
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/chadeldridge/serrors"
+)
+
+var testTime = time.Date(2000, 1, 2, 3, 4, 5, 0, time.UTC)
+
+func TestAgentPush(t *testing.T) {
+	// received_at is when the test ran, so it is left out.
+	opts := &slog.HandlerOptions{ReplaceAttr: func(_ []string, a slog.Attr) slog.Attr {
+		if a.Key == serrors.ReceivedAtKey {
+			return slog.Attr{}
+		}
+
+		return a
+	}}
+	s := New(func() *serrors.SErrors { return serrors.NewTextHandler(io.Discard, opts) })
+	srv := httptest.NewServer(s.Handler())
+	defer srv.Close()
+
+	e := serrors.New(nil, nil)
+	e.Warn(testTime, "m", slog.Int("a", 1))
+
+	a := NewAgent(srv.URL, "worker-1", nil)
+	if err := a.Push(context.Background(), e); err != nil {
+		t.Fatalf("\ngot  %s\nwant nil", err.Error())
+	}
+
+	e.Error(testTime, "m2")
+	for i := 0; i < 2; i++ {
+		if err := a.Push(context.Background(), e); err != nil {
+			t.Fatalf("\ngot  %s\nwant nil", err.Error())
+		}
+	}
+
+	src, ok := s.Source("worker-1")
+	if !ok {
+		t.Fatalf("\ngot  no source\nwant worker-1")
+	}
+
+	want := "time=2000-01-02T03:04:05.000Z level=WARN msg=m a=1\ntime=2000-01-02T03:04:05.000Z level=ERROR msg=m2\n"
+	if src.String() != want {
+		t.Fatalf("\ngot  %s\nwant %s", src.String(), want)
+	}
+
+	want = "time=2000-01-02T03:04:05.000Z level=WARN msg=m a=1 source=worker-1 " +
+		"provenance.collector=worker-1\n" +
+		"time=2000-01-02T03:04:05.000Z level=ERROR msg=m2 source=worker-1 " +
+		"provenance.collector=worker-1\n"
+	if s.Global().String() != want {
+		t.Fatalf("\ngot  %s\nwant %s", s.Global().String(), want)
+	}
+}
+
+func TestServerHandler(t *testing.T) {
+	s := New(nil)
+	r := slog.NewRecord(testTime, slog.LevelError, "m", 0)
+	s.Receive(context.Background(), "b", []slog.Record{r})
+	s.Receive(context.Background(), "a", []slog.Record{r, r})
+
+	h := s.Handler()
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/sources", nil))
+
+	var infos []sourceInfo
+	if err := json.NewDecoder(w.Body).Decode(&infos); err != nil {
+		t.Fatalf("\ngot  %s\nwant nil", err.Error())
+	}
+
+	if len(infos) != 2 || infos[0] != (sourceInfo{"a", 2, "ERROR"}) || infos[1] != (sourceInfo{"b", 1, "ERROR"}) {
+		t.Fatalf("\ngot  %v\nwant a and b", infos)
+	}
+
+	tests := []struct {
+		method string
+		path   string
+		code   int
+		want   string
+	}{
+		{http.MethodGet, "/dashboard/", http.StatusOK, "<title>serrors dashboard</title>"},
+		{http.MethodGet, "/dashboard/records.json", http.StatusOK, `"source":"a"`},
+		{http.MethodGet, "/sources/a/dashboard/records.json", http.StatusOK, `[{"time":"2000-01-02T03:04:05Z","level":"ERROR","msg":"m"},`},
+		{http.MethodGet, "/sources/missing/dashboard/", http.StatusNotFound, "404"},
+		{http.MethodGet, "/sources/a/other", http.StatusNotFound, "404"},
+		{http.MethodGet, "/ingest", http.StatusMethodNotAllowed, "method not allowed"},
+		{http.MethodPost, "/ingest", http.StatusBadRequest, "missing source"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.path, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			h.ServeHTTP(w, httptest.NewRequest(test.method, test.path, strings.NewReader(`{}`)))
+
+			if w.Code != test.code {
+				t.Fatalf("\ngot  %d\nwant %d", w.Code, test.code)
+			}
+
+			if !strings.Contains(w.Body.String(), test.want) {
+				t.Fatalf("\ngot  %s\nwant %s", w.Body.String(), test.want)
+			}
+		})
+	}
+}
+
+func TestAgentPushAfterRemoval(t *testing.T) {
+	s := New(nil)
+	srv := httptest.NewServer(s.Handler())
+	defer srv.Close()
+
+	e := serrors.New(nil, nil, serrors.WithMaxRecords(1, serrors.DropOldest))
+	a := NewAgent(srv.URL, "w", nil)
+	for _, m := range []string{"a", "b", "c"} {
+		e.Info(testTime, m)
+		if err := a.Push(context.Background(), e); err != nil {
+			t.Fatalf("\ngot  %s\nwant nil", err.Error())
+		}
+	}
+
+	e.Reset()
+	e.Info(testTime, "d")
+	if err := a.Push(context.Background(), e); err != nil {
+		t.Fatalf("\ngot  %s\nwant nil", err.Error())
+	}
+
+	src, _ := s.Source("w")
+	if got := len(src.Records()); got != 4 {
+		t.Fatalf("\ngot  %d records\nwant 4", got)
+	}
+}
+
+func TestAgentPushBatches(t *testing.T) {
+	s := New(nil)
+	posts, fail := 0, false
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		posts++
+		if fail {
+			http.Error(w, "down", http.StatusServiceUnavailable)
+			return
+		}
+		s.Handler().ServeHTTP(w, r)
+	}))
+	defer srv.Close()
+
+	e := serrors.New(nil, nil)
+	for i := range 5 {
+		e.Info(testTime, "m", slog.Int("i", i))
+	}
+
+	// Each record encodes to about 100 bytes, so two fit in a batch.
+	a := NewAgent(srv.URL, "w", nil)
+	a.batchSize = 250
+	if err := a.Push(context.Background(), e); err != nil {
+		t.Fatalf("\ngot  %s\nwant nil", err.Error())
+	}
+
+	src, _ := s.Source("w")
+	if got := src.Count(); posts != 3 || got != 5 {
+		t.Fatalf("\ngot  %d posts %d records\nwant 3 posts 5 records", posts, got)
+	}
+
+	e.Info(testTime, "m", slog.Int("i", 5))
+	fail = true
+	if err := a.Push(context.Background(), e); err == nil {
+		t.Fatalf("\ngot  nil\nwant error")
+	}
+
+	fail = false
+	e.Info(testTime, "m", slog.Int("i", 6))
+	if err := a.Push(context.Background(), e); err != nil {
+		t.Fatalf("\ngot  %s\nwant nil", err.Error())
+	}
+
+	if got := src.Count(); got != 7 {
+		t.Fatalf("\ngot  %d records\nwant 7", got)
+	}
+
+	a.batchSize = 10
+	e.Info(testTime, "m", slog.Int("i", 7))
+	if err := a.Push(context.Background(), e); err == nil || len(a.pending) != 0 {
+		t.Fatalf("\ngot  %v %d pending\nwant error 0 pending", err, len(a.pending))
+	}
+}
@@ -0,0 +1,42 @@
+package serrors
+
+import "log/slog"
+
+// Annotate adds attrs to the record at index i, e.g. retried=true once a retry
+// loop has succeeded. It panics if i is out of range.
+func (e *SErrors) Annotate(i int, attrs ...slog.Attr) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	e.records[i] = annotated(e.records[i], attrs)
+	e.invalidate()
+}
+
+// AnnotateMatching adds attrs to every record for which pred returns true and
+// returns the number of records annotated
+func (e *SErrors) AnnotateMatching(pred func(slog.Record) bool, attrs ...slog.Attr) int {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	n := 0
+	for i, r := range e.records {
+		if pred(r) {
+			e.records[i] = annotated(r, attrs)
+			n++
+		}
+	}
+
+	if n > 0 {
+		e.invalidate()
+	}
+
+	return n
+}
+
+// annotated returns a copy of r with attrs added. Copying keeps copies of r handed out by Records
+// unchanged.
+func annotated(r slog.Record, attrs []slog.Attr) slog.Record {
+	r = r.Clone()
+	r.AddAttrs(attrs...)
+	return r
+}
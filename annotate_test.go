@@ -0,0 +1,43 @@
+package serrors
+
+import (
+	"log/slog"
+	"testing"
+)
+
+func TestSErrorsAnnotate(t *testing.T) {
+	e := NewTextHandler(nil, nil)
+	e.Warn(testTime, "m", slog.Int("a", 1))
+	e.Error(testTime, "m2")
+	before := e.Records()
+
+	e.Annotate(0, slog.Bool("retried", true))
+
+	want := "time=2000-01-02T03:04:05.000Z level=WARN msg=m a=1 retried=true\ntime=2000-01-02T03:04:05.000Z level=ERROR msg=m2\n"
+	if got := e.String(); got != want {
+		t.Fatalf("\ngot  %s\nwant %s", got, want)
+	}
+
+	if before[0].NumAttrs() != 1 {
+		t.Fatalf("\ngot  %d\nwant 1", before[0].NumAttrs())
+	}
+}
+
+func TestSErrorsAnnotateMatching(t *testing.T) {
+	e := NewTextHandler(nil, nil)
+	e.Warn(testTime, "m")
+	e.Error(testTime, "m2")
+	e.Warn(testTime, "m3")
+
+	n := e.AnnotateMatching(func(r slog.Record) bool { return r.Level == slog.LevelWarn }, slog.String("resolved_at", "later"))
+	if n != 2 {
+		t.Fatalf("\ngot  %d\nwant 2", n)
+	}
+
+	want := "time=2000-01-02T03:04:05.000Z level=WARN msg=m resolved_at=later\n" +
+		"time=2000-01-02T03:04:05.000Z level=ERROR msg=m2\n" +
+		"time=2000-01-02T03:04:05.000Z level=WARN msg=m3 resolved_at=later\n"
+	if got := e.String(); got != want {
+		t.Fatalf("\ngot  %s\nwant %s", got, want)
+	}
+}
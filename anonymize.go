@@ -0,0 +1,139 @@
+package serrors
+
+import (
+	"cmp"
+	"log/slog"
+	"maps"
+	"slices"
+	"strings"
+)
+
+// AnonymizeOptions configures Anonymized
+type AnonymizeOptions struct {
+	// MinCount is the fewest records a reported count may cover, the k of k-anonymity. Values
+	// below 2 are raised to 2.
+	MinCount int
+	// Attrs are the attr paths, see GetAttrPath, whose values are reported with the counts. Every
+	// other attr is left out.
+	Attrs []string
+}
+
+// AnonymousCount is the number of records sharing a fingerprint and attr values, reported by
+// Anonymized
+type AnonymousCount struct {
+	Fingerprint string            `json:"fingerprint"`
+	Level       string            `json:"level"`
+	Attrs       map[string]string `json:"attrs,omitempty"`
+	Count       int               `json:"count"`
+}
+
+// AnonymousReport holds the counts reported by Anonymized and the number of records left out
+type AnonymousReport struct {
+	Counts     []AnonymousCount `json:"counts"`
+	Suppressed int              `json:"suppressed"`
+}
+
+// Anonymized aggregates the records into counts per fingerprint and combination of the values of
+// opts.Attrs, for sharing error telemetry outside the trust boundary. No count covers fewer than
+// opts.MinCount records: combinations below it are folded into a count for their fingerprint
+// without attrs, and fingerprints still below it are suppressed. Messages, times and every other
+// attr are dropped. Counts are sorted from the most common. Spilled records are included.
+func (e *SErrors) Anonymized(opts AnonymizeOptions) (AnonymousReport, error) {
+	k := max(opts.MinCount, 2)
+	type combo struct {
+		attrs map[string]string
+		level slog.Level
+		count int
+	}
+
+	// fingerprints maps each fingerprint to its combinations, keyed by their joined values.
+	fingerprints := map[string]map[string]*combo{}
+	err := e.eachRecord(func(r slog.Record) error {
+		fp := e.fingerprint(r)
+		attrs := map[string]string{}
+		values := make([]string, len(opts.Attrs))
+		for i, path := range opts.Attrs {
+			if v, ok := GetAttrPath(r, path); ok {
+				attrs[path] = v.String()
+				values[i] = "=" + v.String()
+			}
+		}
+
+		combos, ok := fingerprints[fp]
+		if !ok {
+			combos = map[string]*combo{}
+			fingerprints[fp] = combos
+		}
+
+		key := strings.Join(values, "\x00")
+		c, ok := combos[key]
+		if !ok {
+			c = &combo{attrs: attrs, level: r.Level}
+			combos[key] = c
+		}
+
+		c.level = max(c.level, r.Level)
+		c.count++
+		return nil
+	})
+	if err != nil {
+		return AnonymousReport{}, err
+	}
+
+	report := AnonymousReport{Counts: []AnonymousCount{}}
+	for fp, combos := range fingerprints {
+		var level slog.Level
+		n := 0
+		for _, c := range combos {
+			if c.count >= k && len(c.attrs) > 0 {
+				report.Counts = append(report.Counts, AnonymousCount{
+					Fingerprint: fp,
+					Level:       c.level.String(),
+					Attrs:       c.attrs,
+					Count:       c.count,
+				})
+				continue
+			}
+
+			if n == 0 || c.level > level {
+				level = c.level
+			}
+			n += c.count
+		}
+
+		switch {
+		case n >= k:
+			report.Counts = append(report.Counts, AnonymousCount{
+				Fingerprint: fp,
+				Level:       level.String(),
+				Count:       n,
+			})
+		case n > 0:
+			report.Suppressed += n
+		}
+	}
+
+	slices.SortFunc(report.Counts, func(a, b AnonymousCount) int {
+		if c := cmp.Compare(b.Count, a.Count); c != 0 {
+			return c
+		}
+
+		if c := cmp.Compare(a.Fingerprint, b.Fingerprint); c != 0 {
+			return c
+		}
+
+		return slices.Compare(attrPairs(a.Attrs), attrPairs(b.Attrs))
+	})
+
+	return report, nil
+}
+
+// attrPairs returns attrs as sorted key=value pairs
+func attrPairs(attrs map[string]string) []string {
+	pairs := make([]string, 0, len(attrs))
+	for _, k := range slices.Sorted(maps.Keys(attrs)) {
+		pairs = append(pairs, k+"="+attrs[k])
+	}
+
+	return pairs
+}
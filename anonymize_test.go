@@ -0,0 +1,30 @@
+package serrors
+
+import (
+	"encoding/json"
+	"log/slog"
+	"testing"
+)
+
+func TestSErrorsAnonymized(t *testing.T) {
+	e := New(nil, nil)
+	for i := 0; i < 3; i++ {
+		e.Error(testTime, "timeout", slog.String("region", "eu"), slog.Int("user", i))
+	}
+	e.Warn(testTime, "timeout", slog.String("region", "us"), slog.Int("user", 9))
+	e.Error(testTime, "timeout", slog.String("region", "ap"), slog.Int("user", 8))
+	e.Info(testTime, "rare", slog.String("region", "eu"))
+
+	report, err := e.Anonymized(AnonymizeOptions{MinCount: 2, Attrs: []string{"region"}})
+	if err != nil {
+		t.Fatalf("\ngot  %s\nwant nil", err.Error())
+	}
+
+	got, _ := json.Marshal(report)
+	want := `{"counts":[` +
+		`{"fingerprint":"timeout","level":"ERROR","attrs":{"region":"eu"},"count":3},` +
+		`{"fingerprint":"timeout","level":"ERROR","count":2}],"suppressed":1}`
+	if string(got) != want {
+		t.Fatalf("\ngot  %s\nwant %s", got, want)
+	}
+}
@@ -0,0 +1,12 @@
+package serrors
+
+// WithArena keeps at most memLimit records as slog.Records. When the limit is exceeded the oldest
+// records are encoded into one contiguous byte arena, a MemoryStore, leaving the newest
+// memLimit/2 as records. Collections of hundreds of thousands of records then hold a few large
+// allocations instead of the attrs of every record, which keeps GC pauses short. Compacted records
+// are only reachable in order: like WithSpillDir, Log, WriteNDJSON and WriteFormat stream them
+// back before the others and Level includes them, while other methods only see the records not
+// yet compacted. RemoveSpill drops the arena. It replaces WithSpillDir if both are used.
+func WithArena(memLimit int) Option {
+	return WithSpillStore(NewMemoryStore(), memLimit)
+}
@@ -0,0 +1,46 @@
+package serrors
+
+import (
+	"bytes"
+	"fmt"
+	"log/slog"
+	"testing"
+	"time"
+)
+
+func TestSErrorsWithArena(t *testing.T) {
+	got := bytes.NewBuffer(nil)
+	e := NewTextHandler(got, nil, WithArena(4))
+
+	var want string
+	for i := 0; i < 11; i++ {
+		e.Info(testTime, "m", slog.Int("i", i), slog.Duration("d", time.Second), slog.Group("g", slog.Bool("b", true)))
+		want += fmt.Sprintf("time=2000-01-02T03:04:05.000Z level=INFO msg=m i=%d d=1s g.b=true\n", i)
+	}
+	e.Error(testTime, "m")
+	want += "time=2000-01-02T03:04:05.000Z level=ERROR msg=m\n"
+
+	if len(e.records) > 4 || e.Spilled()+len(e.records) != 12 {
+		t.Fatalf("\ngot  %d in memory, %d spilled\nwant at most 4 in memory, 12 total", len(e.records), e.Spilled())
+	}
+
+	if e.Level() != slog.LevelError {
+		t.Fatalf("\ngot  %s\nwant %s", e.Level(), slog.LevelError)
+	}
+
+	if err := e.Log(); err != nil {
+		t.Fatalf("\ngot  %s\nwant nil", err.Error())
+	}
+
+	if got.String() != want {
+		t.Fatalf("\ngot  %s\nwant %s", got, want)
+	}
+
+	if err := e.RemoveSpill(); err != nil {
+		t.Fatalf("\ngot  %s\nwant nil", err.Error())
+	}
+
+	if e.Spilled() != 0 || len(e.spill.store.(*MemoryStore).ends) != 0 {
+		t.Fatalf("\ngot  %d spilled\nwant none", e.Spilled())
+	}
+}
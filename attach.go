@@ -0,0 +1,25 @@
+package serrors
+
+// Attach stores v under key alongside the records, e.g. the domain object that failed, so it can
+// travel up the call stack with e. Payloads are never rendered or logged. Attaching to an existing
+// key replaces its payload.
+func Attach[T any](e *SErrors, key string, v T) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if e.payloads == nil {
+		e.payloads = map[string]any{}
+	}
+
+	e.payloads[key] = v
+}
+
+// Detach returns the payload attached under key. ok is false if there is none or it is not a T.
+// The payload stays attached.
+func Detach[T any](e *SErrors, key string) (v T, ok bool) {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+
+	v, ok = e.payloads[key].(T)
+	return v, ok
+}
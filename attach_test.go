@@ -0,0 +1,32 @@
+package serrors
+
+import (
+	"log/slog"
+	"testing"
+)
+
+func TestSErrorsAttach(t *testing.T) {
+	type user struct{ ID int }
+
+	e := NewTextHandler(nil, nil)
+	Attach(e, "user", user{ID: 7})
+	e.Add(testTime, slog.LevelError, "save failed")
+
+	got, ok := Detach[user](e, "user")
+	if !ok || got.ID != 7 {
+		t.Fatalf("\ngot  %v %t\nwant {7} true", got, ok)
+	}
+
+	if _, ok := Detach[string](e, "user"); ok {
+		t.Fatalf("\ngot  ok\nwant type mismatch")
+	}
+
+	if _, ok := Detach[user](e, "missing"); ok {
+		t.Fatalf("\ngot  ok\nwant missing")
+	}
+
+	want := "time=2000-01-02T03:04:05.000Z level=ERROR msg=\"save failed\"\n"
+	if got := e.String(); got != want {
+		t.Fatalf("\ngot  %s\nwant %s", got, want)
+	}
+}
@@ -0,0 +1,179 @@
+package serrors
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/chadeldridge/serrors/internal/wire"
+)
+
+// Attr keys added to each record of an Audit
+const (
+	// AuditSeqKey is the sequence number of the record, starting at 1
+	AuditSeqKey = "seq"
+	// AuditPrevHashKey is the hash of the previous record, empty for the first
+	AuditPrevHashKey = "prev_hash"
+	// AuditHashKey is the SHA-256 of the record and the previous hash, in hex
+	AuditHashKey = "hash"
+)
+
+// Audit is an append-only collector for compliance-sensitive error and audit trails. Each record
+// is given the next sequence number and the hash of the previous record, and is then hashed
+// itself, chaining the records so any later change, removal or reordering is caught by Verify.
+// Records are stored exactly as added: transforms, level remapping, sampling and TTLs are not
+// applied, and options that drop records, WithRecordTTL, WithMaxRecords and the flush options,
+// are ignored. Audit has no methods to remove or change records.
+type Audit struct {
+	mu   sync.Mutex
+	e    *SErrors
+	seq  uint64
+	prev string
+}
+
+// NewAudit creates an Audit. The arguments are those of NewJSONHandler.
+func NewAudit(logWriter io.Writer, opts *slog.HandlerOptions, options ...Option) *Audit {
+	options = append(options[:len(options):len(options)], keepRecords)
+	return &Audit{e: NewJSONHandler(logWriter, opts, options...)}
+}
+
+// keepRecords undoes the options that would drop records from an Audit
+func keepRecords(e *SErrors) {
+	e.ttl, e.capacity, e.flush = 0, nil, nil
+}
+
+// Add creates a new slog.Record from slog.Attr(s) and appends it to the trail
+func (a *Audit) Add(t time.Time, l slog.Level, msg string, attrs ...slog.Attr) {
+	r := slog.NewRecord(t, l, msg, 0)
+	r.AddAttrs(attrs...)
+	a.append(r)
+}
+
+// AddAny creates a new slog.Record from key-value pairs and appends it to the trail
+func (a *Audit) AddAny(t time.Time, l slog.Level, msg string, args ...any) {
+	r := slog.NewRecord(t, l, msg, 0)
+	r.Add(args...)
+	a.append(r)
+}
+
+// AddRecord appends an existing slog.Record to the trail
+func (a *Audit) AddRecord(r slog.Record) {
+	a.append(r.Clone())
+}
+
+// append chains r to the previous record and stores it
+func (a *Audit) append(r slog.Record) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	a.seq++
+	r.AddAttrs(slog.Uint64(AuditSeqKey, a.seq), slog.String(AuditPrevHashKey, a.prev))
+	a.prev = auditHash(a.prev, r)
+	r.AddAttrs(slog.String(AuditHashKey, a.prev))
+
+	defer a.e.syncWAL()
+	a.e.mu.Lock()
+	defer a.e.mu.Unlock()
+
+	a.e.journal(a.e.seq+1, r)
+	a.e.store(r)
+}
+
+// Verify checks the sequence numbers and hash chain of every record, including spilled ones, and
+// returns an error describing the first record that does not match
+func (a *Audit) Verify() error {
+	var seq uint64
+	prev := ""
+	return a.e.eachRecord(func(r slog.Record) error {
+		seq++
+		var attrs []slog.Attr
+		r.Attrs(func(at slog.Attr) bool {
+			attrs = append(attrs, at)
+			return true
+		})
+
+		gotSeq, gotPrev, gotHash, err := auditAttrs(attrs)
+		if err != nil {
+			return fmt.Errorf("serrors: audit record %d: %w", seq, err)
+		}
+
+		// The hash covers every attr but itself.
+		n := slog.NewRecord(r.Time, r.Level, r.Message, r.PC)
+		n.AddAttrs(attrs[:len(attrs)-1]...)
+
+		if gotSeq != seq {
+			return fmt.Errorf("serrors: audit record %d: sequence is %d", seq, gotSeq)
+		}
+
+		if gotPrev != prev {
+			return fmt.Errorf("serrors: audit record %d: previous hash does not match", seq)
+		}
+
+		prev = auditHash(prev, n)
+		if gotHash != prev {
+			return fmt.Errorf("serrors: audit record %d: hash does not match", seq)
+		}
+
+		return nil
+	})
+}
+
+// auditAttrs returns the sequence number, previous hash and hash an Audit appended to attrs, which
+// are their last three. Attrs of the record with the same keys are ignored.
+func auditAttrs(attrs []slog.Attr) (seq uint64, prev, hash string, err error) {
+	n := len(attrs)
+	if n < 3 {
+		return 0, "", "", errors.New("audit attrs are missing")
+	}
+
+	s, p, h := attrs[n-3], attrs[n-2], attrs[n-1]
+	switch {
+	case s.Key != AuditSeqKey || s.Value.Kind() != slog.KindUint64:
+		return 0, "", "", errors.New("sequence is missing or not a Uint64")
+	case p.Key != AuditPrevHashKey || p.Value.Kind() != slog.KindString:
+		return 0, "", "", errors.New("previous hash is missing or not a String")
+	case h.Key != AuditHashKey || h.Value.Kind() != slog.KindString:
+		return 0, "", "", errors.New("hash is missing or not a String")
+	}
+
+	return s.Value.Uint64(), p.Value.String(), h.Value.String(), nil
+}
+
+// auditHash returns the hash of r chained to prev
+func auditHash(prev string, r slog.Record) string {
+	b, err := json.Marshal(wire.FromRecord(r))
+	if err != nil {
+		// FromRecord only holds values encoding/json can marshal, with NaN and infinities as
+		// strings.
+		panic(err)
+	}
+
+	h := sha256.New()
+	h.Write([]byte(prev))
+	h.Write(b)
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// Level returns the highest slog.Level of the records
+func (a *Audit) Level() slog.Level { return a.e.Level() }
+
+// Records returns a copy of the records
+func (a *Audit) Records() []slog.Record { return a.e.Records() }
+
+// String returns all records as a single string
+func (a *Audit) String() string { return a.e.String() }
+
+// MarshalJSON converts the records to a JSON array
+func (a *Audit) MarshalJSON() ([]byte, error) { return a.e.MarshalJSON() }
+
+// Log writes all records using the logger handler
+func (a *Audit) Log() error { return a.e.Log() }
+
+// WriteNDJSON writes every record to w as one JSON object per line
+func (a *Audit) WriteNDJSON(w io.Writer) error { return a.e.WriteNDJSON(w) }
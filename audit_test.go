@@ -0,0 +1,144 @@
+package serrors
+
+import (
+	"log/slog"
+	"math"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSErrorsAudit(t *testing.T) {
+	a := NewAudit(nil, nil, WithSampler(func(slog.Record) bool { return false }))
+	a.Add(testTime, slog.LevelInfo, "login", slog.String("user", "bob"))
+	a.AddAny(testTime, slog.LevelWarn, "denied", "path", "/admin")
+	a.AddRecord(slog.NewRecord(testTime, slog.LevelError, "locked", 0))
+
+	rs := a.Records()
+	if len(rs) != 3 {
+		t.Fatalf("\ngot  %d records\nwant 3 unsampled", len(rs))
+	}
+
+	for i, r := range rs {
+		if v, _ := GetAttrPath(r, AuditSeqKey); v.Uint64() != uint64(i+1) {
+			t.Fatalf("\ngot  seq %s\nwant %d", v, i+1)
+		}
+	}
+
+	prev, _ := GetAttrPath(rs[1], AuditPrevHashKey)
+	hash, _ := GetAttrPath(rs[0], AuditHashKey)
+	if prev.String() != hash.String() || len(hash.String()) != 64 {
+		t.Fatalf("\ngot  %s\nwant %s", prev, hash)
+	}
+
+	if err := a.Verify(); err != nil {
+		t.Fatalf("\ngot  %s\nwant nil", err)
+	}
+
+	if a.Level() != slog.LevelError {
+		t.Fatalf("\ngot  %s\nwant %s", a.Level(), slog.LevelError)
+	}
+}
+
+func TestSErrorsAuditTampered(t *testing.T) {
+	tests := []struct {
+		name   string
+		tamper func(rs []slog.Record) []slog.Record
+		want   string
+	}{
+		{
+			name: "changed",
+			tamper: func(rs []slog.Record) []slog.Record {
+				rs[1].Message = "allowed"
+				return rs
+			},
+			want: "record 2: hash",
+		},
+		{
+			name:   "removed",
+			tamper: func(rs []slog.Record) []slog.Record { return append(rs[:1], rs[2:]...) },
+			want:   "record 2: sequence",
+		},
+		{
+			name: "swapped",
+			tamper: func(rs []slog.Record) []slog.Record {
+				rs[0], rs[1] = rs[1], rs[0]
+				return rs
+			},
+			want: "record 1: sequence",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			a := NewAudit(nil, nil)
+			for _, msg := range []string{"a", "b", "c"} {
+				a.Add(testTime, slog.LevelInfo, msg)
+			}
+
+			a.e.records = test.tamper(a.e.records)
+			err := a.Verify()
+			if err == nil || !strings.Contains(err.Error(), test.want) {
+				t.Fatalf("\ngot  %v\nwant %s", err, test.want)
+			}
+		})
+	}
+}
+
+func TestSErrorsAuditSpill(t *testing.T) {
+	a := NewAudit(nil, nil, WithSpillDir(t.TempDir(), 2))
+	defer a.e.RemoveSpill()
+	for i := 0; i < 6; i++ {
+		a.Add(testTime, slog.LevelInfo, "m", slog.Int("i", i), slog.Any("m", map[string]any{"b": 1.5, "a": true}))
+	}
+
+	if err := a.Verify(); err != nil {
+		t.Fatalf("\ngot  %s\nwant nil", err)
+	}
+}
+
+func TestSErrorsAuditFloats(t *testing.T) {
+	a := NewAudit(nil, nil, WithSpillDir(t.TempDir(), 2))
+	defer a.e.RemoveSpill()
+	for _, f := range []float64{math.NaN(), math.Inf(1), math.Inf(-1), 1.5} {
+		a.Add(testTime, slog.LevelInfo, "m", slog.Float64("x", f))
+	}
+
+	if err := a.Verify(); err != nil {
+		t.Fatalf("\ngot  %s\nwant nil", err)
+	}
+}
+
+func TestSErrorsAuditUserKeys(t *testing.T) {
+	a := NewAudit(nil, nil)
+	a.Add(testTime, slog.LevelInfo, "m", slog.Int("seq", 7), slog.Int(AuditHashKey, 1))
+	if err := a.Verify(); err != nil {
+		t.Fatalf("\ngot  %s\nwant nil", err)
+	}
+
+	r := slog.NewRecord(testTime, slog.LevelInfo, "keys", 0)
+	r.AddAttrs(slog.Int(AuditSeqKey, 2), slog.String(AuditPrevHashKey, ""), slog.String(AuditHashKey, ""))
+	a.AddRecord(r)
+	if err := a.Verify(); err != nil {
+		t.Fatalf("\ngot  %s\nwant nil", err)
+	}
+}
+
+func TestSErrorsAuditKeepsRecords(t *testing.T) {
+	a := NewAudit(nil, nil,
+		WithClock(fixedClock(testTime.Add(time.Hour))),
+		WithRecordTTL(time.Minute),
+		WithMaxRecords(1, DropOldest),
+		WithFlushEvery(1),
+	)
+	a.Add(testTime, slog.LevelInfo, "a")
+	a.Add(testTime, slog.LevelInfo, "b")
+
+	if n := len(a.Records()); n != 2 {
+		t.Fatalf("\ngot  %d records\nwant 2", n)
+	}
+
+	if err := a.Verify(); err != nil {
+		t.Fatalf("\ngot  %s\nwant nil", err)
+	}
+}
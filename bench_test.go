@@ -0,0 +1,145 @@
+package serrors
+
+import (
+	"fmt"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+)
+
+// benchSizes are the collection sizes the benchmarks run at
+var benchSizes = []int{10, 1_000, 100_000}
+
+// benchCollection returns a collection holding n records
+func benchCollection(b *testing.B, json bool, n int) *SErrors {
+	b.Helper()
+
+	e := NewTextHandler(io.Discard, nil)
+	if json {
+		e = NewJSONHandler(io.Discard, nil)
+	}
+
+	for i := range n {
+		l := slog.LevelError
+		if i%2 == 0 {
+			l = slog.LevelInfo
+		}
+		e.Add(testTime, l, "m", slog.Int("i", i), slog.String("path", "/var/lib/app"))
+	}
+
+	return e
+}
+
+// benchEach runs fn as a sub-benchmark for each format and size
+func benchEach(b *testing.B, fn func(b *testing.B, e *SErrors)) {
+	for _, json := range []bool{false, true} {
+		name := "text"
+		if json {
+			name = "json"
+		}
+
+		for _, n := range benchSizes {
+			b.Run(fmt.Sprintf("%s/%d", name, n), func(b *testing.B) {
+				e := benchCollection(b, json, n)
+				b.ReportAllocs()
+				b.ResetTimer()
+				fn(b, e)
+			})
+		}
+	}
+}
+
+func BenchmarkSErrorsAdd(b *testing.B) {
+	e := New(io.Discard, nil)
+	attrs := []slog.Attr{slog.Int("i", 1), slog.String("path", "/var/lib/app")}
+	b.ReportAllocs()
+	for range b.N {
+		e.Add(testTime, slog.LevelError, "m", attrs...)
+	}
+}
+
+func BenchmarkSErrorsString(b *testing.B) {
+	benchEach(b, func(b *testing.B, e *SErrors) {
+		for range b.N {
+			_ = e.String()
+		}
+	})
+}
+
+func BenchmarkSErrorsMarshalJSON(b *testing.B) {
+	benchEach(b, func(b *testing.B, e *SErrors) {
+		for range b.N {
+			if _, err := e.MarshalJSON(); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
+
+func BenchmarkSErrorsLog(b *testing.B) {
+	benchEach(b, func(b *testing.B, e *SErrors) {
+		for range b.N {
+			if err := e.Log(); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
+
+func BenchmarkSErrorsAddArena(b *testing.B) {
+	e := New(io.Discard, nil, WithArena(1_000))
+	attrs := []slog.Attr{slog.Int("i", 1), slog.String("path", "/var/lib/app")}
+	b.ReportAllocs()
+	for range b.N {
+		e.Add(testTime, slog.LevelError, "m", attrs...)
+	}
+}
+
+func BenchmarkSErrorsAddMaxRecords(b *testing.B) {
+	e := New(io.Discard, nil, WithMaxRecords(10_000, DropOldest), WithRenderCache())
+	attrs := []slog.Attr{slog.Int("i", 1), slog.String("path", "/var/lib/app")}
+	b.ReportAllocs()
+	for range b.N {
+		e.Add(testTime, slog.LevelError, "m", attrs...)
+	}
+}
+
+func BenchmarkSErrorsRtoString(b *testing.B) {
+	benchEach(b, func(b *testing.B, e *SErrors) {
+		rs := e.Records()
+		for i := range b.N {
+			_ = e.RtoString(rs[i%len(rs)])
+		}
+	})
+}
+
+func BenchmarkSErrorsStringRenderCache(b *testing.B) {
+	for _, n := range benchSizes {
+		b.Run(fmt.Sprint(n), func(b *testing.B) {
+			e := New(io.Discard, nil, WithRenderCache())
+			for i := range n {
+				e.Add(testTime, slog.LevelError, "m", slog.Int("i", i))
+			}
+			b.ReportAllocs()
+			b.ResetTimer()
+			for range b.N {
+				_ = e.String()
+			}
+		})
+	}
+}
+
+func BenchmarkSErrorsWindow(b *testing.B) {
+	e := New(io.Discard, nil)
+	for i := range 100_000 {
+		e.Add(testTime.Add(time.Duration(i)*time.Second), slog.LevelError, "m")
+	}
+	from := testTime.Add(50_000 * time.Second)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for range b.N {
+		e.Window(from, from.Add(time.Minute))
+	}
+}
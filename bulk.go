@@ -0,0 +1,287 @@
+package serrors
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// Defaults used by NewBulkUploader
+const (
+	DefaultMaxPayload    = 1 << 20
+	DefaultFlushInterval = 5 * time.Second
+	DefaultDeadLetterMax = 100
+	DefaultSendTimeout   = 30 * time.Second
+)
+
+// BulkOptions configures a BulkUploader
+type BulkOptions struct {
+	// URL the batches are POSTed to
+	URL string
+	// Client sends the requests. Defaults to http.DefaultClient.
+	Client *http.Client
+	// Header is added to every request, e.g. Authorization
+	Header http.Header
+	// MaxPayload is the most uncompressed NDJSON bytes sent in one request. A single record larger
+	// than MaxPayload is sent on its own. Defaults to DefaultMaxPayload.
+	MaxPayload int
+	// FlushInterval is how often buffered records are sent. Defaults to DefaultFlushInterval and a
+	// negative value disables the timer so only Flush and a full payload send.
+	FlushInterval time.Duration
+	// DeadLetter receives the batches that failed to send. Defaults to
+	// NewDeadLetter(DefaultDeadLetterMax).
+	DeadLetter *DeadLetter
+	// Compression of the request bodies, sent as their Content-Encoding. Defaults to
+	// CompressionGzip.
+	Compression Compression
+	// SendTimeout bounds the requests sent by Write and the flush timer, which have no context of
+	// their own. Defaults to DefaultSendTimeout.
+	SendTimeout time.Duration
+}
+
+// BulkUploader is the shared transport for HTTP sinks. It is an io.Writer that takes one NDJSON
+// record per Write, as written by the slog JSON handler, so it can be the logWriter of New:
+//
+//	u := serrors.NewBulkUploader(serrors.BulkOptions{URL: "https://logs.example.com/bulk"})
+//	errs := serrors.New(u, nil)
+//	...
+//	errs.Log()
+//	u.Close(ctx)
+//
+// Records are buffered until MaxPayload is reached or FlushInterval passes, then POSTed as a
+// compressed NDJSON body. Batches are sent in the order they were filled, without holding up the
+// Writes that do not fill one. Batches that fail are added to the DeadLetter.
+type BulkUploader struct {
+	opts BulkOptions
+	// mu guards pending, count and taken
+	mu      sync.Mutex
+	pending bytes.Buffer
+	count   int
+	// taken numbers the batches taken from pending
+	taken uint64
+	// sendMu guards sent, the number of the next batch to send, and is held while sending
+	sendMu sync.Mutex
+	sent   uint64
+	turn   *sync.Cond
+	// done stops the flush timer
+	done      chan struct{}
+	closeOnce sync.Once
+	wg        sync.WaitGroup
+}
+
+// NewBulkUploader creates a BulkUploader and starts its flush timer
+func NewBulkUploader(opts BulkOptions) *BulkUploader {
+	if opts.Client == nil {
+		opts.Client = http.DefaultClient
+	}
+
+	if opts.MaxPayload <= 0 {
+		opts.MaxPayload = DefaultMaxPayload
+	}
+
+	if opts.FlushInterval == 0 {
+		opts.FlushInterval = DefaultFlushInterval
+	}
+
+	if opts.DeadLetter == nil {
+		opts.DeadLetter = NewDeadLetter(DefaultDeadLetterMax)
+	}
+
+	if opts.Compression == "" {
+		opts.Compression = CompressionGzip
+	}
+
+	if opts.SendTimeout <= 0 {
+		opts.SendTimeout = DefaultSendTimeout
+	}
+
+	u := &BulkUploader{opts: opts, done: make(chan struct{})}
+	u.turn = sync.NewCond(&u.sendMu)
+	if opts.FlushInterval > 0 {
+		u.wg.Add(1)
+		go u.run()
+	}
+
+	return u
+}
+
+// DeadLetter returns the DeadLetter failed batches are added to
+func (u *BulkUploader) DeadLetter() *DeadLetter { return u.opts.DeadLetter }
+
+// Write buffers p as one record. If p does not fit in the current payload, the buffered records are
+// sent first, within SendTimeout; other Writes are not held up meanwhile. Send failures go to the
+// DeadLetter rather than failing the Write.
+func (u *BulkUploader) Write(p []byte) (int, error) {
+	u.mu.Lock()
+	var b *bulkBatch
+	if u.count > 0 && u.pending.Len()+len(p) > u.opts.MaxPayload {
+		b = u.take()
+	}
+
+	u.pending.Write(p)
+	if len(p) == 0 || p[len(p)-1] != '\n' {
+		u.pending.WriteByte('\n')
+	}
+	u.count++
+	u.mu.Unlock()
+
+	if b != nil {
+		ctx, cancel := context.WithTimeout(context.Background(), u.opts.SendTimeout)
+		defer cancel()
+		u.send(ctx, b)
+	}
+
+	return len(p), nil
+}
+
+// Flush sends the buffered records. A failed batch is added to the DeadLetter and its error
+// returned.
+func (u *BulkUploader) Flush(ctx context.Context) error {
+	u.mu.Lock()
+	b := u.take()
+	u.mu.Unlock()
+
+	return u.send(ctx, b)
+}
+
+// Close stops the flush timer and flushes the buffered records
+func (u *BulkUploader) Close(ctx context.Context) error {
+	u.closeOnce.Do(func() { close(u.done) })
+	u.wg.Wait()
+
+	return u.Flush(ctx)
+}
+
+// Retry sends the batches in the DeadLetter again, oldest first. Batches that fail again go back to
+// the DeadLetter and their errors are returned.
+func (u *BulkUploader) Retry(ctx context.Context) error {
+	u.sendMu.Lock()
+	defer u.sendMu.Unlock()
+
+	var errs []error
+	for _, b := range u.opts.DeadLetter.Drain() {
+		if err := u.post(ctx, b.Body); err != nil {
+			b.Time, b.Err = time.Now(), err
+			u.opts.DeadLetter.Add(b)
+			errs = append(errs, err)
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// run flushes every FlushInterval until Close
+func (u *BulkUploader) run() {
+	defer u.wg.Done()
+
+	t := time.NewTicker(u.opts.FlushInterval)
+	defer t.Stop()
+
+	for {
+		select {
+		case <-u.done:
+			return
+		case <-t.C:
+			ctx, cancel := context.WithTimeout(context.Background(), u.opts.SendTimeout)
+			u.Flush(ctx)
+			cancel()
+		}
+	}
+}
+
+// bulkBatch is a batch of records taken from the buffer to be sent
+type bulkBatch struct {
+	// seq numbers the batch in the order it was taken
+	seq     uint64
+	records int
+	body    []byte
+}
+
+// take returns the buffered records as a batch and empties the buffer, or nil if it is empty. u.mu
+// must be held.
+func (u *BulkUploader) take() *bulkBatch {
+	if u.count == 0 {
+		return nil
+	}
+
+	b := &bulkBatch{seq: u.taken, records: u.count, body: bytes.Clone(u.pending.Bytes())}
+	u.taken++
+	u.pending.Reset()
+	u.count = 0
+
+	return b
+}
+
+// send POSTs b once the batches taken before it are sent, adding it to the DeadLetter on failure.
+// u.mu must not be held.
+func (u *BulkUploader) send(ctx context.Context, b *bulkBatch) error {
+	if b == nil {
+		return nil
+	}
+
+	u.sendMu.Lock()
+	defer u.sendMu.Unlock()
+
+	for u.sent != b.seq {
+		u.turn.Wait()
+	}
+	defer u.turn.Broadcast()
+	u.sent++
+
+	err := u.post(ctx, b.body)
+	if err != nil {
+		u.opts.DeadLetter.Add(FailedBatch{Time: time.Now(), Err: err, Records: b.records, Body: b.body})
+	}
+
+	return err
+}
+
+// post compresses body and POSTs it to the URL
+func (u *BulkUploader) post(ctx context.Context, body []byte) error {
+	var c bytes.Buffer
+	w, err := u.opts.Compression.NewWriter(&c)
+	if err != nil {
+		return err
+	}
+
+	if _, err := w.Write(body); err != nil {
+		return err
+	}
+
+	if err := w.Close(); err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, u.opts.URL, &c)
+	if err != nil {
+		return err
+	}
+
+	for k, vs := range u.opts.Header {
+		for _, v := range vs {
+			req.Header.Add(k, v)
+		}
+	}
+	req.Header.Set("Content-Type", "application/x-ndjson")
+	if enc := u.opts.Compression.ContentEncoding(); enc != "" {
+		req.Header.Set("Content-Encoding", enc)
+	}
+
+	res, err := u.opts.Client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+	io.Copy(io.Discard, res.Body)
+
+	if res.StatusCode >= 300 {
+		return fmt.Errorf("serrors: bulk upload failed: %s", res.Status)
+	}
+
+	return nil
+}
@@ -0,0 +1,175 @@
+package serrors
+
+import (
+	"compress/gzip"
+	"context"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+type bulkServer struct {
+	mu     sync.Mutex
+	bodies []string
+	fail   bool
+}
+
+func (s *bulkServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if r.Header.Get("Content-Encoding") != "gzip" || r.Header.Get("X-Token") != "t" {
+		http.Error(w, "bad headers", http.StatusBadRequest)
+		return
+	}
+
+	if s.fail {
+		http.Error(w, "down", http.StatusServiceUnavailable)
+		return
+	}
+
+	gz, err := gzip.NewReader(r.Body)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	b, _ := io.ReadAll(gz)
+	s.bodies = append(s.bodies, string(b))
+}
+
+func (s *bulkServer) got() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return append([]string(nil), s.bodies...)
+}
+
+func TestBulkUploaderMaxPayload(t *testing.T) {
+	s := &bulkServer{}
+	srv := httptest.NewServer(s)
+	defer srv.Close()
+
+	line := `{"time":"2000-01-02T03:04:05Z","level":"ERROR","msg":"m"}` + "\n"
+	u := NewBulkUploader(BulkOptions{
+		URL:           srv.URL,
+		Header:        http.Header{"X-Token": {"t"}},
+		MaxPayload:    len(line) * 2,
+		FlushInterval: -1,
+	})
+
+	e := New(u, nil)
+	for i := 0; i < 5; i++ {
+		e.Error(testTime, "m")
+	}
+
+	if err := e.Log(); err != nil {
+		t.Fatalf("\ngot  %s\nwant nil", err.Error())
+	}
+
+	if err := u.Close(context.Background()); err != nil {
+		t.Fatalf("\ngot  %s\nwant nil", err.Error())
+	}
+
+	want := []string{line + line, line + line, line}
+	got := s.got()
+	if strings.Join(got, "|") != strings.Join(want, "|") {
+		t.Fatalf("\ngot  %q\nwant %q", got, want)
+	}
+}
+
+func TestBulkUploaderFlushInterval(t *testing.T) {
+	s := &bulkServer{}
+	srv := httptest.NewServer(s)
+	defer srv.Close()
+
+	u := NewBulkUploader(BulkOptions{URL: srv.URL, Header: http.Header{"X-Token": {"t"}}, FlushInterval: 10 * time.Millisecond})
+	defer u.Close(context.Background())
+
+	e := New(u, nil)
+	e.Error(testTime, "m", slog.Int("a", 1))
+	e.Log()
+
+	for i := 0; i < 100 && len(s.got()) == 0; i++ {
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	want := `{"time":"2000-01-02T03:04:05Z","level":"ERROR","msg":"m","a":1}` + "\n"
+	if got := s.got(); len(got) != 1 || got[0] != want {
+		t.Fatalf("\ngot  %q\nwant %q", got, want)
+	}
+}
+
+func TestBulkUploaderDeadLetter(t *testing.T) {
+	s := &bulkServer{fail: true}
+	srv := httptest.NewServer(s)
+	defer srv.Close()
+
+	u := NewBulkUploader(BulkOptions{URL: srv.URL, Header: http.Header{"X-Token": {"t"}}, FlushInterval: -1})
+	u.Write([]byte(`{"msg":"a"}`))
+	u.Write([]byte(`{"msg":"b"}` + "\n"))
+
+	err := u.Flush(context.Background())
+	want := "serrors: bulk upload failed: 503 Service Unavailable"
+	if err == nil || err.Error() != want {
+		t.Fatalf("\ngot  %v\nwant %s", err, want)
+	}
+
+	bs := u.DeadLetter().Batches()
+	if len(bs) != 1 || bs[0].Records != 2 || string(bs[0].Body) != "{\"msg\":\"a\"}\n{\"msg\":\"b\"}\n" {
+		t.Fatalf("\ngot  %v\nwant 1 batch of 2 records", bs)
+	}
+
+	if err := u.Flush(context.Background()); err != nil {
+		t.Fatalf("\ngot  %s\nwant nil for empty flush", err.Error())
+	}
+}
+
+func TestBulkUploaderSlowEndpoint(t *testing.T) {
+	release := make(chan struct{})
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-release:
+		case <-r.Context().Done():
+		}
+	}))
+	defer srv.Close()
+	defer close(release)
+
+	line := []byte(`{"msg":"m"}` + "\n")
+	u := NewBulkUploader(BulkOptions{
+		URL:           srv.URL,
+		MaxPayload:    len(line) * 3,
+		FlushInterval: -1,
+		SendTimeout:   50 * time.Millisecond,
+	})
+
+	for range 3 {
+		u.Write(line)
+	}
+	sent := make(chan struct{})
+	go func() {
+		defer close(sent)
+		u.Write(line)
+	}()
+
+	// The Write sending the first batch waits on the endpoint, but not the Writes after it.
+	time.Sleep(10 * time.Millisecond)
+	start := time.Now()
+	for range 2 {
+		u.Write(line)
+	}
+	if d := time.Since(start); d > 20*time.Millisecond {
+		t.Fatalf("\ngot  %s\nwant no wait", d)
+	}
+
+	<-sent
+	if n := u.DeadLetter().Len(); n != 1 {
+		t.Fatalf("\ngot  %d\nwant 1 timed out batch", n)
+	}
+}
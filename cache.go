@@ -0,0 +1,92 @@
+package serrors
+
+import (
+	"encoding/json"
+	"slices"
+	"strings"
+	"sync"
+)
+
+// renderCache holds the rendered output of the records in memory, in order. Records are only ever
+// appended to the cached prefix; any other change to the records or their rendering drops it.
+type renderCache struct {
+	// mu guards the cache while concurrent readers, which only hold e.mu for reading, extend it
+	mu sync.Mutex
+	// text is the output of the handler of e for each record
+	text []string
+	// json is the JSON object of each record as written by MarshalJSON
+	json []json.RawMessage
+}
+
+// WithRenderCache caches the output of String and MarshalJSON per record, so rendering a
+// collection again, e.g. to log it and return it in an HTTP response, only renders the records
+// added since. Removing a record, including one dropped by WithMaxRecords, only drops its own
+// output; sorting or annotating records and changing the verbosity drop the cache. It costs about
+// the size of the output in memory.
+func WithRenderCache() Option {
+	return func(e *SErrors) {
+		e.cache = &renderCache{}
+	}
+}
+
+// invalidate drops the cache after the records have changed other than by appending. e.mu must
+// be held for writing.
+func (e *SErrors) invalidate() {
+	if e.cache != nil {
+		e.cache.text, e.cache.json = nil, nil
+	}
+}
+
+// invalidateAt drops the cached output of the record at index i after it has been removed, keeping
+// the output of the others. e.mu must be held for writing.
+func (e *SErrors) invalidateAt(i int) {
+	c := e.cache
+	if c == nil {
+		return
+	}
+
+	if i < len(c.text) {
+		c.text = slices.Delete(c.text, i, i+1)
+	}
+
+	if i < len(c.json) {
+		c.json = slices.Delete(c.json, i, i+1)
+	}
+}
+
+// resetRender drops the pooled writers and cached output after the format or handler options of e
+// change. e.mu must be held for writing.
+func (e *SErrors) resetRender() {
+	e.writers = sync.Pool{}
+	e.invalidate()
+}
+
+// cachedString renders the records not yet in the cache and returns them all joined. e.mu must be
+// held.
+func (e *SErrors) cachedString() string {
+	c := e.cache
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for _, r := range e.records[len(c.text):] {
+		c.text = append(c.text, e.render(r))
+	}
+
+	return strings.Join(c.text, "")
+}
+
+// cachedJSON renders the records not yet in the cache and returns them all as JSON objects. e.mu
+// must be held.
+func (e *SErrors) cachedJSON() ([]json.RawMessage, error) {
+	c := e.cache
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	raw, err := jsonRecords(e.records[len(c.json):], e.jsonOpts(), e.profiled)
+	if err != nil {
+		return nil, err
+	}
+
+	c.json = append(c.json, raw...)
+	return c.json, nil
+}
@@ -0,0 +1,100 @@
+package serrors
+
+import (
+	"log/slog"
+	"testing"
+)
+
+func TestSErrorsRenderCache(t *testing.T) {
+	renders := 0
+	opts := &slog.HandlerOptions{ReplaceAttr: func(_ []string, a slog.Attr) slog.Attr {
+		if a.Key == slog.MessageKey {
+			renders++
+		}
+		return a
+	}}
+
+	for _, json := range []bool{false, true} {
+		e := NewTextHandler(nil, opts, WithRenderCache())
+		plain := NewTextHandler(nil, nil)
+		if json {
+			e = NewJSONHandler(nil, opts, WithRenderCache())
+			plain = NewJSONHandler(nil, nil)
+		}
+
+		check := func(name string, want int) {
+			t.Helper()
+			renders = 0
+			got := e.String()
+			b, err := e.MarshalJSON()
+			if err != nil {
+				t.Fatal(err)
+			}
+			wantJSON, _ := plain.MarshalJSON()
+
+			if got != plain.String() || string(b) != string(wantJSON) {
+				t.Fatalf("%s:\ngot  %s%s\nwant %s%s", name, got, b, plain.String(), wantJSON)
+			}
+
+			if renders != want {
+				t.Fatalf("%s renders:\ngot  %d\nwant %d", name, renders, want)
+			}
+		}
+
+		both := func(fn func(e *SErrors)) {
+			fn(e)
+			fn(plain)
+		}
+
+		both(func(e *SErrors) {
+			e.Info(testTime, "b", slog.Int("i", 2))
+			e.Error(testTime, "a", slog.Int("i", 1))
+		})
+		check("first", 4)
+		check("again", 0)
+
+		both(func(e *SErrors) { e.Warn(testTime, "c") })
+		check("added", 2)
+
+		both(func(e *SErrors) { e.Annotate(0, slog.Bool("retried", true)) })
+		check("annotated", 6)
+
+		both(func(e *SErrors) { e.SortByLevel() })
+		check("sorted", 6)
+
+		// Only the output of the removed record is dropped.
+		both(func(e *SErrors) { e.Remove(0) })
+		check("removed", 0)
+
+		both(func(e *SErrors) { e.RenderVerbosity(VerbosityQuiet) })
+		check("verbosity", 4)
+	}
+}
+
+func TestSErrorsRenderCacheMaxRecords(t *testing.T) {
+	renders := 0
+	opts := &slog.HandlerOptions{ReplaceAttr: func(_ []string, a slog.Attr) slog.Attr {
+		if a.Key == slog.MessageKey {
+			renders++
+		}
+		return a
+	}}
+
+	e := NewTextHandler(nil, opts, WithRenderCache(), WithMaxRecords(3, DropOldest))
+	for _, m := range []string{"a", "b", "c", "d", "e"} {
+		e.Info(testTime, m)
+		_ = e.String()
+	}
+
+	// Each String renders only the record just added, even once the oldest are dropped.
+	if renders != 5 {
+		t.Fatalf("\ngot  %d renders\nwant 5", renders)
+	}
+
+	want := "time=2000-01-02T03:04:05.000Z level=INFO msg=c\n" +
+		"time=2000-01-02T03:04:05.000Z level=INFO msg=d\n" +
+		"time=2000-01-02T03:04:05.000Z level=INFO msg=e\n"
+	if got := e.String(); got != want {
+		t.Fatalf("\ngot  %s\nwant %s", got, want)
+	}
+}
@@ -0,0 +1,26 @@
+package serrors
+
+import "runtime"
+
+// WithCaller records the file and line of the code that added each record in its PC, which the
+// handlers write as the source attr when slog.HandlerOptions.AddSource is set. By default the
+// caller of the serrors method is recorded; skip moves that many frames further up, for helpers
+// that wrap serrors. Capturing the caller costs a runtime.Callers call per record.
+func WithCaller(skip int) Option {
+	return func(e *SErrors) {
+		e.caller, e.callerSkip = true, max(skip, 0)
+	}
+}
+
+// pc returns the program counter of the caller of the exported method calling pc, or 0 if e does
+// not use WithCaller
+func (e *SErrors) pc() uintptr {
+	if !e.caller {
+		return 0
+	}
+
+	// Skip runtime.Callers, pc and the exported method.
+	var pcs [1]uintptr
+	runtime.Callers(3+e.callerSkip, pcs[:])
+	return pcs[0]
+}
@@ -0,0 +1,55 @@
+package serrors
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"log/slog"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+func TestSErrorsWithCaller(t *testing.T) {
+	got := bytes.NewBuffer(nil)
+	e := NewTextHandler(got, &slog.HandlerOptions{AddSource: true}, WithCaller(0))
+	e.Add(testTime, slog.LevelInfo, "a")
+	e.Error(testTime, "b")
+	e.WarnAny(testTime, "c", "k", 1)
+	e.InfoNow("d")
+	e.AddError(testTime, errors.New("e"))
+	e.Begin("f").End(nil)
+	e.StopWatch("g")(slog.LevelInfo)
+
+	helper := func() { e.Error(testTime, "h") }
+	helper()
+
+	if err := e.Log(); err != nil {
+		t.Fatalf("\ngot  %s\nwant nil", err.Error())
+	}
+
+	lines := strings.Split(strings.TrimSpace(got.String()), "\n")
+	for _, line := range lines {
+		if !strings.Contains(line, "source=") || !strings.Contains(line, "caller_test.go:") {
+			t.Fatalf("\ngot  %s\nwant a caller_test.go source", line)
+		}
+	}
+
+	got.Reset()
+	w := NewTextHandler(got, &slog.HandlerOptions{AddSource: true}, WithCaller(1))
+	wrap := func(msg string) { w.Error(testTime, msg) }
+	_, _, line, _ := runtime.Caller(0)
+	wrap("wrapped")
+	w.Log()
+	if want := fmt.Sprintf("caller_test.go:%d", line+1); !strings.Contains(got.String(), want) {
+		t.Fatalf("\ngot  %s\nwant %s", got, want)
+	}
+
+	got.Reset()
+	n := NewTextHandler(got, &slog.HandlerOptions{AddSource: true})
+	n.Error(testTime, "none")
+	n.Log()
+	if strings.Contains(got.String(), "caller_test.go") {
+		t.Fatalf("\ngot  %s\nwant no source", got)
+	}
+}
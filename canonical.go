@@ -0,0 +1,158 @@
+package serrors
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// WithCanonicalJSON makes MarshalJSON write canonical JSON so serialized collections can be hashed,
+// signed and diffed byte for byte: object keys are sorted, including the time, level and msg keys
+// of records, there is no whitespace or HTML escaping, integers are written as is and other
+// numbers in their shortest form. Repeated keys, such as an attr named msg or two attrs with the
+// same key, are all kept, in the order they were written.
+func WithCanonicalJSON() Option {
+	return func(e *SErrors) {
+		e.canonical = true
+	}
+}
+
+// canonicalObject is a JSON object with its members in document order. Decoding into a map would
+// keep only the last of repeated keys.
+type canonicalObject []canonicalMember
+
+// canonicalMember is a key and value of a canonicalObject
+type canonicalMember struct {
+	key   string
+	value any
+}
+
+// canonicalJSON re-encodes the JSON document b in canonical form
+func canonicalJSON(b []byte) ([]byte, error) {
+	dec := json.NewDecoder(bytes.NewReader(b))
+	dec.UseNumber()
+
+	v, err := decodeCanonical(dec)
+	if err != nil {
+		return nil, fmt.Errorf("serrors: canonical json: %w", err)
+	}
+
+	var out bytes.Buffer
+	if err := writeCanonical(&out, v); err != nil {
+		return nil, err
+	}
+
+	return out.Bytes(), nil
+}
+
+// decodeCanonical decodes the next value of dec from its tokens: objects as canonicalObject,
+// arrays as []any and other values as the tokens of dec, which must use UseNumber
+func decodeCanonical(dec *json.Decoder) (any, error) {
+	tok, err := dec.Token()
+	if err != nil {
+		return nil, err
+	}
+
+	switch tok {
+	case json.Delim('{'):
+		obj := canonicalObject{}
+		for dec.More() {
+			key, err := dec.Token()
+			if err != nil {
+				return nil, err
+			}
+
+			v, err := decodeCanonical(dec)
+			if err != nil {
+				return nil, err
+			}
+			obj = append(obj, canonicalMember{key: key.(string), value: v})
+		}
+
+		_, err = dec.Token()
+		return obj, err
+	case json.Delim('['):
+		arr := []any{}
+		for dec.More() {
+			v, err := decodeCanonical(dec)
+			if err != nil {
+				return nil, err
+			}
+			arr = append(arr, v)
+		}
+
+		_, err = dec.Token()
+		return arr, err
+	}
+
+	return tok, nil
+}
+
+// writeCanonical writes v, decoded with decodeCanonical, to b in canonical form
+func writeCanonical(b *bytes.Buffer, v any) error {
+	switch v := v.(type) {
+	case canonicalObject:
+		// The sort is stable so repeated keys keep their order.
+		members := slices.Clone(v)
+		slices.SortStableFunc(members, func(a, b canonicalMember) int {
+			return strings.Compare(a.key, b.key)
+		})
+
+		b.WriteByte('{')
+		for i, m := range members {
+			if i > 0 {
+				b.WriteByte(',')
+			}
+
+			if err := writeCanonical(b, m.key); err != nil {
+				return err
+			}
+
+			b.WriteByte(':')
+			if err := writeCanonical(b, m.value); err != nil {
+				return err
+			}
+		}
+		b.WriteByte('}')
+	case []any:
+		b.WriteByte('[')
+		for i, e := range v {
+			if i > 0 {
+				b.WriteByte(',')
+			}
+
+			if err := writeCanonical(b, e); err != nil {
+				return err
+			}
+		}
+		b.WriteByte(']')
+	case json.Number:
+		// Integers of any size are written as is, which covers uint64 and big.Int values.
+		if !strings.ContainsAny(string(v), ".eE") {
+			b.WriteString(string(v))
+			return nil
+		}
+
+		f, err := v.Float64()
+		if err != nil {
+			return err
+		}
+
+		b.WriteString(strconv.FormatFloat(f, 'g', -1, 64))
+	default:
+		// Strings, booleans and null. HTML characters are not escaped.
+		var s bytes.Buffer
+		enc := json.NewEncoder(&s)
+		enc.SetEscapeHTML(false)
+		if err := enc.Encode(v); err != nil {
+			return err
+		}
+
+		b.Write(bytes.TrimSuffix(s.Bytes(), []byte("\n")))
+	}
+
+	return nil
+}
@@ -0,0 +1,77 @@
+package serrors
+
+import (
+	"log/slog"
+	"math"
+	"testing"
+)
+
+func TestSErrorsCanonicalJSON(t *testing.T) {
+	tests := []struct {
+		name    string
+		options []Option
+		want    string
+	}{
+		{
+			name:    "array",
+			options: []Option{WithCanonicalJSON()},
+			want: `[{"a":{"x":1,"y":2},"f":1.5,"g":1e+21,"level":"ERROR","m":{"k1":"v","k2":true},` +
+				`"msg":"m","time":"2000-01-02T03:04:05Z","u":18446744073709551615,"z":"<"}]`,
+		},
+		{
+			name:    "meta",
+			options: []Option{WithCanonicalJSON(), WithMetaBlock()},
+			want: `{"errors":[{"a":{"x":1,"y":2},"f":1.5,"g":1e+21,"level":"ERROR","m":{"k1":"v","k2":true},` +
+				`"msg":"m","time":"2000-01-02T03:04:05Z","u":18446744073709551615,"z":"<"}],"meta":{"id":7,"job":"a"}}`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			e := New(nil, nil, test.options...)
+			e.SetMeta("job", "a")
+			e.SetMeta("id", 7)
+			e.Add(testTime, slog.LevelError, "m",
+				slog.String("z", "<"),
+				slog.Group("a", slog.Int("y", 2), slog.Int("x", 1)),
+				slog.Float64("f", 1.50),
+				slog.Float64("g", 1e21),
+				slog.Uint64("u", math.MaxUint64),
+				slog.Any("m", map[string]any{"k2": true, "k1": "v"}),
+			)
+
+			got, err := e.MarshalJSON()
+			if err != nil {
+				t.Fatalf("\ngot  %s\nwant nil", err)
+			}
+
+			if string(got) != test.want {
+				t.Fatalf("\ngot  %s\nwant %s", got, test.want)
+			}
+		})
+	}
+}
+
+func TestSErrorsCanonicalJSONText(t *testing.T) {
+	e := NewTextHandler(nil, nil, WithCanonicalJSON())
+	e.Add(testTime, slog.LevelError, "m")
+
+	want := `[{"level":"ERROR","msg":"m","time":"2000-01-02T03:04:05Z"}]`
+	got, err := e.MarshalJSON()
+	if err != nil || string(got) != want {
+		t.Fatalf("\ngot  %s, %v\nwant %s", got, err, want)
+	}
+}
+
+func TestSErrorsCanonicalJSONRepeatedKeys(t *testing.T) {
+	e := New(nil, nil, WithCanonicalJSON())
+	e.Add(testTime, slog.LevelError, "m", slog.String("msg", "user"), slog.Int("a", 2), slog.Int("a", 1))
+	e.Add(testTime, slog.LevelError, "m", slog.Int("a", 1))
+
+	want := `[{"a":2,"a":1,"level":"ERROR","msg":"m","msg":"user","time":"2000-01-02T03:04:05Z"},` +
+		`{"a":1,"level":"ERROR","msg":"m","time":"2000-01-02T03:04:05Z"}]`
+	got, err := e.MarshalJSON()
+	if err != nil || string(got) != want {
+		t.Fatalf("\ngot  %s, %v\nwant %s", got, err, want)
+	}
+}
@@ -0,0 +1,90 @@
+package serrors
+
+import "log/slog"
+
+// DropPolicy decides which record is dropped when a collection created with WithMaxRecords is full
+type DropPolicy int
+
+const (
+	// DropOldest drops the oldest record to make room, like a ring buffer
+	DropOldest DropPolicy = iota
+	// DropLowestLevel drops the oldest record of the lowest level to make room. The new record is
+	// dropped instead if its level is lower than every record held.
+	DropLowestLevel
+	// RejectNew drops records added once the collection is full
+	RejectNew
+)
+
+// capacity bounds the records held in memory, see WithMaxRecords
+type capacity struct {
+	max     int
+	policy  DropPolicy
+	dropped int
+}
+
+// WithMaxRecords holds at most n records in memory, dropping records according to policy when a
+// record is added to a full collection, so long-running handlers do not grow without bound. Level
+// is lowered when the highest records are dropped. Dropped records are counted, see Dropped.
+// Records written by an Audit are not bounded.
+func WithMaxRecords(n int, policy DropPolicy) Option {
+	return func(e *SErrors) {
+		if n > 0 {
+			e.capacity = &capacity{max: n, policy: policy}
+		}
+	}
+}
+
+// Dropped returns the number of records dropped because the collection was full, see
+// WithMaxRecords, or because they could not be spilled, see WithSpillDir
+func (e *SErrors) Dropped() int {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+
+	n := 0
+	if e.capacity != nil {
+		n += e.capacity.dropped
+	}
+
+	if e.spill != nil {
+		n += e.spill.dropped
+	}
+
+	return n
+}
+
+// makeRoom drops a record if the collection is full and reports whether r should be stored. e.mu
+// must be held for writing.
+func (e *SErrors) makeRoom(r slog.Record) bool {
+	c := e.capacity
+	if c == nil || len(e.records) < c.max {
+		return true
+	}
+
+	c.dropped++
+	i := 0
+	switch c.policy {
+	case RejectNew:
+		return false
+	case DropLowestLevel:
+		for j, o := range e.records {
+			if o.Level < e.records[i].Level {
+				i = j
+			}
+		}
+
+		if r.Level < e.records[i].Level {
+			return false
+		}
+	}
+
+	l, resolved := e.records[i].Level, e.isResolved(i)
+	e.deleteRecord(i)
+	e.invalidateAt(i)
+
+	// r is stored next, so the level only changes if the highest record was dropped and r is lower.
+	if !resolved && l >= e.level && r.Level < l {
+		e.recomputeLevel()
+	}
+
+	return true
+}
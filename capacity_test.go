@@ -0,0 +1,59 @@
+package serrors
+
+import (
+	"log/slog"
+	"testing"
+)
+
+func TestSErrorsWithMaxRecords(t *testing.T) {
+	tests := []struct {
+		name   string
+		policy DropPolicy
+		want   string
+		level  slog.Level
+	}{
+		{
+			name:   "oldest",
+			policy: DropOldest,
+			want: "time=2000-01-02T03:04:05.000Z level=INFO msg=c\n" +
+				"time=2000-01-02T03:04:05.000Z level=DEBUG msg=d\n",
+			level: slog.LevelInfo,
+		},
+		{
+			name:   "lowest level",
+			policy: DropLowestLevel,
+			want: "time=2000-01-02T03:04:05.000Z level=ERROR msg=a\n" +
+				"time=2000-01-02T03:04:05.000Z level=INFO msg=c\n",
+			level: slog.LevelError,
+		},
+		{
+			name:   "reject new",
+			policy: RejectNew,
+			want: "time=2000-01-02T03:04:05.000Z level=ERROR msg=a\n" +
+				"time=2000-01-02T03:04:05.000Z level=DEBUG msg=b\n",
+			level: slog.LevelError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := NewTextHandler(nil, nil, WithMaxRecords(2, tt.policy))
+			e.Add(testTime, slog.LevelError, "a")
+			e.Add(testTime, slog.LevelDebug, "b")
+			e.Add(testTime, slog.LevelInfo, "c")
+			e.Add(testTime, slog.LevelDebug, "d")
+
+			if got := e.String(); got != tt.want {
+				t.Fatalf("\ngot  %s\nwant %s", got, tt.want)
+			}
+
+			if e.Level() != tt.level {
+				t.Fatalf("\ngot  %s\nwant %s", e.Level(), tt.level)
+			}
+
+			if e.Dropped() != 2 {
+				t.Fatalf("\ngot  %d\nwant 2", e.Dropped())
+			}
+		})
+	}
+}
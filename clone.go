@@ -0,0 +1,58 @@
+package serrors
+
+import (
+	"log/slog"
+	"maps"
+	"slices"
+)
+
+// Clone returns an independent copy of e for handing off to another goroutine. An SErrors must
+// not be copied by value: it holds a mutex, pooled render buffers and the state of Watch and
+// Follow. Clone copies the records with their attrs, meta fields, payloads and the options that
+// shape how records are added and rendered, so each copy can be added to, changed and rendered
+// without affecting the other. Both still write to the same logger and routes, which slog
+// handlers allow from any number of goroutines. Spilled records, the spill store, the WAL,
+// subscribers and the ticker of WithFlushInterval are not copied.
+func (e *SErrors) Clone() *SErrors {
+	e.expire()
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+
+	c := e.emptyCopy()
+	c.routes = e.routes
+	c.transforms = e.transforms
+	c.remap = e.remap
+	c.sampler, c.sampledOut = e.sampler, e.sampledOut
+	c.sourceHost, c.sourceID, c.provenance = e.sourceHost, e.sourceID, e.provenance
+	c.caller, c.callerSkip = e.caller, e.callerSkip
+	c.stackTraces, c.stackMin = e.stackTraces, e.stackMin
+	c.scope = e.scope
+	c.clock = e.clock
+	c.idGen = e.idGen
+	c.strictArgs = e.strictArgs
+	c.out = e.out
+	c.added = e.added
+	c.payloads = maps.Clone(e.payloads)
+	if e.cache != nil {
+		c.cache = &renderCache{}
+	}
+
+	if e.flush != nil {
+		c.flush = &flush{level: e.flush.level, onLevel: e.flush.onLevel, every: e.flush.every}
+	}
+
+	if e.capacity != nil {
+		cp := *e.capacity
+		c.capacity = &cp
+	}
+
+	c.seqs = slices.Clone(e.seqs)
+	c.records = make([]slog.Record, len(e.records))
+	for i, r := range e.records {
+		c.records[i] = r.Clone()
+	}
+	c.reindex()
+	c.recomputeLevel()
+
+	return c
+}
@@ -0,0 +1,57 @@
+package serrors
+
+import (
+	"log/slog"
+	"sync"
+	"testing"
+)
+
+func TestSErrorsClone(t *testing.T) {
+	e := NewTextHandler(nil, nil, WithRenderCache(), WithMaxRecords(3, RejectNew))
+	e.Error(testTime, "a", slog.Int("i", 1))
+	e.SetMeta("job", "nightly")
+	Attach(e, "k", 1)
+
+	c := e.Clone()
+	if got, want := c.String(), e.String(); got != want {
+		t.Fatalf("\ngot  %s\nwant %s", got, want)
+	}
+
+	if c.Level() != slog.LevelError {
+		t.Fatalf("\ngot  %s\nwant %s", c.Level(), slog.LevelError)
+	}
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		c.Annotate(0, slog.Bool("retried", true))
+		c.Info(testTime, "b")
+		c.Info(testTime, "c")
+		c.Info(testTime, "dropped")
+		Attach(c, "k", 2)
+	}()
+	e.Warn(testTime, "w")
+	wg.Wait()
+
+	want := "time=2000-01-02T03:04:05.000Z level=ERROR msg=a i=1\n" +
+		"time=2000-01-02T03:04:05.000Z level=WARN msg=w\n"
+	if got := e.String(); got != want {
+		t.Fatalf("\ngot  %s\nwant %s", got, want)
+	}
+
+	want = "time=2000-01-02T03:04:05.000Z level=ERROR msg=a i=1 retried=true\n" +
+		"time=2000-01-02T03:04:05.000Z level=INFO msg=b\n" +
+		"time=2000-01-02T03:04:05.000Z level=INFO msg=c\n"
+	if got := c.String(); got != want {
+		t.Fatalf("\ngot  %s\nwant %s", got, want)
+	}
+
+	if v, _ := Detach[int](e, "k"); v != 1 {
+		t.Fatalf("\ngot  %d\nwant 1", v)
+	}
+
+	if e.Dropped() != 0 || c.Dropped() != 1 {
+		t.Fatalf("\ngot  %d, %d dropped\nwant 0, 1", e.Dropped(), c.Dropped())
+	}
+}
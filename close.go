@@ -0,0 +1,117 @@
+package serrors
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// UndeliveredError is returned by Close when the log writer still holds batches it could not
+// deliver
+type UndeliveredError struct {
+	// Batches left in the log writer's DeadLetter, oldest first
+	Batches []FailedBatch
+}
+
+// Error implements error
+func (u *UndeliveredError) Error() string {
+	n := 0
+	for _, b := range u.Batches {
+		n += b.Records
+	}
+
+	return fmt.Sprintf("serrors: %d records in %d batches undelivered", n, len(u.Batches))
+}
+
+// Unwrap returns the errors the batches failed with
+func (u *UndeliveredError) Unwrap() []error {
+	errs := make([]error, len(u.Batches))
+	for i, b := range u.Batches {
+		errs[i] = b.Err
+	}
+
+	return errs
+}
+
+// Close shuts e down in one call for a service's shutdown path:
+//
+//   - Watch returns, and Close waits for it until ctx is done
+//   - the ticker of WithFlushInterval stops after a last flush
+//   - Follow and StreamHandler receive the records already sent to them and then end
+//   - a log writer with a Close(context.Context) error method, such as BulkUploader, is closed,
+//     which flushes its buffered records
+//   - a log writer with a Retry(context.Context) error method sends the batches in its DeadLetter
+//     again, and the ones that still fail are returned in an *UndeliveredError
+//   - the WAL is closed, see CloseWAL
+//   - the first error writing to the spill store is returned, see SpillErr
+//
+// Records can still be added and read after Close, but are no longer journaled or streamed. Close
+// returns every error joined and is a no-op after the first call.
+func (e *SErrors) Close(ctx context.Context) error {
+	first := false
+	e.closeOnce.Do(func() {
+		first = true
+		// done is closed under e.mu so track cannot add to wg once Wait may have started.
+		e.mu.Lock()
+		close(e.done)
+		for ch := range e.subs {
+			delete(e.subs, ch)
+			close(ch)
+		}
+		e.mu.Unlock()
+	})
+	if !first {
+		return nil
+	}
+
+	e.mu.RLock()
+	out := e.out
+	e.mu.RUnlock()
+
+	var errs []error
+	stopped := make(chan struct{})
+	go func() {
+		e.wg.Wait()
+		close(stopped)
+	}()
+
+	select {
+	case <-stopped:
+	case <-ctx.Done():
+		errs = append(errs, fmt.Errorf("serrors: waiting for watchers: %w", ctx.Err()))
+	}
+
+	if c, ok := out.(interface{ Close(context.Context) error }); ok {
+		errs = append(errs, c.Close(ctx))
+	}
+
+	if r, ok := out.(interface{ Retry(context.Context) error }); ok {
+		// The batches left in the DeadLetter are reported below.
+		r.Retry(ctx)
+	}
+
+	if d, ok := out.(interface{ DeadLetter() *DeadLetter }); ok {
+		if bs := d.DeadLetter().Batches(); len(bs) > 0 {
+			errs = append(errs, &UndeliveredError{Batches: bs})
+		}
+	}
+
+	errs = append(errs, e.CloseWAL(), e.SpillErr())
+	return errors.Join(errs...)
+}
+
+// track registers a goroutine that Close waits for and returns false if e is already closed. The
+// goroutine must call e.wg.Done when it returns and stop once e.done is closed.
+func (e *SErrors) track() bool {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	select {
+	case <-e.done:
+		return false
+	default:
+	}
+
+	e.wg.Add(1)
+	return true
+}
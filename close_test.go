@@ -0,0 +1,111 @@
+package serrors
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestSErrorsClose(t *testing.T) {
+	e := NewTextHandler(nil, nil, WithWAL(filepath.Join(t.TempDir(), "wal"), false))
+
+	watched := make(chan struct{})
+	go func() {
+		e.Watch(context.Background(), time.Hour)
+		close(watched)
+	}()
+
+	followed := make(chan int)
+	next := e.Follow(context.Background())
+	go func() {
+		n := 0
+		for range next {
+			n++
+		}
+		followed <- n
+	}()
+
+	time.Sleep(10 * time.Millisecond)
+	e.Error(testTime, "m")
+	if err := e.Close(context.Background()); err != nil {
+		t.Fatalf("\ngot  %s\nwant nil", err.Error())
+	}
+
+	select {
+	case <-watched:
+	case <-time.After(time.Second):
+		t.Fatal("Watch did not return")
+	}
+
+	if n := <-followed; n != 1 {
+		t.Fatalf("\ngot  %d\nwant 1", n)
+	}
+
+	if e.wal.store != nil {
+		t.Fatal("WAL not closed")
+	}
+
+	if err := e.Close(context.Background()); err != nil {
+		t.Fatalf("\ngot  %s\nwant nil", err.Error())
+	}
+}
+
+func TestSErrorsCloseUndelivered(t *testing.T) {
+	s := &bulkServer{fail: true}
+	srv := httptest.NewServer(s)
+	defer srv.Close()
+
+	tests := []struct {
+		name    string
+		recover bool
+		want    string
+	}{
+		{"retried", true, ""},
+		{"undelivered", false, "serrors: 2 records in 1 batches undelivered"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			s.mu.Lock()
+			s.fail = true
+			s.mu.Unlock()
+
+			u := NewBulkUploader(BulkOptions{
+				URL:           srv.URL,
+				Header:        http.Header{"X-Token": {"t"}},
+				FlushInterval: -1,
+			})
+			e := New(u, nil)
+			e.Error(testTime, "a")
+			e.Error(testTime, "b")
+			if err := e.Log(); err != nil {
+				t.Fatal(err)
+			}
+
+			if err := u.Flush(context.Background()); err == nil {
+				t.Fatal("want the first flush to fail")
+			}
+
+			s.mu.Lock()
+			s.fail = !test.recover
+			s.mu.Unlock()
+
+			err := e.Close(context.Background())
+			if test.want == "" {
+				if err != nil {
+					t.Fatalf("\ngot  %s\nwant nil", err.Error())
+				}
+				return
+			}
+
+			var ue *UndeliveredError
+			if !errors.As(err, &ue) || ue.Error() != test.want {
+				t.Fatalf("\ngot  %v\nwant %s", err, test.want)
+			}
+		})
+	}
+}
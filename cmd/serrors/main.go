@@ -0,0 +1,90 @@
+// Command serrors works with stored serrors and slog output.
+//
+//	serrors convert -from json -to text [in [out]]
+//
+// Files default to stdin and stdout.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/chadeldridge/serrors"
+)
+
+const usage = `usage: serrors <command> [flags]
+
+commands:
+  convert   re-encode records between formats
+`
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdin, os.Stdout, os.Stderr))
+}
+
+// run executes the command in args and returns the exit code
+func run(args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	if len(args) == 0 {
+		fmt.Fprint(stderr, usage)
+		return 2
+	}
+
+	switch args[0] {
+	case "convert":
+		return convert(args[1:], stdin, stdout, stderr)
+	default:
+		fmt.Fprintf(stderr, "serrors: unknown command %q\n%s", args[0], usage)
+		return 2
+	}
+}
+
+// convert runs the convert command
+func convert(args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	fs := flag.NewFlagSet("convert", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	from := fs.String("from", string(serrors.FormatJSON), "input format: json or text")
+	to := fs.String("to", string(serrors.FormatText), "output format: json, text or a registered format")
+	fs.Usage = func() {
+		fmt.Fprintln(stderr, "usage: serrors convert [-from format] [-to format] [in [out]]")
+		fs.PrintDefaults()
+	}
+
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+
+	if fs.NArg() > 2 {
+		fs.Usage()
+		return 2
+	}
+
+	in, out := stdin, stdout
+	if fs.NArg() > 0 && fs.Arg(0) != "-" {
+		f, err := os.Open(fs.Arg(0))
+		if err != nil {
+			fmt.Fprintln(stderr, err)
+			return 1
+		}
+		defer f.Close()
+		in = f
+	}
+
+	if fs.NArg() > 1 && fs.Arg(1) != "-" {
+		f, err := os.Create(fs.Arg(1))
+		if err != nil {
+			fmt.Fprintln(stderr, err)
+			return 1
+		}
+		defer f.Close()
+		out = f
+	}
+
+	if err := serrors.Convert(in, out, serrors.Format(*from), serrors.Format(*to)); err != nil {
+		fmt.Fprintln(stderr, err)
+		return 1
+	}
+
+	return 0
+}
@@ -0,0 +1,57 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestRunConvert(t *testing.T) {
+	in := `{"time":"2000-01-02T03:04:05Z","level":"WARN","msg":"m","a":1}` + "\n"
+	want := "time=2000-01-02T03:04:05.000Z level=WARN msg=m a=1\n"
+
+	stdout, stderr := bytes.NewBuffer(nil), bytes.NewBuffer(nil)
+	if code := run([]string{"convert"}, strings.NewReader(in), stdout, stderr); code != 0 {
+		t.Fatalf("\ngot  %d %s\nwant 0", code, stderr)
+	}
+
+	if stdout.String() != want {
+		t.Fatalf("\ngot  %s\nwant %s", stdout, want)
+	}
+
+	dir := t.TempDir()
+	inFile, outFile := filepath.Join(dir, "in.txt"), filepath.Join(dir, "out.json")
+	os.WriteFile(inFile, []byte(want), 0o600)
+	if code := run([]string{"convert", "-from", "text", "-to", "json", inFile, outFile}, nil, stdout, stderr); code != 0 {
+		t.Fatalf("\ngot  %d %s\nwant 0", code, stderr)
+	}
+
+	got, _ := os.ReadFile(outFile)
+	if string(got) != in {
+		t.Fatalf("\ngot  %s\nwant %s", got, in)
+	}
+}
+
+func TestRunErrors(t *testing.T) {
+	tests := []struct {
+		args []string
+		code int
+		want string
+	}{
+		{nil, 2, "usage: serrors"},
+		{[]string{"nope"}, 2, `unknown command "nope"`},
+		{[]string{"convert", "-to", "csv"}, 1, `unknown format "csv"`},
+		{[]string{"convert", "a", "b", "c"}, 2, "usage: serrors convert"},
+		{[]string{"convert", "/nonexistent/in"}, 1, "no such file"},
+	}
+
+	for _, test := range tests {
+		stderr := bytes.NewBuffer(nil)
+		code := run(test.args, strings.NewReader(""), bytes.NewBuffer(nil), stderr)
+		if code != test.code || !strings.Contains(stderr.String(), test.want) {
+			t.Fatalf("\ngot  %d %s\nwant %d %s", code, stderr, test.code, test.want)
+		}
+	}
+}
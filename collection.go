@@ -0,0 +1,40 @@
+package serrors
+
+import (
+	"log/slog"
+	"strconv"
+	"time"
+)
+
+// CollectionKey is the attr key of the records nested by AddCollection
+const CollectionKey = "records"
+
+// AddCollection adds one record with level l and msg whose CollectionKey group holds every record
+// of child, including spilled ones, as of the call. Each child record is a group keyed by its
+// index, see RecordAttr, so a caller can report "step 3 failed" with the step's own collection
+// attached instead of flattening it into e:
+//
+//	errs.AddCollection(t, slog.LevelError, "step 3 failed", stepErrs, slog.Int("step", 3))
+//
+// The text handler writes the nested records as records.0.msg=... and the JSON handler as an
+// object keyed by index. attrs are added after the group.
+func (e *SErrors) AddCollection(
+	t time.Time, l slog.Level, msg string, child *SErrors, attrs ...slog.Attr,
+) error {
+	var nested []any
+	if child != nil {
+		err := child.eachRecord(func(r slog.Record) error {
+			nested = append(nested, RecordAttr(r, strconv.Itoa(len(nested))))
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	r := slog.NewRecord(t, l, msg, e.pc())
+	r.AddAttrs(slog.Group(CollectionKey, nested...))
+	r.AddAttrs(attrs...)
+	e.add(r)
+	return nil
+}
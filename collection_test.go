@@ -0,0 +1,54 @@
+package serrors
+
+import (
+	"errors"
+	"io/fs"
+	"log/slog"
+	"testing"
+)
+
+func TestSErrorsAddCollection(t *testing.T) {
+	step := New(nil, nil)
+	step.Warn(testTime, "slow", slog.Int("ms", 900))
+	step.Error(testTime, "read", slog.Any("err", fs.ErrNotExist))
+
+	tests := []struct {
+		name string
+		e    *SErrors
+		want string
+	}{
+		{
+			"text",
+			NewTextHandler(nil, nil),
+			"time=2000-01-02T03:04:05.000Z level=ERROR msg=\"step 3 failed\" " +
+				"records.0.time=2000-01-02T03:04:05.000Z records.0.level=WARN records.0.msg=slow records.0.ms=900 " +
+				"records.1.time=2000-01-02T03:04:05.000Z records.1.level=ERROR records.1.msg=read " +
+				"records.1.err=\"file does not exist\" step=3\n",
+		},
+		{
+			"json",
+			NewJSONHandler(nil, nil),
+			`{"time":"2000-01-02T03:04:05Z","level":"ERROR","msg":"step 3 failed","records":{` +
+				`"0":{"time":"2000-01-02T03:04:05Z","level":"WARN","msg":"slow","ms":900},` +
+				`"1":{"time":"2000-01-02T03:04:05Z","level":"ERROR","msg":"read","err":"file does not exist"}},` +
+				`"step":3}` + "\n",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := test.e.AddCollection(testTime, slog.LevelError, "step 3 failed", step, slog.Int("step", 3))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if got := test.e.String(); got != test.want {
+				t.Fatalf("\ngot  %s\nwant %s", got, test.want)
+			}
+
+			if !errors.Is(test.e.Err(), fs.ErrNotExist) {
+				t.Fatal("errors.Is did not find the nested error")
+			}
+		})
+	}
+}
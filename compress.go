@@ -0,0 +1,74 @@
+package serrors
+
+import (
+	"compress/gzip"
+	"fmt"
+	"io"
+
+	"github.com/klauspost/compress/zstd"
+)
+
+// Compression is how serialized exports are compressed, see WithCompression
+type Compression string
+
+const (
+	// CompressionNone writes exports as they are
+	CompressionNone Compression = "none"
+	// CompressionGzip compresses exports with gzip
+	CompressionGzip Compression = "gzip"
+	// CompressionZstd compresses exports with zstd
+	CompressionZstd Compression = "zstd"
+)
+
+// WithCompression compresses the output of WriteNDJSON, and of the dashboard's NDJSON download
+// with a matching Content-Encoding, with c. Exports are not compressed by default.
+func WithCompression(c Compression) Option {
+	return func(e *SErrors) {
+		e.compression = c
+	}
+}
+
+// ContentEncoding returns the HTTP Content-Encoding of data compressed with c, or "" for none
+func (c Compression) ContentEncoding() string {
+	switch c {
+	case CompressionGzip, CompressionZstd:
+		return string(c)
+	}
+
+	return ""
+}
+
+// Extension returns the file extension of data compressed with c, such as ".gz", or "" for none
+func (c Compression) Extension() string {
+	switch c {
+	case CompressionGzip:
+		return ".gz"
+	case CompressionZstd:
+		return ".zst"
+	}
+
+	return ""
+}
+
+// NewWriter returns a writer compressing to w with c. Close it to flush the compressed data; w
+// itself is not closed. The empty Compression is the same as CompressionNone.
+func (c Compression) NewWriter(w io.Writer) (io.WriteCloser, error) {
+	switch c {
+	case "", CompressionNone:
+		return nopCloser{w}, nil
+	case CompressionGzip:
+		return gzip.NewWriter(w), nil
+	case CompressionZstd:
+		return zstd.NewWriter(w)
+	}
+
+	return nil, fmt.Errorf("serrors: unknown compression %q", c)
+}
+
+// nopCloser is a writer whose Close does nothing
+type nopCloser struct {
+	io.Writer
+}
+
+// Close implements io.Closer
+func (nopCloser) Close() error { return nil }
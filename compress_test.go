@@ -0,0 +1,71 @@
+package serrors
+
+import (
+	"bytes"
+	"compress/gzip"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/klauspost/compress/zstd"
+)
+
+func TestSErrorsWithCompression(t *testing.T) {
+	want := `{"time":"2000-01-02T03:04:05Z","level":"ERROR","msg":"m"}` + "\n"
+	tests := []struct {
+		c      Compression
+		ext    string
+		reader func(io.Reader) (io.Reader, error)
+	}{
+		{CompressionNone, "", func(r io.Reader) (io.Reader, error) { return r, nil }},
+		{CompressionGzip, ".gz", func(r io.Reader) (io.Reader, error) { return gzip.NewReader(r) }},
+		{CompressionZstd, ".zst", func(r io.Reader) (io.Reader, error) { return zstd.NewReader(r) }},
+	}
+
+	for _, test := range tests {
+		t.Run(string(test.c), func(t *testing.T) {
+			e := New(nil, nil, WithCompression(test.c))
+			e.Error(testTime, "m")
+
+			var b bytes.Buffer
+			if err := e.WriteNDJSON(&b); err != nil {
+				t.Fatal(err)
+			}
+
+			r, err := test.reader(&b)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			got, err := io.ReadAll(r)
+			if err != nil || string(got) != want {
+				t.Fatalf("\ngot  %s, %v\nwant %s", got, err, want)
+			}
+
+			if test.c.Extension() != test.ext {
+				t.Fatalf("\ngot  %s\nwant %s", test.c.Extension(), test.ext)
+			}
+
+			req := httptest.NewRequest(http.MethodGet, "/records.ndjson", nil)
+			req.Header.Set("Accept-Encoding", "br;q=0.5, "+test.c.ContentEncoding())
+			w := httptest.NewRecorder()
+			e.DashboardHandler().ServeHTTP(w, req)
+			if got := w.Header().Get("Content-Encoding"); got != test.c.ContentEncoding() {
+				t.Fatalf("\ngot  %s\nwant %s", got, test.c.ContentEncoding())
+			}
+
+			// Clients that do not accept the encoding get plain NDJSON.
+			req.Header.Set("Accept-Encoding", "identity, "+test.c.ContentEncoding()+";q=0")
+			w = httptest.NewRecorder()
+			e.DashboardHandler().ServeHTTP(w, req)
+			if got := w.Header().Get("Content-Encoding"); got != "" || w.Body.String() != want {
+				t.Fatalf("\ngot  %s %s\nwant %s", got, w.Body, want)
+			}
+		})
+	}
+
+	if _, err := Compression("lz4").NewWriter(io.Discard); err == nil {
+		t.Fatal("want error for an unknown compression")
+	}
+}
@@ -0,0 +1,238 @@
+package serrors
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// Config configures a collector from the environment or a config file rather than code, see
+// NewFromConfig and NewFromEnv. The zero Config collects every record as JSON on stderr.
+type Config struct {
+	// Format of the output, FormatJSON or FormatText. Defaults to FormatJSON.
+	Format Format `json:"format"`
+	// Output is where Log writes: "stderr", the default, "stdout", "discard" or the path of a file
+	// records are appended to
+	Output string `json:"output"`
+	// MinLevel is the lowest level collected, parsed with ParseLevel. Records below it are dropped as
+	// they are added. Empty collects every level.
+	MinLevel string `json:"min_level"`
+	// SampleRate keeps each record with this probability, see WithSampling. 0 keeps every record.
+	SampleRate float64 `json:"sample_rate"`
+	// MemLimit caps the records held as slog.Records. Older records are spilled to files in
+	// SpillDir, see WithSpillDir, or compacted in memory when SpillDir is empty, see WithArena. 0
+	// holds every record.
+	MemLimit int    `json:"mem_limit"`
+	SpillDir string `json:"spill_dir"`
+	// RedactKeys are attr keys whose values are redacted, see WithRedactKeys
+	RedactKeys []string `json:"redact_keys"`
+}
+
+// Validate returns every problem with c joined, or nil if it is usable
+func (c Config) Validate() error {
+	var errs []error
+	if c.Format != "" && c.Format != FormatJSON && c.Format != FormatText {
+		errs = append(errs, fmt.Errorf("serrors: config: unknown format %q", c.Format))
+	}
+
+	if c.MinLevel != "" {
+		if _, err := ParseLevel(c.MinLevel); err != nil {
+			errs = append(errs, fmt.Errorf("serrors: config: %w", err))
+		}
+	}
+
+	if c.SampleRate < 0 || c.SampleRate > 1 {
+		errs = append(errs, fmt.Errorf("serrors: config: sample rate %g is not between 0 and 1",
+			c.SampleRate))
+	}
+
+	if c.MemLimit < 0 {
+		errs = append(errs, fmt.Errorf("serrors: config: negative mem limit %d", c.MemLimit))
+	}
+
+	if c.SpillDir != "" && c.MemLimit == 0 {
+		errs = append(errs, errors.New("serrors: config: spill dir set without a mem limit"))
+	}
+
+	return errors.Join(errs...)
+}
+
+// ConfigFromEnv reads a Config from the environment variables named by prefix followed by FORMAT,
+// OUTPUT, MIN_LEVEL, SAMPLE_RATE, MEM_LIMIT, SPILL_DIR and REDACT_KEYS, a comma separated list.
+// With prefix "APP_" the format is read from APP_FORMAT. Unset variables keep their zero value.
+func ConfigFromEnv(prefix string) (Config, error) {
+	env := func(name string) string { return strings.TrimSpace(os.Getenv(prefix + name)) }
+	c := Config{
+		Format:   Format(strings.ToLower(env("FORMAT"))),
+		Output:   env("OUTPUT"),
+		MinLevel: env("MIN_LEVEL"),
+		SpillDir: env("SPILL_DIR"),
+	}
+
+	var errs []error
+	if s := env("SAMPLE_RATE"); s != "" {
+		rate, err := strconv.ParseFloat(s, 64)
+		errs = append(errs, err)
+		c.SampleRate = rate
+	}
+
+	if s := env("MEM_LIMIT"); s != "" {
+		n, err := strconv.Atoi(s)
+		errs = append(errs, err)
+		c.MemLimit = n
+	}
+
+	if s := env("REDACT_KEYS"); s != "" {
+		for _, k := range strings.Split(s, ",") {
+			if k = strings.TrimSpace(k); k != "" {
+				c.RedactKeys = append(c.RedactKeys, k)
+			}
+		}
+	}
+
+	if err := errors.Join(errs...); err != nil {
+		return c, fmt.Errorf("serrors: config: %w", err)
+	}
+
+	return c, nil
+}
+
+// NewFromEnv creates a collector configured by ConfigFromEnv(prefix), see NewFromConfig
+func NewFromEnv(prefix string, options ...Option) (*SErrors, error) {
+	c, err := ConfigFromEnv(prefix)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewFromConfig(c, options...)
+}
+
+// NewFromConfig validates c and creates a collector configured by it. options are applied after
+// the ones derived from c. Call Close when done so an Output file is closed.
+func NewFromConfig(c Config, options ...Option) (*SErrors, error) {
+	if err := c.Validate(); err != nil {
+		return nil, err
+	}
+
+	w, err := c.writer()
+	if err != nil {
+		return nil, err
+	}
+
+	return newSErrors(c.Format != FormatText, w, nil, append(c.options(), options...)), nil
+}
+
+// options returns the Options c maps to. c must be valid.
+func (c Config) options() []Option {
+	var options []Option
+	if c.MinLevel != "" {
+		options = append(options, WithMinLevel(c.minLevel()))
+	}
+
+	if s := c.sampler(); s != nil {
+		options = append(options, WithSampler(s))
+	}
+
+	switch {
+	case c.SpillDir != "":
+		options = append(options, WithSpillDir(c.SpillDir, c.MemLimit))
+	case c.MemLimit > 0:
+		options = append(options, WithArena(c.MemLimit))
+	}
+
+	return append(options, WithRedactKeys(c.RedactKeys...))
+}
+
+// minLevel returns the MinLevel of c, LevelDiscard if it is empty. c must be valid.
+func (c Config) minLevel() slog.Level {
+	if c.MinLevel == "" {
+		return LevelDiscard
+	}
+
+	l, _ := ParseLevel(c.MinLevel)
+	return l
+}
+
+// sampler returns the decision function of the SampleRate of c, nil if every record is kept
+func (c Config) sampler() func(slog.Record) bool {
+	if c.SampleRate <= 0 || c.SampleRate >= 1 {
+		return nil
+	}
+
+	return rateSampler(c.SampleRate)
+}
+
+// writer opens the Output of c
+func (c Config) writer() (io.Writer, error) {
+	switch strings.ToLower(c.Output) {
+	case "", "stderr":
+		return os.Stderr, nil
+	case "stdout":
+		return os.Stdout, nil
+	case "discard":
+		return io.Discard, nil
+	}
+
+	f, err := os.OpenFile(c.Output, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o600)
+	if err != nil {
+		return nil, fmt.Errorf("serrors: config: %w", err)
+	}
+
+	return outputFile{f}, nil
+}
+
+// outputFile is a Config Output file, closed by SErrors.Close
+type outputFile struct {
+	*os.File
+}
+
+// Close closes the file. ctx is not used.
+func (f outputFile) Close(context.Context) error {
+	return f.File.Close()
+}
+
+// ApplyConfig swaps the Format, Output, MinLevel, SampleRate and RedactKeys of a live collector for
+// the ones in c in one step, so operators can turn on debug collection or move the output without
+// a restart. It replaces any sampler, minimum level or redaction set by options. The records
+// already collected are kept as they are. An Output file opened by a previous Config is closed,
+// which fails a Log writing to it at that moment. MemLimit and SpillDir cannot change after the
+// collector is created and must match.
+func (e *SErrors) ApplyConfig(c Config) error {
+	if err := c.Validate(); err != nil {
+		return err
+	}
+
+	limit, dir := 0, ""
+	if e.spill != nil {
+		limit, dir = e.spill.limit, e.spill.dir
+	}
+
+	if c.MemLimit != limit || c.SpillDir != dir {
+		return errors.New("serrors: config: mem limit and spill dir cannot change on a live collector")
+	}
+
+	w, err := c.writer()
+	if err != nil {
+		return err
+	}
+
+	e.mu.Lock()
+	old := e.out
+	e.json, e.out = c.Format != FormatText, w
+	e.minLevel, e.sampler, e.redactKeys = c.minLevel(), c.sampler(), redactSet(c.RedactKeys)
+	e.logger = e.newHandler(w)
+	e.renderTees()
+	e.resetRender()
+	e.mu.Unlock()
+
+	if f, ok := old.(outputFile); ok {
+		return f.Close(context.Background())
+	}
+
+	return nil
+}
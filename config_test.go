@@ -0,0 +1,167 @@
+package serrors
+
+import (
+	"context"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sync"
+	"testing"
+)
+
+func TestConfigFromEnv(t *testing.T) {
+	t.Setenv("APP_FORMAT", "TEXT")
+	t.Setenv("APP_MIN_LEVEL", "warn")
+	t.Setenv("APP_SAMPLE_RATE", "0.5")
+	t.Setenv("APP_MEM_LIMIT", "100")
+	t.Setenv("APP_REDACT_KEYS", "password, token")
+
+	got, err := ConfigFromEnv("APP_")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := Config{
+		Format:     FormatText,
+		MinLevel:   "warn",
+		SampleRate: 0.5,
+		MemLimit:   100,
+		RedactKeys: []string{"password", "token"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("\ngot  %+v\nwant %+v", got, want)
+	}
+
+	t.Setenv("APP_MEM_LIMIT", "lots")
+	if _, err := ConfigFromEnv("APP_"); err == nil {
+		t.Fatal("want error for a bad mem limit")
+	}
+}
+
+func TestConfigValidate(t *testing.T) {
+	tests := []struct {
+		name string
+		c    Config
+		ok   bool
+	}{
+		{"zero", Config{}, true},
+		{"full", Config{Format: FormatText, MinLevel: "DEBUG", SampleRate: 1, MemLimit: 10, SpillDir: "/tmp"}, true},
+		{"format", Config{Format: "xml"}, false},
+		{"level", Config{MinLevel: "loud"}, false},
+		{"rate", Config{SampleRate: 2}, false},
+		{"mem limit", Config{MemLimit: -1}, false},
+		{"spill dir", Config{SpillDir: "/tmp"}, false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if err := test.c.Validate(); (err == nil) != test.ok {
+				t.Fatalf("\ngot  %v\nwant ok %t", err, test.ok)
+			}
+		})
+	}
+}
+
+func TestNewFromConfig(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "out.log")
+	e, err := NewFromConfig(Config{
+		Format:     FormatText,
+		Output:     path,
+		MinLevel:   "WARN",
+		RedactKeys: []string{"password"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	e.Info(testTime, "dropped")
+	e.Error(testTime, "m", slog.String("password", "p"))
+	if err := e.Log(); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := e.Close(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := "time=2000-01-02T03:04:05.000Z level=ERROR msg=m password=[REDACTED]\n"
+	if string(got) != want {
+		t.Fatalf("\ngot  %s\nwant %s", got, want)
+	}
+
+	if _, err := NewFromConfig(Config{Format: "xml"}); err == nil {
+		t.Fatal("want error for an invalid config")
+	}
+}
+
+func TestSErrorsApplyConfig(t *testing.T) {
+	dir := t.TempDir()
+	e, err := NewFromConfig(Config{Output: filepath.Join(dir, "a.log"), MinLevel: "ERROR"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer e.Close(context.Background())
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for range 100 {
+			e.Debug(testTime, "concurrent")
+		}
+	}()
+
+	e.Debug(testTime, "dropped")
+	r := slog.NewRecord(testTime, slog.LevelError, "r", 0)
+	_ = e.RtoString(r)
+	err = e.ApplyConfig(Config{
+		Format:     FormatText,
+		Output:     filepath.Join(dir, "b.log"),
+		MinLevel:   "DEBUG",
+		RedactKeys: []string{"password"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	wg.Wait()
+
+	e.RemoveIf(func(slog.Record) bool { return true })
+	e.Debug(testTime, "kept", slog.String("password", "p"))
+	if err := e.Log(); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := os.ReadFile(filepath.Join(dir, "b.log"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := "time=2000-01-02T03:04:05.000Z level=DEBUG msg=kept password=[REDACTED]\n"
+	if string(got) != want {
+		t.Fatalf("\ngot  %s\nwant %s", got, want)
+	}
+
+	fi, err := os.Stat(filepath.Join(dir, "b.log"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if fi.Mode().Perm() != 0o600 {
+		t.Fatalf("\ngot  %s\nwant -rw-------", fi.Mode().Perm())
+	}
+
+	want = "time=2000-01-02T03:04:05.000Z level=ERROR msg=r\n"
+	if got := e.RtoString(r); got != want {
+		t.Fatalf("\ngot  %s\nwant %s", got, want)
+	}
+
+	if err := e.ApplyConfig(Config{MemLimit: 10}); err == nil {
+		t.Fatal("want error changing the mem limit")
+	}
+}
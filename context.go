@@ -0,0 +1,33 @@
+package serrors
+
+import (
+	"context"
+	"io"
+	"sync"
+)
+
+// contextKey is the context key NewContext stores the collector under
+type contextKey struct{}
+
+// NewContext returns a copy of ctx carrying e, so functions deep in a request can add to a
+// request-scoped collector with FromContext without it being passed through every signature
+func NewContext(ctx context.Context, e *SErrors) context.Context {
+	return context.WithValue(ctx, contextKey{}, e)
+}
+
+// FromContext returns the collector stored in ctx by NewContext. If there is none it returns a
+// shared collector that drops every record entering it, so callers never need to check for nil.
+func FromContext(ctx context.Context) *SErrors {
+	if e, ok := ctx.Value(contextKey{}).(*SErrors); ok && e != nil {
+		return e
+	}
+
+	return discardCollector()
+}
+
+// discardCollector returns the collector FromContext falls back to, created on first use
+var discardCollector = sync.OnceValue(func() *SErrors {
+	e := New(io.Discard, nil)
+	e.discard = true
+	return e
+})
@@ -0,0 +1,37 @@
+package serrors
+
+import (
+	"context"
+	"log/slog"
+	"testing"
+)
+
+func TestContext(t *testing.T) {
+	e := NewTextHandler(nil, nil)
+	ctx := NewContext(context.Background(), e)
+	FromContext(ctx).Error(testTime, "a")
+
+	want := "time=2000-01-02T03:04:05.000Z level=ERROR msg=a\n"
+	if got := e.String(); got != want {
+		t.Fatalf("\ngot  %s\nwant %s", got, want)
+	}
+
+	noop := FromContext(context.Background())
+	noop.Error(testTime, "a", slog.Int("i", 1))
+	noop.Append(e)
+	if !noop.IsZero() {
+		t.Fatalf("\ngot  %s\nwant no records", noop.String())
+	}
+
+	if FromContext(context.Background()) != noop {
+		t.Fatal("want the shared collector")
+	}
+}
+
+func BenchmarkFromContextNone(b *testing.B) {
+	ctx := context.Background()
+	b.ReportAllocs()
+	for range b.N {
+		FromContext(ctx).Error(testTime, "a", slog.Int("i", 1))
+	}
+}
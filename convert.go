@@ -0,0 +1,52 @@
+package serrors
+
+import (
+	"fmt"
+	"io"
+	"log/slog"
+)
+
+// Format names a serialized form of records
+type Format string
+
+const (
+	// FormatJSON is slog JSON handler output, one object per line
+	FormatJSON Format = "json"
+	// FormatText is slog text handler output or logfmt, one record per line
+	FormatText Format = "text"
+)
+
+// Convert re-encodes the records read from in as from and writes them to out as to, which may be
+// any format registered with RegisterFormat. It works a line at a time so large dumps are not held
+// in memory.
+func Convert(in io.Reader, out io.Writer, from, to Format) error {
+	var parse func(line []byte) (slog.Record, error)
+	switch from {
+	case FormatJSON:
+		parse = parseJSONRecord
+	case FormatText:
+		parse = func(line []byte) (slog.Record, error) { return parseTextRecord(string(line)) }
+	default:
+		return fmt.Errorf("serrors: unknown format %q", from)
+	}
+
+	f, err := lookupFormat(to)
+	if err != nil {
+		return err
+	}
+
+	return scanLines(in, func(line []byte) error {
+		r, err := parse(line)
+		if err != nil {
+			return err
+		}
+
+		b, err := f.Render(r)
+		if err != nil {
+			return err
+		}
+
+		_, err = out.Write(b)
+		return err
+	})
+}
@@ -0,0 +1,46 @@
+package serrors
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestConvert(t *testing.T) {
+	text := `time=2000-01-02T03:04:05.000Z level=ERROR msg=m a=1 g.b="x y"` + "\n"
+	json := `{"time":"2000-01-02T03:04:05Z","level":"ERROR","msg":"m","a":1,"g":{"b":"x y"}}` + "\n"
+
+	tests := []struct {
+		name     string
+		in       string
+		from, to Format
+		want     string
+	}{
+		{"textToJSON", text, FormatText, FormatJSON, json},
+		{"jsonToText", json, FormatJSON, FormatText, text},
+		{"jsonToJSON", json, FormatJSON, FormatJSON, json},
+		{"textToText", text, FormatText, FormatText, text},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got := bytes.NewBuffer(nil)
+			if err := Convert(strings.NewReader(test.in), got, test.from, test.to); err != nil {
+				t.Fatalf("\ngot  %s\nwant nil", err.Error())
+			}
+
+			if got.String() != test.want {
+				t.Fatalf("\ngot  %s\nwant %s", got, test.want)
+			}
+		})
+	}
+}
+
+func TestConvertUnknownFormat(t *testing.T) {
+	for _, f := range [][2]Format{{"csv", FormatText}, {FormatText, "csv"}} {
+		err := Convert(strings.NewReader(""), nil, f[0], f[1])
+		if err == nil || err.Error() != `serrors: unknown format "csv"` {
+			t.Fatalf("\ngot  %v\nwant unknown format", err)
+		}
+	}
+}
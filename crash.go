@@ -0,0 +1,152 @@
+package serrors
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"os"
+	"runtime"
+	"runtime/debug"
+	"time"
+)
+
+// crashRecent is how many of the latest records a crash report holds
+const crashRecent = 50
+
+// crashReport is the bundle written by CrashReport
+type crashReport struct {
+	Time      time.Time         `json:"time"`
+	Panic     string            `json:"panic"`
+	PanicType string            `json:"panic_type"`
+	Stack     string            `json:"stack"`
+	Host      crashHost         `json:"host"`
+	Build     *crashBuild       `json:"build,omitempty"`
+	Summary   json.RawMessage   `json:"summary,omitempty"`
+	Records   []json.RawMessage `json:"records"`
+}
+
+// crashHost describes the process that crashed
+type crashHost struct {
+	Hostname   string `json:"hostname"`
+	PID        int    `json:"pid"`
+	GoVersion  string `json:"go_version"`
+	OS         string `json:"os"`
+	Arch       string `json:"arch"`
+	Goroutines int    `json:"goroutines"`
+}
+
+// crashBuild describes the binary that crashed, from its build info
+type crashBuild struct {
+	Path     string `json:"path"`
+	Version  string `json:"version"`
+	Revision string `json:"vcs_revision,omitempty"`
+	Time     string `json:"vcs_time,omitempty"`
+	Modified bool   `json:"vcs_modified,omitempty"`
+}
+
+// CrashReport returns a JSON document for a crash handler bundling the recovered panic value and
+// its type, stack, host and build metadata, the summary written by LogSummary and the last 50
+// records in memory in the JSON output format. stack is usually debug.Stack(). See
+// WriteCrashReport.
+func (e *SErrors) CrashReport(recovered any, stack []byte) []byte {
+	c := crashReport{
+		Time:      e.now(),
+		Panic:     fmt.Sprint(recovered),
+		PanicType: fmt.Sprintf("%T", recovered),
+		Stack:     string(stack),
+		Host:      newCrashHost(),
+		Build:     newCrashBuild(),
+		Records:   []json.RawMessage{},
+	}
+
+	var b bytes.Buffer
+	h := slog.NewJSONHandler(&b, e.jsonOpts())
+	encode := func(r slog.Record) json.RawMessage {
+		b.Reset()
+		if err := h.Handle(context.Background(), e.profiled(r)); err != nil {
+			return nil
+		}
+
+		return bytes.Clone(bytes.TrimSuffix(b.Bytes(), []byte("\n")))
+	}
+
+	// A summary that cannot read the spilled records is left out rather than losing the report.
+	if r, err := e.summary(); err == nil {
+		c.Summary = encode(r)
+	}
+
+	rs := e.Records()
+	for _, r := range rs[max(len(rs)-crashRecent, 0):] {
+		if m := encode(r); m != nil {
+			c.Records = append(c.Records, m)
+		}
+	}
+
+	out, _ := json.Marshal(c)
+	return out
+}
+
+// newCrashHost describes the current process
+func newCrashHost() crashHost {
+	name, _ := os.Hostname()
+	return crashHost{
+		Hostname:   name,
+		PID:        os.Getpid(),
+		GoVersion:  runtime.Version(),
+		OS:         runtime.GOOS,
+		Arch:       runtime.GOARCH,
+		Goroutines: runtime.NumGoroutine(),
+	}
+}
+
+// newCrashBuild describes the current binary, or returns nil without build info
+func newCrashBuild() *crashBuild {
+	info, ok := debug.ReadBuildInfo()
+	if !ok {
+		return nil
+	}
+
+	b := &crashBuild{Path: info.Path, Version: info.Main.Version}
+	for _, s := range info.Settings {
+		switch s.Key {
+		case "vcs.revision":
+			b.Revision = s.Value
+		case "vcs.time":
+			b.Time = s.Value
+		case "vcs.modified":
+			b.Modified = s.Value == "true"
+		}
+	}
+
+	return b
+}
+
+// WriteCrashReport writes CrashReport to a new file in dir, creating dir if needed, and returns the
+// file's name. Call it from a deferred recover:
+//
+//	defer func() {
+//		if v := recover(); v != nil {
+//			errs.WriteCrashReport("/var/crash/myapp", v, debug.Stack())
+//			panic(v)
+//		}
+//	}()
+func (e *SErrors) WriteCrashReport(dir string, recovered any, stack []byte) (string, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return "", err
+	}
+
+	pattern := fmt.Sprintf("crash-%s-*.json", e.now().UTC().Format("20060102T150405Z"))
+	f, err := os.CreateTemp(dir, pattern)
+	if err != nil {
+		return "", err
+	}
+
+	if _, err := f.Write(e.CrashReport(recovered, stack)); err != nil {
+		f.Close()
+		return f.Name(), err
+	}
+
+	return f.Name(), f.Close()
+}
@@ -0,0 +1,61 @@
+package serrors
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime/debug"
+	"testing"
+)
+
+func TestSErrorsCrashReport(t *testing.T) {
+	e := New(nil, nil)
+	for i := range crashRecent + 5 {
+		e.Error(testTime, fmt.Sprint(i))
+	}
+
+	var c struct {
+		Panic     string            `json:"panic"`
+		PanicType string            `json:"panic_type"`
+		Stack     string            `json:"stack"`
+		Host      crashHost         `json:"host"`
+		Summary   map[string]any    `json:"summary"`
+		Records   []json.RawMessage `json:"records"`
+	}
+	if err := json.Unmarshal(e.CrashReport(errors.New("boom"), debug.Stack()), &c); err != nil {
+		t.Fatal(err)
+	}
+
+	if c.Panic != "boom" || c.PanicType != "*errors.errorString" || c.Stack == "" {
+		t.Fatalf("\ngot  %q %q\nwant boom *errors.errorString with a stack", c.Panic, c.PanicType)
+	}
+
+	if c.Host.PID != os.Getpid() || c.Host.GoVersion == "" {
+		t.Fatalf("\ngot  %+v\nwant this process", c.Host)
+	}
+
+	if c.Summary["total"] != float64(crashRecent+5) {
+		t.Fatalf("\ngot  %v\nwant %d", c.Summary["total"], crashRecent+5)
+	}
+
+	want := `{"time":"2000-01-02T03:04:05Z","level":"ERROR","msg":"5"}`
+	if len(c.Records) != crashRecent || string(c.Records[0]) != want {
+		t.Fatalf("\ngot  %d records, first %s\nwant %d, first %s", len(c.Records), c.Records[0], crashRecent, want)
+	}
+}
+
+func TestSErrorsWriteCrashReport(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "crash")
+	e := New(nil, nil)
+	name, err := e.WriteCrashReport(dir, "boom", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	b, err := os.ReadFile(name)
+	if err != nil || !json.Valid(b) || filepath.Dir(name) != dir {
+		t.Fatalf("\ngot  %s in %s, %v\nwant a JSON report in %s", b, filepath.Dir(name), err, dir)
+	}
+}
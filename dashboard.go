@@ -0,0 +1,139 @@
+package serrors
+
+import (
+	_ "embed"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+//go:embed dashboard.html
+var dashboardHTML []byte
+
+// DashboardErrorHeader reports errors of DashboardHandler that do not stop the records from being
+// served: spilled records that could not be read or written, see SpillErr, and, as a trailer of
+// the downloads, failures after their body has started.
+const DashboardErrorHeader = "Serrors-Error"
+
+// DashboardHandler returns an http.Handler serving a single page UI over the records: a level
+// breakdown chart, a searchable table, a detail view of each record's attrs and downloads of the
+// records as NDJSON or an HTML report. Every endpoint includes spilled records. The NDJSON download
+// is compressed with WithCompression only if the request's Accept-Encoding allows it. Errors are
+// reported in DashboardErrorHeader alongside the records that could be served. It can be mounted
+// under a prefix with http.StripPrefix.
+//
+//	/               the dashboard page
+//	/records.json   the records as a JSON array
+//	/stream         the records as server-sent events, used by the page, see SErrors.StreamHandler
+//	/records.ndjson the records as NDJSON, see SErrors.WriteNDJSON
+//	/report.html    a standalone HTML report, see SErrors.WriteHTML
+//	/records        the records in the format named by ?format=, see RegisterFormat
+func (e *SErrors) DashboardHandler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/" {
+			http.NotFound(w, r)
+			return
+		}
+
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		w.Write(dashboardHTML)
+	})
+
+	mux.HandleFunc("/records.json", func(w http.ResponseWriter, _ *http.Request) {
+		rs, readErr := e.allRecords()
+		b, err := e.recordsJSON(rs)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		if msg := e.dashboardError(readErr); msg != "" {
+			w.Header().Set(DashboardErrorHeader, msg)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(b)
+	})
+
+	mux.Handle("/stream", e.StreamHandler())
+	mux.HandleFunc("/records.ndjson", func(w http.ResponseWriter, r *http.Request) {
+		c := CompressionNone
+		if enc := e.compression.ContentEncoding(); enc != "" {
+			w.Header().Set("Vary", "Accept-Encoding")
+			if acceptsEncoding(r.Header.Get("Accept-Encoding"), enc) {
+				c = e.compression
+				w.Header().Set("Content-Encoding", enc)
+			}
+		}
+
+		w.Header().Set("Content-Type", "application/x-ndjson")
+		w.Header().Set("Content-Disposition", `attachment; filename="records.ndjson"`)
+		e.writeStreamed(w, func(w io.Writer) error { return e.writeNDJSON(w, c) })
+	})
+
+	mux.HandleFunc("/report.html", func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		w.Header().Set("Content-Disposition", `attachment; filename="report.html"`)
+		e.writeStreamed(w, e.WriteHTML)
+	})
+
+	mux.HandleFunc("/records", func(w http.ResponseWriter, r *http.Request) {
+		name := Format(r.URL.Query().Get("format"))
+		f, ok := LookupFormat(name)
+		if !ok {
+			http.Error(w, fmt.Sprintf("unknown format %q", name), http.StatusBadRequest)
+			return
+		}
+
+		w.Header().Set("Content-Type", f.ContentType())
+		e.writeStreamed(w, func(w io.Writer) error { return e.WriteFormat(w, name) })
+	})
+
+	return mux
+}
+
+// writeStreamed writes a download with write. Part of the body may be sent by the time write
+// fails, so the error goes in the DashboardErrorHeader trailer instead of the status.
+func (e *SErrors) writeStreamed(w http.ResponseWriter, write func(io.Writer) error) {
+	w.Header().Set("Trailer", DashboardErrorHeader)
+	if msg := e.dashboardError(write(w)); msg != "" {
+		w.Header().Set(DashboardErrorHeader, msg)
+	}
+}
+
+// dashboardError returns err and the spill error as a DashboardErrorHeader value, empty if there
+// are none
+func (e *SErrors) dashboardError(err error) string {
+	err = errors.Join(err, e.SpillErr())
+	if err == nil {
+		return ""
+	}
+
+	return strings.ReplaceAll(err.Error(), "\n", "; ")
+}
+
+// acceptsEncoding reports whether the Accept-Encoding header allows the content coding enc
+func acceptsEncoding(header, enc string) bool {
+	for _, part := range strings.Split(header, ",") {
+		name, params, _ := strings.Cut(part, ";")
+		name = strings.TrimSpace(name)
+		if !strings.EqualFold(name, enc) && name != "*" {
+			continue
+		}
+
+		// A quality of 0 means not acceptable.
+		q, ok := strings.CutPrefix(strings.ReplaceAll(params, " ", ""), "q=")
+		if !ok {
+			return true
+		}
+
+		if v, err := strconv.ParseFloat(q, 64); err == nil && v > 0 {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,124 @@
+package serrors
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestSErrorsDashboardHandler(t *testing.T) {
+	e := NewTextHandler(nil, nil)
+	e.Error(testTime, "m", slog.Int("a", 1))
+	e.Warn(testTime, "m2")
+
+	tests := []struct {
+		path        string
+		code        int
+		contentType string
+		want        string
+	}{
+		{"/", http.StatusOK, "text/html; charset=utf-8", "<title>serrors dashboard</title>"},
+		{"/records.json", http.StatusOK, "application/json", `[{"time":"2000-01-02T03:04:05Z","level":"ERROR","msg":"m","a":1},{"time":"2000-01-02T03:04:05Z","level":"WARN","msg":"m2"}]`},
+		{"/records.ndjson", http.StatusOK, "application/x-ndjson", `{"time":"2000-01-02T03:04:05Z","level":"WARN","msg":"m2"}` + "\n"},
+		{"/report.html", http.StatusOK, "text/html; charset=utf-8", "<title>serrors report</title>"},
+		{"/records?format=json", http.StatusOK, "application/x-ndjson", `"msg":"m","a":1}` + "\n"},
+		{"/records?format=text", http.StatusOK, "text/plain; charset=utf-8", "level=WARN msg=m2\n"},
+		{"/records?format=nope", http.StatusBadRequest, "text/plain; charset=utf-8", `unknown format "nope"`},
+		{"/missing", http.StatusNotFound, "text/plain; charset=utf-8", "404"},
+	}
+
+	h := e.DashboardHandler()
+	for _, test := range tests {
+		t.Run(test.path, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			h.ServeHTTP(w, httptest.NewRequest(http.MethodGet, test.path, nil))
+
+			if w.Code != test.code {
+				t.Fatalf("\ngot  %d\nwant %d", w.Code, test.code)
+			}
+
+			if got := w.Header().Get("Content-Type"); got != test.contentType {
+				t.Fatalf("\ngot  %s\nwant %s", got, test.contentType)
+			}
+
+			if !strings.Contains(w.Body.String(), test.want) {
+				t.Fatalf("\ngot  %s\nwant %s", w.Body.String(), test.want)
+			}
+		})
+	}
+}
+
+func TestSErrorsDashboardHandlerEmpty(t *testing.T) {
+	e := New(nil, nil)
+	w := httptest.NewRecorder()
+	e.DashboardHandler().ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/records.json", nil))
+
+	if w.Body.String() != "[]" {
+		t.Fatalf("\ngot  %s\nwant []", w.Body.String())
+	}
+}
+
+func TestSErrorsDashboardHandlerSpilled(t *testing.T) {
+	e := New(nil, nil, WithSpillDir(t.TempDir(), 2))
+	defer e.RemoveSpill()
+	for _, m := range []string{"a", "b", "c", "d"} {
+		e.Warn(testTime, m)
+	}
+
+	h := e.DashboardHandler()
+	for _, path := range []string{"/records.json", "/records.ndjson", "/report.html"} {
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, httptest.NewRequest(http.MethodGet, path, nil))
+		for _, m := range []string{"a", "d"} {
+			if !strings.Contains(w.Body.String(), `"msg":"`+m+`"`) && !strings.Contains(w.Body.String(), ">"+m+"<") {
+				t.Fatalf("%s\ngot  %s\nwant %s", path, w.Body, m)
+			}
+		}
+	}
+}
+
+func TestSErrorsDashboardHandlerSpillError(t *testing.T) {
+	tests := []struct {
+		name  string
+		store *failingStore
+		want  []string
+	}{
+		{"write", &failingStore{MemoryStore: NewMemoryStore(), n: 1}, []string{"a", "d"}},
+		{"read", &failingStore{MemoryStore: NewMemoryStore(), n: 4, loadErr: errors.New("disk gone")}, []string{"d"}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			e := New(nil, nil, WithSpillStore(test.store, 2))
+			for _, m := range []string{"a", "b", "c", "d"} {
+				e.Warn(testTime, m)
+			}
+
+			h := e.DashboardHandler()
+			for _, path := range []string{"/records.json", "/records.ndjson", "/report.html", "/records?format=json"} {
+				w := httptest.NewRecorder()
+				h.ServeHTTP(w, httptest.NewRequest(http.MethodGet, path, nil))
+				if w.Code != http.StatusOK {
+					t.Fatalf("%s\ngot  %d\nwant 200", path, w.Code)
+				}
+
+				for _, m := range test.want {
+					if !strings.Contains(w.Body.String(), `"msg":"`+m+`"`) && !strings.Contains(w.Body.String(), ">"+m+"<") {
+						t.Fatalf("%s\ngot  %s\nwant %s", path, w.Body, m)
+					}
+				}
+
+				if strings.Contains(w.Body.String(), "disk") {
+					t.Fatalf("%s\ngot  %s\nwant no error in the body", path, w.Body)
+				}
+
+				if w.Result().Header.Get(DashboardErrorHeader) == "" && w.Result().Trailer.Get(DashboardErrorHeader) == "" {
+					t.Fatalf("%s\ngot  no %s\nwant the spill error", path, DashboardErrorHeader)
+				}
+			}
+		})
+	}
+}
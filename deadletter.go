@@ -0,0 +1,86 @@
+package serrors
+
+import (
+	"sync"
+	"time"
+)
+
+// FailedBatch is a batch of records a sink could not deliver
+type FailedBatch struct {
+	// Time the delivery failed
+	Time time.Time
+	// Err returned by the delivery
+	Err error
+	// Records is the number of records in Body
+	Records int
+	// Body is the undelivered records as NDJSON
+	Body []byte
+}
+
+// DeadLetter keeps the batches sinks failed to deliver so they can be inspected or retried. It
+// holds at most max batches and drops the oldest when full. It is safe for concurrent use.
+type DeadLetter struct {
+	mu      sync.Mutex
+	max     int
+	batches []FailedBatch
+	// dropped counts batches pushed out by newer ones
+	dropped int
+}
+
+// NewDeadLetter creates a DeadLetter holding at most max batches. max < 1 is treated as 1.
+func NewDeadLetter(max int) *DeadLetter {
+	if max < 1 {
+		max = 1
+	}
+
+	return &DeadLetter{max: max}
+}
+
+// Add stores b, dropping the oldest batch if the DeadLetter is full
+func (d *DeadLetter) Add(b FailedBatch) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if len(d.batches) >= d.max {
+		d.batches = d.batches[1:]
+		d.dropped++
+	}
+
+	d.batches = append(d.batches, b)
+}
+
+// Len returns the number of batches held
+func (d *DeadLetter) Len() int {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	return len(d.batches)
+}
+
+// Dropped returns the number of batches dropped because the DeadLetter was full
+func (d *DeadLetter) Dropped() int {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	return d.dropped
+}
+
+// Batches returns a copy of the batches held, oldest first
+func (d *DeadLetter) Batches() []FailedBatch {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	bs := make([]FailedBatch, len(d.batches))
+	copy(bs, d.batches)
+	return bs
+}
+
+// Drain removes and returns every batch held, oldest first
+func (d *DeadLetter) Drain() []FailedBatch {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	bs := d.batches
+	d.batches = nil
+	return bs
+}
@@ -0,0 +1,30 @@
+package serrors
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestDeadLetter(t *testing.T) {
+	d := NewDeadLetter(2)
+	for _, body := range []string{"a", "b", "c"} {
+		d.Add(FailedBatch{Err: errors.New(body), Records: 1, Body: []byte(body)})
+	}
+
+	if d.Len() != 2 || d.Dropped() != 1 {
+		t.Fatalf("\ngot  %d %d\nwant 2 1", d.Len(), d.Dropped())
+	}
+
+	bs := d.Drain()
+	if len(bs) != 2 || string(bs[0].Body) != "b" || string(bs[1].Body) != "c" {
+		t.Fatalf("\ngot  %v\nwant b and c", bs)
+	}
+
+	if d.Len() != 0 {
+		t.Fatalf("\ngot  %d\nwant 0", d.Len())
+	}
+
+	if NewDeadLetter(0).max != 1 {
+		t.Fatalf("\ngot  %d\nwant 1", NewDeadLetter(0).max)
+	}
+}
@@ -0,0 +1,63 @@
+package serrors
+
+// EmptyJSON is what MarshalJSON writes for a collection without records
+type EmptyJSON int
+
+const (
+	// EmptyArray writes []. This is the default.
+	EmptyArray EmptyJSON = iota
+	// EmptyNull writes null
+	EmptyNull
+)
+
+// WithEmptyJSON sets what MarshalJSON writes for the records when there are none. To leave an
+// empty collection out of a struct altogether, tag the field omitzero, which uses IsZero from Go
+// 1.24, or omitempty and set it with OrNil.
+func WithEmptyJSON(mode EmptyJSON) Option {
+	return func(e *SErrors) {
+		e.emptyJSON = mode
+	}
+}
+
+// IsZero returns true if e is nil or holds no records, including spilled ones. It lets
+// encoding/json omit empty collections from fields tagged omitzero.
+func (e *SErrors) IsZero() bool {
+	if e == nil {
+		return true
+	}
+
+	return e.IsEmpty() && e.Spilled() == 0
+}
+
+// OrNil returns nil if e is empty, see IsZero, and e otherwise, so it can be assigned to a field
+// tagged omitempty:
+//
+//	resp := struct {
+//		Errors *serrors.SErrors `json:"errors,omitempty"`
+//	}{Errors: errs.OrNil()}
+func (e *SErrors) OrNil() *SErrors {
+	if e.IsZero() {
+		return nil
+	}
+
+	return e
+}
+
+// recordsArray renders the records in memory as a JSON array, regardless of whether e uses the
+// JSON or text handler, or as set by WithEmptyJSON when there are none. e.mu must be held.
+func (e *SErrors) recordsArray() ([]byte, error) {
+	if len(e.records) == 0 && e.emptyJSON == EmptyNull {
+		return []byte("null"), nil
+	}
+
+	if e.cache == nil {
+		return encodeRecords(e.records, e.jsonOpts(), e.profiled)
+	}
+
+	raw, err := e.cachedJSON()
+	if err != nil {
+		return nil, err
+	}
+
+	return encodeArray(raw)
+}
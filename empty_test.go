@@ -0,0 +1,69 @@
+package serrors
+
+import (
+	"encoding/json"
+	"log/slog"
+	"testing"
+)
+
+func TestSErrorsEmptyJSON(t *testing.T) {
+	tests := []struct {
+		name    string
+		options []Option
+		want    string
+	}{
+		{name: "default", want: `[]`},
+		{name: "array", options: []Option{WithEmptyJSON(EmptyArray)}, want: `[]`},
+		{name: "null", options: []Option{WithEmptyJSON(EmptyNull)}, want: `null`},
+		{name: "meta", options: []Option{WithEmptyJSON(EmptyNull), WithMetaBlock()}, want: `{"meta":{},"errors":null}`},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			b, err := json.Marshal(New(nil, nil, test.options...))
+			if err != nil || string(b) != test.want {
+				t.Fatalf("\ngot  %s %v\nwant %s", b, err, test.want)
+			}
+		})
+	}
+}
+
+func TestSErrorsOmitEmpty(t *testing.T) {
+	empty := New(nil, nil)
+	full := New(nil, nil)
+	full.Add(testTime, slog.LevelError, "m")
+
+	type resp struct {
+		OK     bool     `json:"ok"`
+		Errors *SErrors `json:"errors,omitempty"`
+	}
+
+	type respZero struct {
+		OK     bool     `json:"ok"`
+		Errors *SErrors `json:"errors,omitzero"`
+	}
+
+	tests := []struct {
+		name string
+		v    any
+		want string
+	}{
+		{name: "omitempty empty", v: resp{OK: true, Errors: empty.OrNil()}, want: `{"ok":true}`},
+		{
+			name: "omitempty full",
+			v:    resp{Errors: full.OrNil()},
+			want: `{"ok":false,"errors":[{"time":"2000-01-02T03:04:05Z","level":"ERROR","msg":"m"}]}`,
+		},
+		{name: "omitzero empty", v: respZero{OK: true, Errors: empty}, want: `{"ok":true}`},
+		{name: "omitzero nil", v: respZero{OK: true}, want: `{"ok":true}`},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			b, err := json.Marshal(test.v)
+			if err != nil || string(b) != test.want {
+				t.Fatalf("\ngot  %s %v\nwant %s", b, err, test.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,218 @@
+package serrors
+
+import (
+	"fmt"
+	"log/slog"
+)
+
+// CollectionError is the error returned by SErrors.Err and SErrors.ToError, so a collection can
+// flow through functions returning error. Get the collection back with errors.As:
+//
+//	var ce *serrors.CollectionError
+//	if errors.As(err, &ce) {
+//		ce.Errs.Log()
+//	}
+type CollectionError struct {
+	// Errs is the collection the error reports
+	Errs *SErrors
+	// compact is set by ToError for the message of compactSummary
+	compact bool
+}
+
+// Error summarizes the collection as the level and message of its first record at the highest
+// level and how many other records it holds, as the compact summary of ToError, or as set by
+// WithErrorFormat. It reflects records added or removed after Err or ToError was called.
+func (c *CollectionError) Error() string {
+	e := c.Errs
+	e.mu.RLock()
+	format := e.errFormat
+	e.mu.RUnlock()
+
+	switch {
+	case format != nil:
+		return format(e.errorSummary())
+	case c.compact:
+		return compactSummary(e.errorSummary())
+	}
+
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+
+	total := len(e.records)
+	if e.spill != nil {
+		total += e.spill.count
+	}
+
+	switch {
+	case total == 0:
+		return "serrors: no records"
+	case len(e.records) == 0:
+		return fmt.Sprintf("serrors: %d spilled records", total)
+	}
+
+	top := e.records[0]
+	for _, r := range e.records[1:] {
+		if r.Level > top.Level {
+			top = r
+		}
+	}
+
+	s := fmt.Sprintf("%s: %s", levelName(top.Level), top.Message)
+	if total > 1 {
+		s += fmt.Sprintf(" (and %d more)", total-1)
+	}
+
+	return s
+}
+
+// Err returns e as an error, or nil if e is nil or holds no records, see IsZero. SErrors cannot
+// implement error itself because its Error method adds a record, so return e.Err() instead:
+//
+//	func run() error {
+//		errs := serrors.New(os.Stderr, nil)
+//		...
+//		return errs.Err()
+//	}
+//
+// Returning e itself from a function with an error result does not compile, and returning a nil
+// *SErrors through an error interface would make it non-nil, which Err avoids.
+func (e *SErrors) Err() error {
+	if e.IsZero() {
+		return nil
+	}
+
+	return &CollectionError{Errs: e}
+}
+
+// ToError is Err with a compact message: the number of records, the highest level and the last
+// message, e.g. "serrors: 3 records, highest ERROR, last: connection refused". It returns nil if
+// e is nil or holds no records, and errors.As gets the collection back from it as from Err.
+// WithErrorFormat overrides the message.
+func (e *SErrors) ToError() error {
+	if e.IsZero() {
+		return nil
+	}
+
+	return &CollectionError{Errs: e, compact: true}
+}
+
+// compactSummary returns the message of the error returned by ToError
+func compactSummary(s ErrorSummary) string {
+	switch {
+	case s.Count == 0:
+		return "serrors: no records"
+	case s.Last.Message == "" && s.Last.Time.IsZero():
+		// Every record was spilled.
+		return fmt.Sprintf("serrors: %d spilled records, highest %s", s.Count, levelName(s.Level))
+	case s.Count == 1:
+		return fmt.Sprintf("serrors: 1 record, %s: %s", levelName(s.Level), s.Last.Message)
+	}
+
+	return fmt.Sprintf("serrors: %d records, highest %s, last: %s", s.Count, levelName(s.Level), s.Last.Message)
+}
+
+// ErrorSummary describes a collection for the message of the errors returned by Err and ToError,
+// see WithErrorFormat
+type ErrorSummary struct {
+	// Count is the number of records, including spilled ones
+	Count int
+	// Level is the highest level of the records
+	Level slog.Level
+	// Last is the last record in memory, or the zero record if every record was spilled
+	Last slog.Record
+}
+
+// WithErrorFormat sets how the errors returned by Err and ToError format their message from a
+// summary of the collection, overriding the default messages:
+//
+//	serrors.WithErrorFormat(func(s serrors.ErrorSummary) string {
+//		return fmt.Sprintf("%d records, highest %s, last: %s", s.Count, s.Level, s.Last.Message)
+//	})
+func WithErrorFormat(format func(ErrorSummary) string) Option {
+	return func(e *SErrors) {
+		e.errFormat = format
+	}
+}
+
+// errorSummary returns the summary of e for WithErrorFormat
+func (e *SErrors) errorSummary() ErrorSummary {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+
+	s := ErrorSummary{Count: len(e.records), Level: e.level}
+	if e.spill != nil {
+		s.Count += e.spill.count
+	}
+
+	if len(e.records) > 0 {
+		s.Last = e.records[len(e.records)-1]
+	}
+
+	return s
+}
+
+// Unwrap returns the errors of the collection, see SErrors.Unwrap, so errors.Is and errors.As
+// look inside it
+func (c *CollectionError) Unwrap() []error {
+	return c.Errs.Unwrap()
+}
+
+// Unwrap returns an error for each record in memory, whose message is the record's message and
+// which wraps the error values of its attrs, including attrs in groups. Together with Err this lets
+// errors.Is and errors.As find a Go error added as an attr:
+//
+//	errs.Error(t, "read config", slog.Any("err", err))
+//	...
+//	errors.Is(errs.Err(), fs.ErrNotExist)
+//
+// Spilled records and records parsed from text or JSON hold the error's message rather than the
+// error, so they are not matched.
+func (e *SErrors) Unwrap() []error {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+
+	errs := make([]error, len(e.records))
+	for i, r := range e.records {
+		errs[i] = recordError{r}
+	}
+
+	return errs
+}
+
+// recordError is a record as an error, see SErrors.Unwrap
+type recordError struct {
+	r slog.Record
+}
+
+// Error returns the message of the record
+func (re recordError) Error() string {
+	return re.r.Message
+}
+
+// Unwrap returns the error values of the record's attrs
+func (re recordError) Unwrap() []error {
+	var errs []error
+	re.r.Attrs(func(a slog.Attr) bool {
+		errs = attrErrors(errs, a)
+		return true
+	})
+
+	return errs
+}
+
+// attrErrors appends the error values in a, including in its group, to errs
+func attrErrors(errs []error, a slog.Attr) []error {
+	v := a.Value.Resolve()
+	switch v.Kind() {
+	case slog.KindGroup:
+		for _, ga := range v.Group() {
+			errs = attrErrors(errs, ga)
+		}
+	case slog.KindAny:
+		if err, ok := v.Any().(error); ok {
+			errs = append(errs, err)
+		}
+	}
+
+	return errs
+}
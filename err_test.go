@@ -0,0 +1,136 @@
+package serrors
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"log/slog"
+	"testing"
+)
+
+func TestSErrorsErr(t *testing.T) {
+	var nilErrs *SErrors
+	if err := nilErrs.Err(); err != nil {
+		t.Fatalf("\ngot  %v\nwant nil", err)
+	}
+
+	e := New(nil, nil)
+	if err := e.Err(); err != nil {
+		t.Fatalf("\ngot  %v\nwant nil", err)
+	}
+
+	e.Warn(testTime, "slow")
+	err := e.Err()
+	if err == nil || err.Error() != "WARN: slow" {
+		t.Fatalf("\ngot  %v\nwant WARN: slow", err)
+	}
+
+	e.Error(testTime, "disk full")
+	e.Error(testTime, "retry failed")
+	e.Add(testTime, slog.LevelInfo, "done")
+	if want := "ERROR: disk full (and 3 more)"; err.Error() != want {
+		t.Fatalf("\ngot  %s\nwant %s", err.Error(), want)
+	}
+
+	var ce *CollectionError
+	if !errors.As(err, &ce) || ce.Errs != e {
+		t.Fatal("errors.As did not return the collection")
+	}
+}
+
+func TestSErrorsWithErrorFormat(t *testing.T) {
+	e := New(nil, nil, WithErrorFormat(func(s ErrorSummary) string {
+		return fmt.Sprintf("%d/%s/%s", s.Count, s.Level, s.Last.Message)
+	}))
+	if err := e.Err(); err != nil {
+		t.Fatalf("\ngot  %v\nwant nil", err)
+	}
+
+	e.Error(testTime, "disk full", slog.Any("err", fs.ErrPermission))
+	e.Warn(testTime, "slow")
+	e.Info(testTime, "done")
+
+	err := e.Err()
+	if want := "3/ERROR/done"; err == nil || err.Error() != want {
+		t.Fatalf("\ngot  %v\nwant %s", err, want)
+	}
+
+	var ce *CollectionError
+	if !errors.As(err, &ce) || ce.Errs != e || !errors.Is(err, fs.ErrPermission) {
+		t.Fatal("errors.As did not return the collection")
+	}
+}
+
+func TestSErrorsErrEmptied(t *testing.T) {
+	e := New(nil, nil)
+	e.Error(testTime, "only")
+	err := e.Err()
+	e.Reset()
+
+	if want := "serrors: no records"; err.Error() != want {
+		t.Fatalf("\ngot  %s\nwant %s", err.Error(), want)
+	}
+}
+
+type codeError struct{ code int }
+
+func (c *codeError) Error() string { return "code error" }
+
+func TestSErrorsUnwrap(t *testing.T) {
+	e := New(nil, nil)
+	e.Error(testTime, "read config", slog.Any("err", fs.ErrNotExist))
+	e.Error(testTime, "call", slog.Group("rpc", slog.Any("error", fmt.Errorf("wrap: %w", &codeError{7}))))
+	e.Warn(testTime, "no error", slog.String("err", "text only"))
+
+	if got := e.Unwrap(); len(got) != 3 || got[0].Error() != "read config" {
+		t.Fatalf("\ngot  %v\nwant 3 record errors", got)
+	}
+
+	err := e.Err()
+	if !errors.Is(err, fs.ErrNotExist) {
+		t.Fatal("errors.Is did not find fs.ErrNotExist")
+	}
+
+	var ce *codeError
+	if !errors.As(err, &ce) || ce.code != 7 {
+		t.Fatal("errors.As did not find the codeError")
+	}
+
+	if errors.Is(err, fs.ErrPermission) {
+		t.Fatal("errors.Is found an error that was not added")
+	}
+}
+
+func TestSErrorsToError(t *testing.T) {
+	var nilErrs *SErrors
+	if err := nilErrs.ToError(); err != nil {
+		t.Fatalf("\ngot  %v\nwant nil", err)
+	}
+
+	e := New(nil, nil)
+	if err := e.ToError(); err != nil {
+		t.Fatalf("\ngot  %v\nwant nil", err)
+	}
+
+	e.Warn(testTime, "slow")
+	err := e.ToError()
+	if want := "serrors: 1 record, WARN: slow"; err == nil || err.Error() != want {
+		t.Fatalf("\ngot  %v\nwant %s", err, want)
+	}
+
+	e.Error(testTime, "disk full", slog.Any("err", fs.ErrPermission))
+	e.Info(testTime, "connection refused")
+	if want := "serrors: 3 records, highest ERROR, last: connection refused"; err.Error() != want {
+		t.Fatalf("\ngot  %s\nwant %s", err.Error(), want)
+	}
+
+	var ce *CollectionError
+	if !errors.As(err, &ce) || ce.Errs != e || !errors.Is(err, fs.ErrPermission) {
+		t.Fatal("errors.As did not return the collection")
+	}
+
+	e.Reset()
+	if want := "serrors: no records"; err.Error() != want {
+		t.Fatalf("\ngot  %s\nwant %s", err.Error(), want)
+	}
+}
@@ -0,0 +1,154 @@
+package serrors
+
+import (
+	"context"
+	_ "embed"
+	"errors"
+	"html/template"
+	"io"
+	"log/slog"
+)
+
+//go:embed report.html
+var reportHTML string
+
+// reportTemplate renders WriteHTML
+var reportTemplate = template.Must(template.New("report").Parse(reportHTML))
+
+// WriteNDJSON writes every record to w as one JSON object per line, regardless of whether the
+// SErrors uses the JSON or text handler. The output is compressed if WithCompression is used.
+func (e *SErrors) WriteNDJSON(w io.Writer) error {
+	return e.writeNDJSON(w, e.compression)
+}
+
+// writeNDJSON is WriteNDJSON compressing with c
+func (e *SErrors) writeNDJSON(w io.Writer, c Compression) error {
+	cw, err := c.NewWriter(w)
+	if err != nil {
+		return err
+	}
+
+	h := slog.NewJSONHandler(cw, e.jsonOpts())
+	err = e.eachRecord(func(r slog.Record) error {
+		return h.Handle(context.Background(), e.profiled(r))
+	})
+
+	return errors.Join(err, cw.Close())
+}
+
+// WriteTo writes every record, including spilled ones, to w as Log renders them: one JSON object
+// or text line per record, or as the Formatter registered in place of FormatJSON or FormatText
+// renders them. Records are streamed one at a time, so huge collections can be dumped to files or
+// HTTP responses without building the output in memory. It implements io.WriterTo.
+func (e *SErrors) WriteTo(w io.Writer) (int64, error) {
+	e.mu.RLock()
+	f := e.outputFormat()
+	e.mu.RUnlock()
+
+	cw := &countWriter{w: w}
+	err := e.writeFormat(cw, f)
+	return cw.n, err
+}
+
+// countWriter counts the bytes written to w
+type countWriter struct {
+	w io.Writer
+	n int64
+}
+
+// Write writes p to w
+func (c *countWriter) Write(p []byte) (int, error) {
+	n, err := c.w.Write(p)
+	c.n += int64(n)
+	return n, err
+}
+
+// reportRow is a record prepared for reportTemplate
+type reportRow struct {
+	Time    string
+	Level   string
+	Message string
+	Attrs   []string
+}
+
+// WriteHTML writes a standalone HTML report of every record, including spilled ones, to w. If the
+// spilled records cannot be read, the report holds the records that could be and the read error
+// is returned after it is written.
+func (e *SErrors) WriteHTML(w io.Writer) error {
+	rs, readErr := e.allRecords()
+
+	data := struct {
+		Level  slog.Level
+		Counts map[string]int
+		Rows   []reportRow
+	}{
+		Counts: map[string]int{},
+		Rows:   make([]reportRow, len(rs)),
+	}
+
+	for i, r := range rs {
+		if i == 0 || r.Level > data.Level {
+			data.Level = r.Level
+		}
+
+		data.Counts[r.Level.String()]++
+		data.Rows[i] = reportRow{
+			Time:    e.locale.formatTime(r.Time),
+			Level:   r.Level.String(),
+			Message: r.Message,
+			Attrs:   flattenAttrs(r, e.locale),
+		}
+	}
+
+	if err := reportTemplate.Execute(w, data); err != nil {
+		return err
+	}
+
+	return readErr
+}
+
+// recordsJSON renders rs as a JSON array using the JSON handler and e.opts
+func (e *SErrors) recordsJSON(rs []slog.Record) ([]byte, error) {
+	return encodeRecords(rs, e.opts, nil)
+}
+
+// FlattenAttrs renders the attrs of r as key=value strings with group keys joined by dots, for
+// line based views. The attrs of a group without a key are inlined, as slog does.
+func FlattenAttrs(r slog.Record) []string {
+	return flattenAttrs(r, nil)
+}
+
+// flattenAttrs is FlattenAttrs with values formatted for l
+func flattenAttrs(r slog.Record, l *locale) []string {
+	var s []string
+	r.Attrs(func(a slog.Attr) bool {
+		s = append(s, flattenAttr("", a, l)...)
+		return true
+	})
+
+	return s
+}
+
+// flattenAttr renders a as key=value strings with group keys joined by dots
+func flattenAttr(prefix string, a slog.Attr, l *locale) []string {
+	a.Value = a.Value.Resolve()
+	key := a.Key
+	switch {
+	case a.Key == "" && a.Value.Kind() == slog.KindGroup:
+		// Attrs of a group without a key are inlined, as slog does.
+		key = prefix
+	case prefix != "":
+		key = prefix + "." + key
+	}
+
+	if a.Value.Kind() != slog.KindGroup {
+		return []string{key + "=" + l.formatValue(a.Value)}
+	}
+
+	var s []string
+	for _, g := range a.Value.Group() {
+		s = append(s, flattenAttr(key, g, l)...)
+	}
+
+	return s
+}
@@ -0,0 +1,89 @@
+package serrors
+
+import (
+	"bytes"
+	"log/slog"
+	"strings"
+	"testing"
+)
+
+func TestSErrorsWriteNDJSON(t *testing.T) {
+	for _, test := range testAttrParamsJSON {
+		t.Run(test.name, func(t *testing.T) {
+			var want string
+			e := NewTextHandler(nil, &test.opts)
+
+			for _, p := range test.params {
+				e.Add(testTime, p.level, p.msg, p.attrs...)
+				want += p.want + "\n"
+			}
+
+			got := bytes.NewBuffer(nil)
+			if err := e.WriteNDJSON(got); err != nil {
+				t.Fatalf("\ngot  %s\nwant nil", err.Error())
+			}
+
+			if got.String() != want {
+				t.Fatalf("\ngot  %s\nwant %s", got, want)
+			}
+		})
+	}
+}
+
+func TestSErrorsWriteTo(t *testing.T) {
+	tests := []struct {
+		name string
+		e    *SErrors
+		want string
+	}{
+		{
+			"json",
+			New(nil, nil, WithArena(2)),
+			`{"time":"2000-01-02T03:04:05Z","level":"INFO","msg":"a","i":0}` + "\n" +
+				`{"time":"2000-01-02T03:04:05Z","level":"INFO","msg":"b","i":1}` + "\n" +
+				`{"time":"2000-01-02T03:04:05Z","level":"INFO","msg":"c","i":2}` + "\n",
+		},
+		{
+			"text",
+			NewTextHandler(nil, nil, WithArena(2)),
+			"time=2000-01-02T03:04:05.000Z level=INFO msg=a i=0\n" +
+				"time=2000-01-02T03:04:05.000Z level=INFO msg=b i=1\n" +
+				"time=2000-01-02T03:04:05.000Z level=INFO msg=c i=2\n",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			for i, msg := range []string{"a", "b", "c"} {
+				test.e.Info(testTime, msg, slog.Int("i", i))
+			}
+
+			got := bytes.NewBuffer(nil)
+			n, err := test.e.WriteTo(got)
+			if err != nil || got.String() != test.want || n != int64(len(test.want)) {
+				t.Fatalf("\ngot  %d %s, %v\nwant %d %s", n, got, err, len(test.want), test.want)
+			}
+		})
+	}
+}
+
+func TestSErrorsWriteHTML(t *testing.T) {
+	e := New(nil, nil)
+	e.Warn(testTime, "<m>", slog.Group("g", slog.Int("a", 1)))
+	e.Error(testTime, "m2")
+
+	got := bytes.NewBuffer(nil)
+	if err := e.WriteHTML(got); err != nil {
+		t.Fatalf("\ngot  %s\nwant nil", err.Error())
+	}
+
+	for _, want := range []string{
+		`Highest level: <span class="ERROR">ERROR</span>`,
+		`<span class="WARN">WARN</span>: 1`,
+		`<td>2000-01-02T03:04:05.000Z</td><td class="WARN">WARN</td><td>&lt;m&gt;</td><td class="attrs">g.a=1`,
+	} {
+		if !strings.Contains(got.String(), want) {
+			t.Fatalf("\ngot  %s\nwant %s", got, want)
+		}
+	}
+}
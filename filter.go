@@ -0,0 +1,59 @@
+package serrors
+
+import "log/slog"
+
+// Filter returns a new SErrors holding the records keep returns true for, rendered like e, to
+// derive sub-collections without rebuilding the handlers. Spilled records are not included.
+func (e *SErrors) Filter(keep func(slog.Record) bool) *SErrors {
+	return e.filter(func(_ int, r slog.Record) bool { return keep(r) })
+}
+
+// filter is Filter passing keep the index of each record too
+func (e *SErrors) filter(keep func(i int, r slog.Record) bool) *SErrors {
+	e.expire()
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+
+	f := e.emptyCopy()
+	for i, r := range e.records {
+		if keep(i, r) {
+			f.records = append(f.records, r)
+			f.seqs = append(f.seqs, e.seqs[i])
+		}
+	}
+	f.reindex()
+	f.recomputeLevel()
+
+	return f
+}
+
+// FilterByLevel returns a new SErrors holding the records at or above min, see Filter
+func (e *SErrors) FilterByLevel(min slog.Level) *SErrors {
+	return e.Filter(func(r slog.Record) bool { return r.Level >= min })
+}
+
+// SplitByLevel returns a new SErrors per level holding the records of that level, rendered like e,
+// so each level can be sent to a different destination. Spilled records are not included.
+func (e *SErrors) SplitByLevel() map[slog.Level]*SErrors {
+	e.expire()
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+
+	split := map[slog.Level]*SErrors{}
+	for i, r := range e.records {
+		s, ok := split[r.Level]
+		if !ok {
+			s = e.emptyCopy()
+			split[r.Level] = s
+		}
+		s.records = append(s.records, r)
+		s.seqs = append(s.seqs, e.seqs[i])
+	}
+
+	for _, s := range split {
+		s.reindex()
+		s.recomputeLevel()
+	}
+
+	return split
+}
@@ -0,0 +1,62 @@
+package serrors
+
+import (
+	"log/slog"
+	"testing"
+)
+
+func TestSErrorsFilter(t *testing.T) {
+	e := NewTextHandler(nil, nil)
+	e.Error(testTime, "a", slog.String("user", "x"))
+	e.Warn(testTime, "b")
+	e.Info(testTime, "c", slog.String("user", "y"))
+
+	f := e.Filter(func(r slog.Record) bool {
+		_, ok := GetAttrPath(r, "user")
+		return ok
+	})
+	f.Debug(testTime, "d")
+
+	want := "time=2000-01-02T03:04:05.000Z level=ERROR msg=a user=x\n" +
+		"time=2000-01-02T03:04:05.000Z level=INFO msg=c user=y\n" +
+		"time=2000-01-02T03:04:05.000Z level=DEBUG msg=d\n"
+	if got := f.String(); got != want {
+		t.Fatalf("\ngot  %s\nwant %s", got, want)
+	}
+
+	if f.Level() != slog.LevelError || len(e.Records()) != 3 {
+		t.Fatalf("\ngot  %s, %d records in e\nwant ERROR, 3", f.Level(), len(e.Records()))
+	}
+
+	if none := e.Filter(func(slog.Record) bool { return false }); !none.IsZero() {
+		t.Fatalf("\ngot  %s\nwant no records", none.String())
+	}
+}
+
+func TestSErrorsFilterByLevel(t *testing.T) {
+	e := NewTextHandler(nil, nil)
+	e.Info(testTime, "a")
+	e.Error(testTime, "b")
+	e.Warn(testTime, "c")
+	e.Error(testTime, "d")
+
+	want := "time=2000-01-02T03:04:05.000Z level=ERROR msg=b\n" +
+		"time=2000-01-02T03:04:05.000Z level=WARN msg=c\n" +
+		"time=2000-01-02T03:04:05.000Z level=ERROR msg=d\n"
+	if got := e.FilterByLevel(slog.LevelWarn).String(); got != want {
+		t.Fatalf("\ngot  %s\nwant %s", got, want)
+	}
+
+	split := e.SplitByLevel()
+	counts := map[slog.Level]int{}
+	for l, s := range split {
+		counts[l] = len(s.Records())
+		if s.Level() != l {
+			t.Fatalf("\ngot  %s\nwant %s", s.Level(), l)
+		}
+	}
+
+	if len(counts) != 3 || counts[slog.LevelError] != 2 || counts[slog.LevelInfo] != 1 {
+		t.Fatalf("\ngot  %v\nwant INFO:1 WARN:1 ERROR:2", counts)
+	}
+}
@@ -0,0 +1,123 @@
+package serrors
+
+import (
+	"log/slog"
+	"time"
+)
+
+// flush holds the state of the auto-flush options
+type flush struct {
+	// level is the lowest level of a record that flushes the collection when onLevel is set
+	level   slog.Level
+	onLevel bool
+	// every is the number of records in memory that flushes the collection
+	every int
+	// interval is how often the collection is flushed
+	interval time.Duration
+	// err is the first error flushing the collection
+	err error
+}
+
+// flushOption returns an Option changing the flush state of e
+func flushOption(fn func(f *flush)) Option {
+	return func(e *SErrors) {
+		if e.flush == nil {
+			e.flush = &flush{}
+		}
+		fn(e.flush)
+	}
+}
+
+// WithAutoFlush logs and empties the collection, see LogAndClear, as soon as a record at or above
+// level is added, so the records leading up to a failure are only logged when something goes
+// wrong. The first error logging them is returned by FlushErr.
+func WithAutoFlush(level slog.Level) Option {
+	return flushOption(func(f *flush) {
+		f.level, f.onLevel = level, true
+	})
+}
+
+// WithFlushEvery logs and empties the collection like WithAutoFlush once n records are held in
+// memory, so a long-running collector drains itself instead of growing forever
+func WithFlushEvery(n int) Option {
+	return flushOption(func(f *flush) {
+		f.every = max(n, 1)
+	})
+}
+
+// WithFlushInterval logs and empties the collection like WithAutoFlush every d if it holds any
+// records. Close stops the ticker after a last flush.
+func WithFlushInterval(d time.Duration) Option {
+	return flushOption(func(f *flush) {
+		f.interval = d
+	})
+}
+
+// FlushErr returns the first error logging the records flushed by WithAutoFlush, WithFlushEvery
+// or WithFlushInterval
+func (e *SErrors) FlushErr() error {
+	if e.flush == nil {
+		return nil
+	}
+
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+
+	return e.flush.err
+}
+
+// flushDue reports whether storing r should flush the collection. e.mu must be held.
+func (e *SErrors) flushDue(r slog.Record) bool {
+	f := e.flush
+	if f == nil {
+		return false
+	}
+
+	return f.onLevel && r.Level >= f.level || f.every > 0 && len(e.records) >= f.every
+}
+
+// autoFlush logs and empties the collection, keeping the first error. e.mu must not be held.
+func (e *SErrors) autoFlush() {
+	err := e.LogAndClear()
+	if err == nil {
+		return
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if e.flush.err == nil {
+		e.flush.err = err
+	}
+}
+
+// startFlusher starts the ticker of WithFlushInterval, which Close stops
+func (e *SErrors) startFlusher() {
+	if e.flush == nil || e.flush.interval <= 0 || !e.track() {
+		return
+	}
+
+	go func() {
+		defer e.wg.Done()
+
+		ticker := time.NewTicker(e.flush.interval)
+		defer ticker.Stop()
+
+		for {
+			done := false
+			select {
+			case <-e.done:
+				done = true
+			case <-ticker.C:
+			}
+
+			if e.Count() > 0 {
+				e.autoFlush()
+			}
+
+			if done {
+				return
+			}
+		}
+	}()
+}
@@ -0,0 +1,93 @@
+package serrors
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"log/slog"
+	"testing"
+	"time"
+)
+
+// failWriter fails every write
+type failWriter struct{}
+
+func (failWriter) Write([]byte) (int, error) { return 0, errors.New("fail") }
+
+func TestSErrorsWithAutoFlush(t *testing.T) {
+	got := bytes.NewBuffer(nil)
+	e := NewTextHandler(got, nil, WithAutoFlush(slog.LevelError))
+	e.Debug(testTime, "d")
+	e.Info(testTime, "i")
+	if got.Len() != 0 {
+		t.Fatalf("\ngot  %s\nwant nothing logged", got)
+	}
+
+	e.Error(testTime, "e")
+	e.Info(testTime, "after")
+
+	want := "time=2000-01-02T03:04:05.000Z level=DEBUG msg=d\n" +
+		"time=2000-01-02T03:04:05.000Z level=INFO msg=i\n" +
+		"time=2000-01-02T03:04:05.000Z level=ERROR msg=e\n"
+	if got.String() != want {
+		t.Fatalf("\ngot  %s\nwant %s", got, want)
+	}
+
+	if rs := e.Records(); len(rs) != 1 || rs[0].Message != "after" {
+		t.Fatalf("\ngot  %d records\nwant only after", len(rs))
+	}
+
+	if err := e.FlushErr(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestSErrorsWithAutoFlushError(t *testing.T) {
+	e := NewTextHandler(failWriter{}, nil, WithAutoFlush(slog.LevelWarn))
+	e.Warn(testTime, "w")
+	e.Error(testTime, "e")
+
+	if err := e.FlushErr(); err == nil || err.Error() != "fail" {
+		t.Fatalf("\ngot  %v\nwant fail", err)
+	}
+}
+
+func TestSErrorsWithFlushEvery(t *testing.T) {
+	got := bytes.NewBuffer(nil)
+	e := NewTextHandler(got, nil, WithFlushEvery(2))
+	for _, msg := range []string{"a", "b", "c"} {
+		e.Info(testTime, msg)
+	}
+
+	want := "time=2000-01-02T03:04:05.000Z level=INFO msg=a\n" +
+		"time=2000-01-02T03:04:05.000Z level=INFO msg=b\n"
+	if got.String() != want {
+		t.Fatalf("\ngot  %s\nwant %s", got, want)
+	}
+
+	if n := e.Count(); n != 1 {
+		t.Fatalf("\ngot  %d\nwant 1", n)
+	}
+}
+
+func TestSErrorsWithFlushInterval(t *testing.T) {
+	got := bytes.NewBuffer(nil)
+	e := NewTextHandler(got, nil, WithFlushInterval(time.Millisecond))
+	e.Info(testTime, "a")
+
+	deadline := time.Now().Add(5 * time.Second)
+	for e.Count() > 0 && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+
+	e.Info(testTime, "b")
+	if err := e.Close(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	want := "time=2000-01-02T03:04:05.000Z level=INFO msg=a\n" +
+		"time=2000-01-02T03:04:05.000Z level=INFO msg=b\n"
+	if got.String() != want || e.Count() != 0 {
+		t.Fatalf("\ngot  %s, %d held\nwant %s", got.String(), e.Count(), want)
+	}
+}
@@ -0,0 +1,29 @@
+package serrors
+
+import (
+	"context"
+	"iter"
+	"log/slog"
+)
+
+// Follow returns an iterator over the records added from the time iteration starts, like tail -f,
+// so in-process consumers can react live without polling. Iteration ends when ctx is done, the
+// loop breaks or e is closed. A consumer that falls far behind misses records rather than blocking Add, see
+// StreamHandler.
+func (e *SErrors) Follow(ctx context.Context) iter.Seq[slog.Record] {
+	return func(yield func(slog.Record) bool) {
+		_, ch, cancel := e.subscribe(false)
+		defer cancel()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case r, ok := <-ch:
+				if !ok || !yield(r) {
+					return
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,63 @@
+package serrors
+
+import (
+	"context"
+	"log/slog"
+	"testing"
+	"time"
+)
+
+func TestSErrorsFollow(t *testing.T) {
+	e := NewTextHandler(nil, nil)
+	e.Add(testTime, slog.LevelInfo, "before")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	go func() {
+		// Wait for the iterator to subscribe.
+		for {
+			e.mu.RLock()
+			n := len(e.subs)
+			e.mu.RUnlock()
+			if n > 0 {
+				break
+			}
+			time.Sleep(time.Millisecond)
+		}
+
+		for _, msg := range []string{"a", "b", "c"} {
+			e.Add(testTime, slog.LevelWarn, msg)
+		}
+	}()
+
+	var got []string
+	for r := range e.Follow(ctx) {
+		got = append(got, r.Message)
+		if len(got) == 2 {
+			break
+		}
+	}
+
+	if len(got) != 2 || got[0] != "a" || got[1] != "b" {
+		t.Fatalf("\ngot  %v\nwant [a b]", got)
+	}
+
+	// Breaking out of the loop unsubscribes.
+	e.mu.RLock()
+	n := len(e.subs)
+	e.mu.RUnlock()
+	if n != 0 {
+		t.Fatalf("\ngot  %d subscribers\nwant 0", n)
+	}
+}
+
+func TestSErrorsFollowCanceled(t *testing.T) {
+	e := NewTextHandler(nil, nil)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	for r := range e.Follow(ctx) {
+		t.Fatalf("\ngot  %s\nwant nothing", r.Message)
+	}
+}
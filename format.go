@@ -0,0 +1,155 @@
+package serrors
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"sync"
+)
+
+// Formatter renders records in an output format registered with RegisterFormat
+type Formatter interface {
+	// Render returns r in the format, including any line ending
+	Render(r slog.Record) ([]byte, error)
+	// ContentType is the MIME type of the output, used in HTTP responses
+	ContentType() string
+}
+
+// formats holds the registered formats
+var formats = struct {
+	sync.RWMutex
+	m map[Format]Formatter
+}{m: map[Format]Formatter{
+	FormatJSON: handlerFormat{json: true},
+	FormatText: handlerFormat{},
+}}
+
+// RegisterFormat makes f available under name to StringFormat, WriteFormat, String, WriteTo, the
+// records route of DashboardHandler, Convert and the serrors command, so output formats can be
+// added without forking the package. Registering a name again replaces its Formatter, including
+// FormatJSON and FormatText, which are registered by default and used by String and WriteTo.
+func RegisterFormat(name Format, f Formatter) {
+	formats.Lock()
+	defer formats.Unlock()
+
+	formats.m[name] = f
+}
+
+// LookupFormat returns the Formatter registered under name
+func LookupFormat(name Format) (Formatter, bool) {
+	formats.RLock()
+	defer formats.RUnlock()
+
+	f, ok := formats.m[name]
+	return f, ok
+}
+
+// lookupFormat returns the Formatter registered under name or an error
+func lookupFormat(name Format) (Formatter, error) {
+	f, ok := LookupFormat(name)
+	if !ok {
+		return nil, fmt.Errorf("serrors: unknown format %q", name)
+	}
+
+	return f, nil
+}
+
+// handlerFormat renders records with the slog JSON or text handler. A collection writing in it uses
+// its own handler options, ReplaceAttr, verbosity and profile, see SErrors.writeFormat.
+type handlerFormat struct {
+	json bool
+}
+
+// isHandlerFormat reports whether f is one of the default formats
+func isHandlerFormat(f Formatter) bool {
+	_, ok := f.(handlerFormat)
+	return ok
+}
+
+// Render implements Formatter with the default handler options
+func (f handlerFormat) Render(r slog.Record) ([]byte, error) {
+	var b bytes.Buffer
+	var h slog.Handler = slog.NewTextHandler(&b, nil)
+	if f.json {
+		h = slog.NewJSONHandler(&b, nil)
+	}
+
+	if err := h.Handle(context.Background(), r); err != nil {
+		return nil, err
+	}
+
+	return b.Bytes(), nil
+}
+
+// ContentType implements Formatter
+func (f handlerFormat) ContentType() string {
+	if f.json {
+		return "application/x-ndjson"
+	}
+
+	return "text/plain; charset=utf-8"
+}
+
+// StringFormat returns all records rendered in the registered format name
+func (e *SErrors) StringFormat(name Format) (string, error) {
+	var b bytes.Buffer
+	err := e.WriteFormat(&b, name)
+	return b.String(), err
+}
+
+// WriteFormat writes every record, including spilled ones, to w in the registered format name.
+// The default FormatJSON and FormatText render with the handler options, ReplaceAttr, verbosity and
+// profile of e, as Log does.
+func (e *SErrors) WriteFormat(w io.Writer, name Format) error {
+	f, err := lookupFormat(name)
+	if err != nil {
+		return err
+	}
+
+	return e.writeFormat(w, f)
+}
+
+// outputFormat returns the registered Formatter of the format e writes in, FormatJSON or
+// FormatText
+func (e *SErrors) outputFormat() Formatter {
+	name := FormatText
+	if e.json {
+		name = FormatJSON
+	}
+
+	f, ok := LookupFormat(name)
+	if !ok {
+		return handlerFormat{json: e.json}
+	}
+
+	return f
+}
+
+// writeFormat writes every record, including spilled ones, to w with f
+func (e *SErrors) writeFormat(w io.Writer, f Formatter) error {
+	if hf, ok := f.(handlerFormat); ok {
+		e.mu.RLock()
+		h := e.formatHandler(w, hf.json)
+		e.mu.RUnlock()
+
+		return e.eachRecord(func(r slog.Record) error {
+			e.mu.RLock()
+			r = e.prepareFormat(r, hf.json)
+			e.mu.RUnlock()
+
+			return h.Handle(context.Background(), r)
+		})
+	}
+
+	return e.eachRecord(func(r slog.Record) error {
+		b, err := f.Render(r)
+		if err != nil {
+			return err
+		}
+
+		_, err = w.Write(b)
+		return err
+	})
+}
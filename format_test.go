@@ -0,0 +1,106 @@
+package serrors
+
+import (
+	"bytes"
+	"log/slog"
+	"strings"
+	"testing"
+)
+
+// tsvFormat renders the level and message of a record separated by a tab
+type tsvFormat struct{}
+
+func (tsvFormat) Render(r slog.Record) ([]byte, error) {
+	return []byte(r.Level.String() + "\t" + r.Message + "\n"), nil
+}
+
+func (tsvFormat) ContentType() string { return "text/tab-separated-values" }
+
+func TestSErrorsStringFormat(t *testing.T) {
+	RegisterFormat("tsv", tsvFormat{})
+
+	e := New(nil, nil)
+	e.Error(testTime, "m", slog.Int("a", 1))
+	e.Warn(testTime, "m2")
+
+	tests := []struct {
+		name Format
+		want string
+	}{
+		{FormatJSON, `{"time":"2000-01-02T03:04:05Z","level":"ERROR","msg":"m","a":1}` + "\n" +
+			`{"time":"2000-01-02T03:04:05Z","level":"WARN","msg":"m2"}` + "\n"},
+		{FormatText, "time=2000-01-02T03:04:05.000Z level=ERROR msg=m a=1\n" +
+			"time=2000-01-02T03:04:05.000Z level=WARN msg=m2\n"},
+		{"tsv", "ERROR\tm\nWARN\tm2\n"},
+	}
+
+	for _, test := range tests {
+		t.Run(string(test.name), func(t *testing.T) {
+			got, err := e.StringFormat(test.name)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if got != test.want {
+				t.Fatalf("\ngot  %s\nwant %s", got, test.want)
+			}
+		})
+	}
+
+	if _, err := e.StringFormat("nope"); err == nil {
+		t.Fatal("want error for an unregistered format")
+	}
+}
+
+func TestConvertRegisteredFormat(t *testing.T) {
+	RegisterFormat("tsv", tsvFormat{})
+
+	in := `{"time":"2000-01-02T03:04:05Z","level":"ERROR","msg":"m"}` + "\n"
+	var got bytes.Buffer
+	if err := Convert(strings.NewReader(in), &got, FormatJSON, "tsv"); err != nil {
+		t.Fatal(err)
+	}
+
+	if want := "ERROR\tm\n"; got.String() != want {
+		t.Fatalf("\ngot  %s\nwant %s", got.String(), want)
+	}
+}
+
+func TestSErrorsWriteFormatOptions(t *testing.T) {
+	mask := func(_ []string, a slog.Attr) slog.Attr {
+		if a.Key == "password" {
+			a.Value = slog.StringValue("***")
+		}
+		return a
+	}
+	e := New(nil, &slog.HandlerOptions{ReplaceAttr: mask})
+	e.Error(testTime, "login", slog.String("password", "hunter2"))
+
+	for _, name := range []Format{FormatJSON, FormatText} {
+		got, err := e.StringFormat(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if strings.Contains(got, "hunter2") || !strings.Contains(got, "***") {
+			t.Fatalf("%s\ngot  %s\nwant the password masked", name, got)
+		}
+	}
+}
+
+func TestSErrorsStringRegisteredFormat(t *testing.T) {
+	RegisterFormat(FormatText, tsvFormat{})
+	t.Cleanup(func() { RegisterFormat(FormatText, handlerFormat{}) })
+
+	e := NewTextHandler(nil, nil)
+	e.Error(testTime, "m")
+
+	if got, want := e.String(), "ERROR\tm\n"; got != want {
+		t.Fatalf("\ngot  %s\nwant %s", got, want)
+	}
+
+	var b bytes.Buffer
+	if _, err := e.WriteTo(&b); err != nil || b.String() != "ERROR\tm\n" {
+		t.Fatalf("\ngot  %s, %v\nwant ERROR\tm", b.String(), err)
+	}
+}
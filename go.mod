@@ -1,3 +1,17 @@
 module github.com/chadeldridge/serrors
 
-go 1.21.1
+go 1.23
+
+require (
+	github.com/klauspost/compress v1.18.0
+	golang.org/x/term v0.27.0
+	golang.org/x/text v0.17.0
+	google.golang.org/grpc v1.67.3
+)
+
+require (
+	golang.org/x/net v0.28.0 // indirect
+	golang.org/x/sys v0.28.0 // indirect
+	google.golang.org/genproto/googleapis/rpc v0.0.0-20240814211410-ddb44dafa142 // indirect
+	google.golang.org/protobuf v1.34.2 // indirect
+)
@@ -0,0 +1,70 @@
+package serrors
+
+import (
+	"log/slog"
+	"strings"
+)
+
+// GetAttrPath returns the value of the attr of r at path, with group keys joined by dots as the
+// text handler writes them, e.g. "req.method". Values are resolved and groups without a key are
+// searched as if their attrs were inlined, matching slog. ok is false if there is no such attr.
+func GetAttrPath(r slog.Record, path string) (v slog.Value, ok bool) {
+	keys := strings.Split(path, ".")
+	r.Attrs(func(a slog.Attr) bool {
+		v, ok = attrPath(a, keys)
+		return !ok
+	})
+
+	return v, ok
+}
+
+// attrPath returns the value at keys below a
+func attrPath(a slog.Attr, keys []string) (slog.Value, bool) {
+	v := a.Value.Resolve()
+	if a.Key == "" && v.Kind() == slog.KindGroup {
+		return groupPath(v.Group(), keys)
+	}
+
+	if a.Key != keys[0] {
+		return slog.Value{}, false
+	}
+
+	if len(keys) == 1 {
+		return v, true
+	}
+
+	if v.Kind() != slog.KindGroup {
+		return slog.Value{}, false
+	}
+
+	return groupPath(v.Group(), keys[1:])
+}
+
+// groupPath returns the value at keys in attrs
+func groupPath(attrs []slog.Attr, keys []string) (slog.Value, bool) {
+	for _, a := range attrs {
+		if v, ok := attrPath(a, keys); ok {
+			return v, true
+		}
+	}
+
+	return slog.Value{}, false
+}
+
+// RecordAttr converts r to a group attr named key holding its time, level, msg and attrs, so a
+// collected error can be attached as context to another logger's entry or to a parent record. A
+// zero time is left out, as the slog handlers do.
+func RecordAttr(r slog.Record, key string) slog.Attr {
+	attrs := make([]slog.Attr, 0, r.NumAttrs()+3)
+	if !r.Time.IsZero() {
+		attrs = append(attrs, slog.Time(slog.TimeKey, r.Time))
+	}
+
+	attrs = append(attrs, slog.Any(slog.LevelKey, r.Level), slog.String(slog.MessageKey, r.Message))
+	r.Attrs(func(a slog.Attr) bool {
+		attrs = append(attrs, a)
+		return true
+	})
+
+	return slog.Attr{Key: key, Value: slog.GroupValue(attrs...)}
+}
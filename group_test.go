@@ -0,0 +1,111 @@
+package serrors
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"strings"
+	"testing"
+	"time"
+)
+
+// groupRecord adds a record with nested, empty and inline groups to e
+func groupRecord(e *SErrors) {
+	e.Add(testTime, slog.LevelError, "m",
+		slog.Group("req",
+			slog.String("method", "GET"),
+			slog.Group("headers", slog.Group("auth", slog.String("scheme", "basic"))),
+			slog.Group("empty"),
+		),
+		slog.Group("", slog.Int("inline", 1)),
+		slog.Group("none"),
+	)
+}
+
+func TestSErrorsGroups(t *testing.T) {
+	j := New(nil, nil)
+	groupRecord(j)
+
+	wantJSON := `{"time":"2000-01-02T03:04:05Z","level":"ERROR","msg":"m",` +
+		`"req":{"method":"GET","headers":{"auth":{"scheme":"basic"}}},"inline":1}`
+	a, err := j.ToArray()
+	if err != nil || len(a) != 1 || a[0] != wantJSON {
+		t.Fatalf("\ngot  %v\nwant %s", a, wantJSON)
+	}
+
+	b, err := json.Marshal(j)
+	if err != nil || string(b) != "["+wantJSON+"]" {
+		t.Fatalf("\ngot  %s\nwant [%s]", b, wantJSON)
+	}
+
+	txt := NewTextHandler(nil, nil)
+	groupRecord(txt)
+
+	wantText := "time=2000-01-02T03:04:05.000Z level=ERROR msg=m req.method=GET " +
+		"req.headers.auth.scheme=basic inline=1\n"
+	if got := txt.String(); got != wantText {
+		t.Fatalf("\ngot  %s\nwant %s", got, wantText)
+	}
+
+	wantPretty := "2000-01-02T03:04:05.000Z ERROR m\n  req:\n    method: GET\n    headers:\n" +
+		"      auth:\n        scheme: basic\n  inline: 1\n"
+	if got := txt.PrettyString(); got != wantPretty {
+		t.Fatalf("\ngot  %s\nwant %s", got, wantPretty)
+	}
+
+	html := bytes.NewBuffer(nil)
+	if err := txt.WriteHTML(html); err != nil {
+		t.Fatalf("\ngot  %s\nwant nil", err)
+	}
+
+	for _, want := range []string{"req.headers.auth.scheme=basic", "inline=1"} {
+		if !strings.Contains(html.String(), want) {
+			t.Fatalf("\ngot  %s\nwant %s", html, want)
+		}
+	}
+}
+
+func TestSErrorsGetAttrPath(t *testing.T) {
+	e := New(nil, nil)
+	groupRecord(e)
+	r := e.First()
+
+	tests := []struct {
+		path string
+		want string
+		ok   bool
+	}{
+		{path: "req.method", want: "GET", ok: true},
+		{path: "req.headers.auth.scheme", want: "basic", ok: true},
+		{path: "inline", want: "1", ok: true},
+		{path: "req.headers.auth", want: "[scheme=basic]", ok: true},
+		{path: "req.method.x"},
+		{path: "req.missing"},
+		{path: "method"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.path, func(t *testing.T) {
+			v, ok := GetAttrPath(r, test.path)
+			if ok != test.ok || (ok && v.String() != test.want) {
+				t.Fatalf("\ngot  %s %t\nwant %s %t", v, ok, test.want, test.ok)
+			}
+		})
+	}
+}
+
+func TestSErrorsRecordAttr(t *testing.T) {
+	e := New(nil, nil)
+	e.Add(testTime, slog.LevelError, "db down", slog.Group("db", slog.String("host", "db1")))
+	e.Add(time.Time{}, slog.LevelWarn, "no time")
+
+	p := New(nil, nil)
+	p.Add(testTime, slog.LevelError, "request failed", RecordAttr(e.First(), "cause"), RecordAttr(e.Last(), "warning"))
+
+	want := `{"time":"2000-01-02T03:04:05Z","level":"ERROR","msg":"request failed",` +
+		`"cause":{"time":"2000-01-02T03:04:05Z","level":"ERROR","msg":"db down","db":{"host":"db1"}},` +
+		`"warning":{"level":"WARN","msg":"no time"}}` + "\n"
+	if got := p.RtoString(p.First()); got != want {
+		t.Fatalf("\ngot  %s\nwant %s", got, want)
+	}
+}
@@ -0,0 +1,129 @@
+package grpcship
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+
+	"github.com/chadeldridge/serrors"
+	"google.golang.org/grpc"
+)
+
+// Defaults used by NewClient
+const (
+	DefaultBatchSize = 500
+	DefaultWindow    = 4
+)
+
+// streamDesc describes StreamRecords to grpc.ClientConn.NewStream
+var streamDesc = &grpc.StreamDesc{StreamName: "StreamRecords", ServerStreams: true, ClientStreams: true}
+
+// Client sends records to a Shipper server
+type Client struct {
+	cc     grpc.ClientConnInterface
+	source string
+	// BatchSize is the number of records per Batch sent by Ship
+	BatchSize int
+	// Window is the number of unacked batches Ship allows before it waits for an ack
+	Window int
+}
+
+// NewClient creates a Client that sends records from source over cc
+func NewClient(cc grpc.ClientConnInterface, source string) *Client {
+	return &Client{cc: cc, source: source, BatchSize: DefaultBatchSize, Window: DefaultWindow}
+}
+
+// PushBatch sends rs as a single Batch and returns the server's ack. A rejected batch returns the
+// ack and an error.
+func (c *Client) PushBatch(ctx context.Context, rs []slog.Record) (*Ack, error) {
+	ack := &Ack{}
+	err := c.cc.Invoke(ctx, "/"+ServiceName+"/PushBatch", c.batch(0, rs), ack, grpc.CallContentSubtype(codec{}.Name()))
+	if err != nil {
+		return nil, err
+	}
+
+	return ack, ackError(ack)
+}
+
+// Ship streams every record in e to the server in batches of BatchSize. No more than Window
+// batches are in flight at once, so a slow server slows the client down instead of buffering
+// without bound. Ship returns once every batch is acked, or at the first rejected batch.
+func (c *Client) Ship(ctx context.Context, e *serrors.SErrors) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	stream, err := c.cc.NewStream(ctx, streamDesc, "/"+ServiceName+"/StreamRecords", grpc.CallContentSubtype(codec{}.Name()))
+	if err != nil {
+		return err
+	}
+
+	size := max(c.BatchSize, 1)
+	window := max(c.Window, 1)
+	rs := e.Records()
+	var sent, acked uint64
+	for start := 0; start < len(rs); start += size {
+		for sent-acked >= uint64(window) {
+			if err := recvAck(stream, acked+1); err != nil {
+				return err
+			}
+			acked++
+		}
+
+		sent++
+		if err := stream.SendMsg(c.batch(sent, rs[start:min(start+size, len(rs))])); err != nil {
+			return err
+		}
+	}
+
+	if err := stream.CloseSend(); err != nil {
+		return err
+	}
+
+	for acked < sent {
+		if err := recvAck(stream, acked+1); err != nil {
+			return err
+		}
+		acked++
+	}
+
+	if err := stream.RecvMsg(&Ack{}); !errors.Is(err, io.EOF) {
+		return err
+	}
+
+	return nil
+}
+
+// batch converts rs to a Batch
+func (c *Client) batch(seq uint64, rs []slog.Record) *Batch {
+	b := &Batch{Source: c.source, Seq: seq, Records: make([]Record, len(rs))}
+	for i, r := range rs {
+		b.Records[i] = FromRecord(r)
+	}
+
+	return b
+}
+
+// recvAck waits for the ack of batch seq
+func recvAck(stream grpc.ClientStream, seq uint64) error {
+	ack := &Ack{}
+	if err := stream.RecvMsg(ack); err != nil {
+		return err
+	}
+
+	if ack.Seq != seq {
+		return fmt.Errorf("grpcship: got ack for batch %d, want %d", ack.Seq, seq)
+	}
+
+	return ackError(ack)
+}
+
+// ackError returns the rejection in ack as an error
+func ackError(ack *Ack) error {
+	if ack.Error == "" {
+		return nil
+	}
+
+	return fmt.Errorf("grpcship: batch %d rejected: %s", ack.Seq, ack.Error)
+}
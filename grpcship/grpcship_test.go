@@ -0,0 +1,140 @@
+package grpcship
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/chadeldridge/serrors"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/encoding"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+var testTime = time.Date(2000, 1, 2, 3, 4, 5, 0, time.UTC)
+
+func dial(t *testing.T, recv Receiver) *grpc.ClientConn {
+	t.Helper()
+	lis := bufconn.Listen(1 << 20)
+	s := grpc.NewServer()
+	Register(s, NewServer(recv))
+	go s.Serve(lis)
+	t.Cleanup(s.Stop)
+
+	cc, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return lis.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		t.Fatalf("\ngot  %s\nwant nil", err.Error())
+	}
+	t.Cleanup(func() { cc.Close() })
+
+	return cc
+}
+
+func TestRecordRoundTrip(t *testing.T) {
+	r := slog.NewRecord(testTime, slog.LevelWarn, "m", 0)
+	r.AddAttrs(
+		slog.Int64("i", 1<<60),
+		slog.Uint64("u", 1<<63),
+		slog.Float64("f", 1.5),
+		slog.Bool("b", true),
+		slog.String("s", "x"),
+		slog.Duration("d", time.Second),
+		slog.Time("t", testTime),
+		slog.Any("err", errors.New("boom")),
+		slog.Group("g", slog.Int("a", 1)),
+	)
+
+	want := serrors.NewTextHandler(nil, nil)
+	want.Add(r.Time, r.Level, r.Message, attrs(r)...)
+
+	got := serrors.NewTextHandler(nil, nil)
+	cc := dial(t, CollectInto(got))
+	ack, err := NewClient(cc, "test").PushBatch(context.Background(), []slog.Record{r})
+	if err != nil {
+		t.Fatalf("\ngot  %s\nwant nil", err.Error())
+	}
+
+	if ack.Accepted != 1 {
+		t.Fatalf("\ngot  %d\nwant 1", ack.Accepted)
+	}
+
+	if got.String() != want.String() {
+		t.Fatalf("\ngot  %s\nwant %s", got.String(), want.String())
+	}
+}
+
+func TestClientShip(t *testing.T) {
+	e := serrors.New(nil, nil)
+	for i := 0; i < 25; i++ {
+		e.Info(testTime, "m", slog.Int("i", i))
+	}
+
+	var mu sync.Mutex
+	var sources []string
+	var got []slog.Record
+	cc := dial(t, ReceiverFunc(func(_ context.Context, source string, rs []slog.Record) error {
+		mu.Lock()
+		defer mu.Unlock()
+		sources = append(sources, source)
+		got = append(got, rs...)
+		return nil
+	}))
+
+	c := NewClient(cc, "worker-1")
+	c.BatchSize = 4
+	c.Window = 2
+	if err := c.Ship(context.Background(), e); err != nil {
+		t.Fatalf("\ngot  %s\nwant nil", err.Error())
+	}
+
+	if len(sources) != 7 || sources[0] != "worker-1" {
+		t.Fatalf("\ngot  %v\nwant 7 batches from worker-1", sources)
+	}
+
+	if len(got) != 25 || got[24].Message != "m" {
+		t.Fatalf("\ngot  %d\nwant 25", len(got))
+	}
+}
+
+func TestClientShipRejected(t *testing.T) {
+	e := serrors.New(nil, nil)
+	e.Error(testTime, "m")
+
+	cc := dial(t, ReceiverFunc(func(context.Context, string, []slog.Record) error {
+		return errors.New("full")
+	}))
+
+	err := NewClient(cc, "worker-1").Ship(context.Background(), e)
+	want := "grpcship: batch 1 rejected: full"
+	if err == nil || err.Error() != want {
+		t.Fatalf("\ngot  %v\nwant %s", err, want)
+	}
+}
+
+func attrs(r slog.Record) []slog.Attr {
+	var as []slog.Attr
+	r.Attrs(func(a slog.Attr) bool {
+		as = append(as, a)
+		return true
+	})
+	return as
+}
+
+func TestCodecRegistration(t *testing.T) {
+	if _, ok := encoding.GetCodec(CodecName).(codec); !ok {
+		t.Fatalf("\ngot  %T\nwant codec", encoding.GetCodec(CodecName))
+	}
+
+	// The "json" content-subtype of other services is left alone.
+	if _, ok := encoding.GetCodec("json").(codec); ok {
+		t.Fatalf("\ngot  codec\nwant the json codec untouched")
+	}
+}
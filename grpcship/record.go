@@ -0,0 +1,36 @@
+package grpcship
+
+import (
+	"log/slog"
+
+	"github.com/chadeldridge/serrors/internal/wire"
+)
+
+// Batch is a group of records sent by a client
+type Batch struct {
+	// Source identifies the sending process, e.g. hostname/job
+	Source string `json:"source"`
+	// Seq numbers the batches of a stream so acks can be matched to them
+	Seq uint64 `json:"seq"`
+	// Records in the batch
+	Records []Record `json:"records"`
+}
+
+// Ack is the server's reply to a Batch
+type Ack struct {
+	// Seq of the acknowledged Batch
+	Seq uint64 `json:"seq"`
+	// Accepted is the number of records the server stored
+	Accepted int `json:"accepted"`
+	// Error is set when the server rejected the batch
+	Error string `json:"error,omitempty"`
+}
+
+// Record is the wire form of a slog.Record
+type Record = wire.Record
+
+// Attr is the wire form of a slog.Attr
+type Attr = wire.Attr
+
+// FromRecord converts a slog.Record to its wire form
+func FromRecord(r slog.Record) Record { return wire.FromRecord(r) }
@@ -0,0 +1,176 @@
+// Package grpcship ships serrors collections from worker processes to a central aggregator over
+// gRPC. The service is
+//
+//	service Shipper {
+//	  // PushBatch sends one batch and waits for its ack.
+//	  rpc PushBatch(Batch) returns (Ack);
+//	  // StreamRecords sends batches on one stream. The server acks each batch in order and the
+//	  // client stops sending while Window batches are unacked, which gives backpressure.
+//	  rpc StreamRecords(stream Batch) returns (stream Ack);
+//	}
+//
+// Messages are encoded as JSON with the "serrors-json" gRPC content-subtype, see CodecName, so no
+// generated protobuf code is needed on either side. Other services in the process keep their own
+// codecs.
+package grpcship
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"log/slog"
+
+	"github.com/chadeldridge/serrors"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/encoding"
+)
+
+// ServiceName is the full gRPC service name
+const ServiceName = "serrors.grpcship.Shipper"
+
+// CodecName is the gRPC content-subtype of the Shipper messages. The codec is registered under it
+// when the package is imported and Client selects it on every call.
+const CodecName = "serrors-json"
+
+// codec encodes messages as JSON for the CodecName content-subtype
+type codec struct{}
+
+func (codec) Marshal(v any) ([]byte, error) { return json.Marshal(v) }
+
+func (codec) Unmarshal(data []byte, v any) error {
+	d := json.NewDecoder(bytes.NewReader(data))
+	d.UseNumber()
+	return d.Decode(v)
+}
+
+func (codec) Name() string { return CodecName }
+
+func init() {
+	encoding.RegisterCodec(codec{})
+}
+
+// Receiver stores the records of a batch. An error rejects the batch and is returned to the client
+// in the Ack.
+type Receiver interface {
+	Receive(ctx context.Context, source string, rs []slog.Record) error
+}
+
+// ReceiverFunc adapts a function to a Receiver
+type ReceiverFunc func(ctx context.Context, source string, rs []slog.Record) error
+
+// Receive calls f(ctx, source, rs)
+func (f ReceiverFunc) Receive(ctx context.Context, source string, rs []slog.Record) error {
+	return f(ctx, source, rs)
+}
+
+// CollectInto returns a Receiver that adds every received record to e
+func CollectInto(e *serrors.SErrors) Receiver {
+	return ReceiverFunc(func(_ context.Context, _ string, rs []slog.Record) error {
+		for _, r := range rs {
+			e.AddRecord(r)
+		}
+		return nil
+	})
+}
+
+// Server implements the Shipper service by passing every batch to a Receiver
+type Server struct {
+	recv Receiver
+}
+
+// NewServer creates a Server that passes batches to recv
+func NewServer(recv Receiver) *Server {
+	return &Server{recv: recv}
+}
+
+// Register registers the Shipper service backed by srv on s
+func Register(s grpc.ServiceRegistrar, srv *Server) {
+	s.RegisterService(&serviceDesc, srv)
+}
+
+// PushBatch passes b to the Receiver and acks it
+func (s *Server) PushBatch(ctx context.Context, b *Batch) (*Ack, error) {
+	return s.receive(ctx, b), nil
+}
+
+// StreamRecords passes each batch on stream to the Receiver and acks it in order
+func (s *Server) StreamRecords(stream grpc.ServerStream) error {
+	for {
+		b := &Batch{}
+		if err := stream.RecvMsg(b); err != nil {
+			if errors.Is(err, io.EOF) {
+				return nil
+			}
+			return err
+		}
+
+		if err := stream.SendMsg(s.receive(stream.Context(), b)); err != nil {
+			return err
+		}
+	}
+}
+
+// receive decodes the records of b, passes them to the Receiver and builds the Ack
+func (s *Server) receive(ctx context.Context, b *Batch) *Ack {
+	ack := &Ack{Seq: b.Seq}
+	rs := make([]slog.Record, len(b.Records))
+	for i, w := range b.Records {
+		r, err := w.ToRecord()
+		if err != nil {
+			ack.Error = err.Error()
+			return ack
+		}
+		rs[i] = r
+	}
+
+	if err := s.recv.Receive(ctx, b.Source, rs); err != nil {
+		ack.Error = err.Error()
+		return ack
+	}
+
+	ack.Accepted = len(rs)
+	return ack
+}
+
+// shipper is the interface grpc checks Server against when registering
+type shipper interface {
+	PushBatch(ctx context.Context, b *Batch) (*Ack, error)
+	StreamRecords(stream grpc.ServerStream) error
+}
+
+var serviceDesc = grpc.ServiceDesc{
+	ServiceName: ServiceName,
+	HandlerType: (*shipper)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "PushBatch",
+			Handler: func(srv any, ctx context.Context, dec func(any) error, interceptor grpc.UnaryServerInterceptor) (any, error) {
+				b := &Batch{}
+				if err := dec(b); err != nil {
+					return nil, err
+				}
+
+				if interceptor == nil {
+					return srv.(shipper).PushBatch(ctx, b)
+				}
+
+				info := &grpc.UnaryServerInfo{Server: srv, FullMethod: "/" + ServiceName + "/PushBatch"}
+				return interceptor(ctx, b, info, func(ctx context.Context, req any) (any, error) {
+					return srv.(shipper).PushBatch(ctx, req.(*Batch))
+				})
+			},
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName: "StreamRecords",
+			Handler: func(srv any, stream grpc.ServerStream) error {
+				return srv.(shipper).StreamRecords(stream)
+			},
+			ServerStreams: true,
+			ClientStreams: true,
+		},
+	},
+}
@@ -0,0 +1,151 @@
+package serrors
+
+import (
+	"context"
+	"log/slog"
+	"slices"
+)
+
+// Handler returns a slog.Handler that adds every record it handles to e, so anything logged through
+// a slog.Logger is collected:
+//
+//	logger := slog.New(errs.Handler())
+//
+// Records go through the same transforms, remapping, redaction and sampling as Add. The handler is
+// enabled for the levels e collects, see Config.MinLevel.
+func (e *SErrors) Handler() slog.Handler {
+	return &collectHandler{e: e}
+}
+
+// collectHandler is the handler returned by SErrors.Handler
+type collectHandler struct {
+	e     *SErrors
+	scope attrScope
+}
+
+// attrScope holds attrs and groups added to every record, by slog.Handler.WithAttrs and WithGroup
+// or by Scope.WithAttrs, Scope.WithGroup and WithDefaultAttrs
+type attrScope struct {
+	// attrs are the attrs added with WithAttrs before the first group
+	attrs []slog.Attr
+	// groups are the groups opened with WithGroup, each with the attrs added inside it
+	groups []handlerGroup
+}
+
+// handlerGroup is a group opened with WithGroup
+type handlerGroup struct {
+	name  string
+	attrs []slog.Attr
+}
+
+// isEmpty reports whether s adds nothing to records
+func (s attrScope) isEmpty() bool {
+	return len(s.attrs) == 0 && len(s.groups) == 0
+}
+
+// apply returns the attrs of r nested in the groups of s, after the attrs of s
+func (s attrScope) apply(r slog.Record) []slog.Attr {
+	var attrs []slog.Attr
+	r.Attrs(func(a slog.Attr) bool {
+		attrs = append(attrs, a)
+		return true
+	})
+	attrs = cleanAttrs(attrs)
+
+	for i := len(s.groups) - 1; i >= 0; i-- {
+		g := s.groups[i]
+		attrs = append(slices.Clip(g.attrs), attrs...)
+		if len(attrs) > 0 {
+			attrs = []slog.Attr{{Key: g.name, Value: slog.GroupValue(attrs...)}}
+		}
+	}
+
+	return append(slices.Clip(s.attrs), attrs...)
+}
+
+// record returns a copy of r with the attrs and groups of s
+func (s attrScope) record(r slog.Record) slog.Record {
+	n := slog.NewRecord(r.Time, r.Level, r.Message, r.PC)
+	n.AddAttrs(s.apply(r)...)
+	return n
+}
+
+// withAttrs returns s adding attrs inside the groups opened so far
+func (s attrScope) withAttrs(attrs []slog.Attr) attrScope {
+	attrs = cleanAttrs(attrs)
+	if len(attrs) == 0 {
+		return s
+	}
+
+	if len(s.groups) == 0 {
+		s.attrs = append(slices.Clip(s.attrs), attrs...)
+		return s
+	}
+
+	s.groups = slices.Clone(s.groups)
+	g := &s.groups[len(s.groups)-1]
+	g.attrs = append(slices.Clip(g.attrs), attrs...)
+	return s
+}
+
+// withGroup returns s nesting the attrs added after it in a group called name
+func (s attrScope) withGroup(name string) attrScope {
+	if name == "" {
+		return s
+	}
+
+	s.groups = append(slices.Clip(s.groups), handlerGroup{name: name})
+	return s
+}
+
+// Enabled reports whether e collects records at l
+func (h *collectHandler) Enabled(_ context.Context, l slog.Level) bool {
+	h.e.mu.RLock()
+	defer h.e.mu.RUnlock()
+
+	return l >= h.e.minLevel
+}
+
+// Handle adds r, with the attrs and groups of h, to the collection
+func (h *collectHandler) Handle(_ context.Context, r slog.Record) error {
+	h.e.add(h.scope.record(r))
+	return nil
+}
+
+// WithAttrs returns a handler adding attrs, inside the groups opened so far, to every record
+func (h *collectHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return &collectHandler{e: h.e, scope: h.scope.withAttrs(attrs)}
+}
+
+// WithGroup returns a handler nesting the attrs added after it in a group called name
+func (h *collectHandler) WithGroup(name string) slog.Handler {
+	return &collectHandler{e: h.e, scope: h.scope.withGroup(name)}
+}
+
+// cleanAttrs resolves attrs and applies the slog.Handler rules: empty attrs and groups are dropped
+// and the attrs of groups with an empty key are inlined
+func cleanAttrs(attrs []slog.Attr) []slog.Attr {
+	out := make([]slog.Attr, 0, len(attrs))
+	for _, a := range attrs {
+		a.Value = a.Value.Resolve()
+		if a.Equal(slog.Attr{}) {
+			continue
+		}
+
+		if a.Value.Kind() != slog.KindGroup {
+			out = append(out, a)
+			continue
+		}
+
+		g := cleanAttrs(a.Value.Group())
+		switch {
+		case len(g) == 0:
+		case a.Key == "":
+			out = append(out, g...)
+		default:
+			out = append(out, slog.Attr{Key: a.Key, Value: slog.GroupValue(g...)})
+		}
+	}
+
+	return out
+}
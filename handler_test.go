@@ -0,0 +1,74 @@
+package serrors
+
+import (
+	"log/slog"
+	"testing"
+	"testing/slogtest"
+)
+
+func TestSErrorsHandlerSlogtest(t *testing.T) {
+	var e *SErrors
+	slogtest.Run(t, func(*testing.T) slog.Handler {
+		e = New(nil, nil)
+		return e.Handler()
+	}, func(t *testing.T) map[string]any {
+		rs := e.Records()
+		if len(rs) != 1 {
+			t.Fatalf("\ngot  %d records\nwant 1", len(rs))
+		}
+
+		r := rs[0]
+		m := map[string]any{slog.LevelKey: r.Level, slog.MessageKey: r.Message}
+		if !r.Time.IsZero() {
+			m[slog.TimeKey] = r.Time
+		}
+
+		r.Attrs(func(a slog.Attr) bool {
+			m[a.Key] = valueMap(a.Value)
+			return true
+		})
+
+		return m
+	})
+}
+
+// valueMap converts group values to maps for slogtest
+func valueMap(v slog.Value) any {
+	if v.Kind() != slog.KindGroup {
+		return v.Any()
+	}
+
+	m := map[string]any{}
+	for _, a := range v.Group() {
+		m[a.Key] = valueMap(a.Value)
+	}
+
+	return m
+}
+
+func TestSErrorsHandler(t *testing.T) {
+	e, err := NewFromConfig(Config{
+		Format:     FormatText,
+		Output:     "discard",
+		MinLevel:   "INFO",
+		RedactKeys: []string{"token"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	logger := slog.New(e.Handler()).With("svc", "api").WithGroup("req")
+	logger.Debug("dropped")
+	logger.Error("failed", "token", "t", "status", 500)
+
+	want := `level=ERROR msg=failed svc=api req.token=[REDACTED] req.status=500`
+	rs := e.Records()
+	if len(rs) != 1 {
+		t.Fatalf("\ngot  %d records\nwant 1", len(rs))
+	}
+
+	rs[0].Time = testTime
+	if got := e.RtoString(rs[0]); got != "time=2000-01-02T03:04:05.000Z "+want+"\n" {
+		t.Fatalf("\ngot  %s\nwant %s", got, want)
+	}
+}
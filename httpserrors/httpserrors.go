@@ -0,0 +1,115 @@
+// Package httpserrors is net/http middleware giving each request its own serrors.SErrors. The
+// collector is stored in the request context, where handlers find it with serrors.FromContext,
+// and is logged when the handler returns. Panics are recovered into an Error record.
+package httpserrors
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"os"
+
+	"github.com/chadeldridge/serrors"
+)
+
+// PanicKey is the attr holding the recovered value of a panicking handler
+const PanicKey = "panic"
+
+// Options configures Middleware. A nil *Options uses the defaults.
+type Options struct {
+	// NewCollector creates the collector of each request. Defaults to serrors.New(os.Stderr, nil).
+	NewCollector func(r *http.Request) *serrors.SErrors
+	// ErrorBody writes a 500 response with a JSON body, {"error":"..."} summarizing the collection
+	// as serrors.CollectionError does, when the handler returns with an Error level record and has
+	// not written a response. The summary holds record messages, so only use it when they are safe
+	// to show to clients.
+	ErrorBody bool
+}
+
+// Middleware returns middleware that installs a fresh collector in the request context with
+// serrors.NewContext and calls its Log method when the handler returns if it holds any records.
+// A panic in the handler is recovered into an Error record with a stack trace and answered with a
+// 500 if nothing was written yet. http.ErrAbortHandler is logged and panics again so the server
+// aborts the response.
+func Middleware(opts *Options) func(http.Handler) http.Handler {
+	if opts == nil {
+		opts = &Options{}
+	}
+
+	newCollector := opts.NewCollector
+	if newCollector == nil {
+		newCollector = func(*http.Request) *serrors.SErrors { return serrors.New(os.Stderr, nil) }
+	}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			e := newCollector(r)
+			rw := &responseWriter{ResponseWriter: w}
+			defer func() {
+				v := recover()
+				if v != nil {
+					e.AddNow(slog.LevelError, fmt.Sprint(v), slog.Any(PanicKey, v), serrors.WithStack())
+				}
+
+				if !rw.written && (v != nil || opts.ErrorBody && e.Level() >= slog.LevelError) {
+					writeError(rw, e, opts.ErrorBody)
+				}
+
+				if !e.IsZero() {
+					e.Log()
+				}
+
+				if errors.Is(asError(v), http.ErrAbortHandler) {
+					panic(v)
+				}
+			}()
+
+			next.ServeHTTP(rw, r.WithContext(serrors.NewContext(r.Context(), e)))
+		})
+	}
+}
+
+// writeError answers with a 500, with a JSON body if body is set
+func writeError(w http.ResponseWriter, e *serrors.SErrors, body bool) {
+	if !body {
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusInternalServerError)
+	json.NewEncoder(w).Encode(struct {
+		Error string `json:"error"`
+	}{e.Err().Error()})
+}
+
+// asError returns v if it is an error
+func asError(v any) error {
+	err, _ := v.(error)
+	return err
+}
+
+// responseWriter records whether the response has been started
+type responseWriter struct {
+	http.ResponseWriter
+	written bool
+}
+
+// WriteHeader starts the response
+func (w *responseWriter) WriteHeader(code int) {
+	w.written = true
+	w.ResponseWriter.WriteHeader(code)
+}
+
+// Write starts the response if needed and writes b
+func (w *responseWriter) Write(b []byte) (int, error) {
+	w.written = true
+	return w.ResponseWriter.Write(b)
+}
+
+// Unwrap returns the wrapped ResponseWriter for http.ResponseController
+func (w *responseWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
@@ -0,0 +1,103 @@
+package httpserrors
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/chadeldridge/serrors"
+)
+
+var testTime = time.Date(2000, 1, 2, 3, 4, 5, 0, time.UTC)
+
+func TestMiddleware(t *testing.T) {
+	tests := []struct {
+		name    string
+		body    bool
+		handler http.HandlerFunc
+		code    int
+		resp    string
+		log     string
+	}{
+		{
+			name: "ok",
+			handler: func(w http.ResponseWriter, _ *http.Request) {
+				w.Write([]byte("ok"))
+			},
+			code: http.StatusOK,
+			resp: "ok",
+		},
+		{
+			name: "records",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				serrors.FromContext(r.Context()).Warn(testTime, "slow")
+				w.Write([]byte("ok"))
+			},
+			code: http.StatusOK,
+			resp: "ok",
+			log:  "time=2000-01-02T03:04:05.000Z level=WARN msg=slow\n",
+		},
+		{
+			name: "panic",
+			handler: func(http.ResponseWriter, *http.Request) {
+				panic("boom")
+			},
+			code: http.StatusInternalServerError,
+			resp: "Internal Server Error\n",
+			log:  "level=ERROR msg=boom panic=boom stack=",
+		},
+		{
+			name: "error body",
+			body: true,
+			handler: func(_ http.ResponseWriter, r *http.Request) {
+				serrors.FromContext(r.Context()).Error(testTime, "db down")
+			},
+			code: http.StatusInternalServerError,
+			resp: `{"error":"ERROR: db down"}` + "\n",
+			log:  "time=2000-01-02T03:04:05.000Z level=ERROR msg=\"db down\"\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var log bytes.Buffer
+			opts := &Options{
+				NewCollector: func(*http.Request) *serrors.SErrors {
+					return serrors.NewTextHandler(&log, nil)
+				},
+				ErrorBody: tt.body,
+			}
+
+			w := httptest.NewRecorder()
+			Middleware(opts)(tt.handler).ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/", nil))
+
+			if w.Code != tt.code || w.Body.String() != tt.resp {
+				t.Fatalf("\ngot  %d %s\nwant %d %s", w.Code, w.Body, tt.code, tt.resp)
+			}
+
+			if !strings.Contains(log.String(), tt.log) || (tt.log == "") != (log.Len() == 0) {
+				t.Fatalf("\ngot  %s\nwant %s", log.String(), tt.log)
+			}
+		})
+	}
+}
+
+func TestMiddlewareAbortHandler(t *testing.T) {
+	defer func() {
+		if v := recover(); v != http.ErrAbortHandler {
+			t.Fatalf("\ngot  %v\nwant %v", v, http.ErrAbortHandler)
+		}
+	}()
+
+	opts := &Options{NewCollector: func(*http.Request) *serrors.SErrors {
+		return serrors.New(io.Discard, nil)
+	}}
+	h := Middleware(opts)(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {
+		panic(http.ErrAbortHandler)
+	}))
+	h.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil))
+}
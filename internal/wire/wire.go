@@ -0,0 +1,153 @@
+// Package wire is the typed JSON form of slog.Record shared by the packages that move records
+// between processes or to disk. Unlike the slog JSON handler output, it keeps the slog.Kind of each
+// attr so records convert back without losing types.
+package wire
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"math"
+	"strconv"
+	"time"
+)
+
+// Record is the wire form of a slog.Record
+type Record struct {
+	Time    time.Time  `json:"time"`
+	Level   slog.Level `json:"level"`
+	Message string     `json:"msg"`
+	Attrs   []Attr     `json:"attrs,omitempty"`
+}
+
+// Attr is the wire form of a slog.Attr. Kind keeps the slog.Kind so numbers, durations and times
+// survive the trip through JSON. Group is used instead of Value for slog.KindGroup.
+type Attr struct {
+	Key   string `json:"key"`
+	Kind  string `json:"kind"`
+	Value any    `json:"value,omitempty"`
+	Group []Attr `json:"group,omitempty"`
+}
+
+// FromRecord converts a slog.Record to its wire form
+func FromRecord(r slog.Record) Record {
+	w := Record{Time: r.Time, Level: r.Level, Message: r.Message}
+	r.Attrs(func(a slog.Attr) bool {
+		w.Attrs = append(w.Attrs, fromAttr(a))
+		return true
+	})
+
+	return w
+}
+
+// fromAttr converts a slog.Attr to its wire form
+func fromAttr(a slog.Attr) Attr {
+	v := a.Value.Resolve()
+	w := Attr{Key: a.Key, Kind: v.Kind().String()}
+	switch v.Kind() {
+	case slog.KindGroup:
+		for _, g := range v.Group() {
+			w.Group = append(w.Group, fromAttr(g))
+		}
+	case slog.KindDuration:
+		w.Value = v.Duration().String()
+	case slog.KindTime:
+		w.Value = v.Time().Format(time.RFC3339Nano)
+	case slog.KindFloat64:
+		// JSON has no NaN or infinities, so they are sent as the strings ParseFloat reads back.
+		if f := v.Float64(); math.IsNaN(f) || math.IsInf(f, 0) {
+			w.Value = strconv.FormatFloat(f, 'g', -1, 64)
+		} else {
+			w.Value = f
+		}
+	case slog.KindAny:
+		// Values encoding/json can't handle, such as errors, are sent as strings.
+		if err, ok := v.Any().(error); ok {
+			w.Value = err.Error()
+		} else if _, err := json.Marshal(v.Any()); err != nil {
+			w.Value = v.String()
+		} else {
+			w.Value = v.Any()
+		}
+	default:
+		w.Value = v.Any()
+	}
+
+	return w
+}
+
+// ToRecord converts the wire form back to a slog.Record
+func (w Record) ToRecord() (slog.Record, error) {
+	r := slog.NewRecord(w.Time, w.Level, w.Message, 0)
+	for _, a := range w.Attrs {
+		sa, err := a.toAttr()
+		if err != nil {
+			return slog.Record{}, err
+		}
+		r.AddAttrs(sa)
+	}
+
+	return r, nil
+}
+
+// toAttr converts the wire form back to a slog.Attr
+func (w Attr) toAttr() (slog.Attr, error) {
+	switch w.Kind {
+	case slog.KindGroup.String():
+		as := make([]slog.Attr, len(w.Group))
+		for i, g := range w.Group {
+			a, err := g.toAttr()
+			if err != nil {
+				return slog.Attr{}, err
+			}
+			as[i] = a
+		}
+		return slog.Attr{Key: w.Key, Value: slog.GroupValue(as...)}, nil
+	case slog.KindDuration.String():
+		s, _ := w.Value.(string)
+		d, err := time.ParseDuration(s)
+		return slog.Duration(w.Key, d), err
+	case slog.KindTime.String():
+		s, _ := w.Value.(string)
+		t, err := time.Parse(time.RFC3339Nano, s)
+		return slog.Time(w.Key, t), err
+	case slog.KindFloat64.String():
+		if s, ok := w.Value.(string); ok {
+			f, err := strconv.ParseFloat(s, 64)
+			return slog.Float64(w.Key, f), err
+		}
+	}
+
+	// The codec decodes numbers as json.Number so the kind can restore the original type without
+	// losing precision.
+	switch v := w.Value.(type) {
+	case json.Number:
+		switch w.Kind {
+		case slog.KindInt64.String():
+			n, err := v.Int64()
+			return slog.Int64(w.Key, n), err
+		case slog.KindUint64.String():
+			n, err := strconv.ParseUint(v.String(), 10, 64)
+			return slog.Uint64(w.Key, n), err
+		}
+		n, err := v.Float64()
+		return slog.Float64(w.Key, n), err
+	case bool:
+		return slog.Bool(w.Key, v), nil
+	case string:
+		return slog.String(w.Key, v), nil
+	case nil, map[string]any, []any:
+		return slog.Any(w.Key, v), nil
+	default:
+		return slog.Attr{}, fmt.Errorf("serrors: unsupported value %T for attr %q", v, w.Key)
+	}
+}
+
+// Unmarshal decodes a Record encoded with encoding/json into w. Numbers are decoded as json.Number
+// so ToRecord can restore them exactly.
+func Unmarshal(data []byte, w *Record) error {
+	d := json.NewDecoder(bytes.NewReader(data))
+	d.UseNumber()
+	return d.Decode(w)
+}
@@ -0,0 +1,76 @@
+package serrors
+
+import (
+	"errors"
+	"iter"
+	"log/slog"
+)
+
+// All returns an iterator over the records in memory, oldest first, without copying them. e is
+// only locked while each record is read, so the loop body may add or remove records: records
+// added during iteration are visited, and removing records may make it skip some.
+func (e *SErrors) All() iter.Seq[slog.Record] {
+	return func(yield func(slog.Record) bool) {
+		e.expire()
+		for i := 0; ; i++ {
+			r, ok := e.recordAt(i)
+			if !ok || !yield(r) {
+				return
+			}
+		}
+	}
+}
+
+// Backward returns an iterator over the records in memory, newest first, without copying them.
+// Like All, e is only locked while each record is read; records added during iteration are not
+// visited.
+func (e *SErrors) Backward() iter.Seq[slog.Record] {
+	return func(yield func(slog.Record) bool) {
+		e.expire()
+		e.mu.RLock()
+		n := len(e.records)
+		e.mu.RUnlock()
+
+		for i := n - 1; i >= 0; i-- {
+			r, ok := e.recordAt(i)
+			if ok && !yield(r) {
+				return
+			}
+		}
+	}
+}
+
+// recordAt returns the record at index i and false if there is none
+func (e *SErrors) recordAt(i int) (slog.Record, bool) {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+
+	if i >= len(e.records) {
+		return slog.Record{}, false
+	}
+
+	return e.records[i], true
+}
+
+// Each returns an iterator over every record, the spilled ones first like Log, then copies of the
+// records in memory. A failure to read the spilled records is yielded last, with a zero record,
+// after the records that could be read.
+func (e *SErrors) Each() iter.Seq2[slog.Record, error] {
+	return func(yield func(slog.Record, error) bool) {
+		stopped := false
+		err := e.eachRecord(func(r slog.Record) error {
+			if !yield(r, nil) {
+				stopped = true
+				return errStopEach
+			}
+
+			return nil
+		})
+		if err != nil && !stopped {
+			yield(slog.Record{}, err)
+		}
+	}
+}
+
+// errStopEach ends eachRecord once the loop over Each breaks
+var errStopEach = errors.New("serrors: iteration stopped")
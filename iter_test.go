@@ -0,0 +1,93 @@
+package serrors
+
+import (
+	"iter"
+	"log/slog"
+	"slices"
+	"testing"
+)
+
+func TestSErrorsAll(t *testing.T) {
+	tests := []struct {
+		name string
+		seq  func(e *SErrors) iter.Seq[slog.Record]
+		want []string
+	}{
+		{"all", (*SErrors).All, []string{"a", "b", "c"}},
+		{"backward", (*SErrors).Backward, []string{"c", "b", "a"}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			e := New(nil, nil)
+			for _, msg := range []string{"a", "b", "c"} {
+				e.Info(testTime, msg)
+			}
+
+			var got []string
+			for r := range test.seq(e) {
+				got = append(got, r.Message)
+			}
+
+			if !slices.Equal(got, test.want) {
+				t.Fatalf("\ngot  %v\nwant %v", got, test.want)
+			}
+
+			got = nil
+			for r := range test.seq(e) {
+				got = append(got, r.Message)
+				break
+			}
+
+			if !slices.Equal(got, test.want[:1]) {
+				t.Fatalf("\ngot  %v\nwant %v", got, test.want[:1])
+			}
+		})
+	}
+}
+
+func TestSErrorsAllAdd(t *testing.T) {
+	e := New(nil, nil)
+	e.Info(testTime, "a")
+
+	var got []string
+	for r := range e.All() {
+		got = append(got, r.Message)
+		if len(got) == 1 {
+			e.Info(testTime, "b")
+		}
+	}
+
+	if want := []string{"a", "b"}; !slices.Equal(got, want) {
+		t.Fatalf("\ngot  %v\nwant %v", got, want)
+	}
+}
+
+func TestSErrorsEach(t *testing.T) {
+	e := New(nil, nil, WithArena(2))
+	for _, msg := range []string{"a", "b", "c", "d"} {
+		e.Info(testTime, msg)
+	}
+
+	var got []string
+	for r, err := range e.Each() {
+		if err != nil {
+			t.Fatal(err)
+		}
+		got = append(got, r.Message)
+	}
+
+	if want := []string{"a", "b", "c", "d"}; !slices.Equal(got, want) {
+		t.Fatalf("\ngot  %v\nwant %v", got, want)
+	}
+
+	got = nil
+	for r := range e.Each() {
+		got = append(got, r.Message)
+		break
+	}
+
+	if !slices.Equal(got, []string{"a"}) {
+		t.Fatalf("\ngot  %v\nwant [a]", got)
+	}
+}
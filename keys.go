@@ -0,0 +1,83 @@
+package serrors
+
+import (
+	"log/slog"
+	"strings"
+	"unicode"
+)
+
+// SnakeCaseKey converts slog.Attr.Key to snake_case, e.g. requestID to request_id, and returns the
+// new slog.Attr
+func SnakeCaseKey(_ []string, a slog.Attr) slog.Attr {
+	a.Key = strings.Join(keyWords(a.Key), "_")
+	return a
+}
+
+// CamelCaseKey converts slog.Attr.Key to camelCase, e.g. request_id to requestId, and returns the
+// new slog.Attr
+func CamelCaseKey(_ []string, a slog.Attr) slog.Attr {
+	words := keyWords(a.Key)
+	for i := 1; i < len(words); i++ {
+		r := []rune(words[i])
+		r[0] = unicode.ToUpper(r[0])
+		words[i] = string(r)
+	}
+
+	a.Key = strings.Join(words, "")
+	return a
+}
+
+// RenameKeys returns a ReplaceAttr function that renames keys found in names, e.g.
+// {"time": "timestamp", "msg": "message"}. Keys inside groups are matched by their dotted path,
+// such as "req.method".
+func RenameKeys(names map[string]string) func([]string, slog.Attr) slog.Attr {
+	return func(groups []string, a slog.Attr) slog.Attr {
+		path := a.Key
+		if len(groups) > 0 {
+			path = strings.Join(groups, ".") + "." + a.Key
+		}
+
+		if n, ok := names[path]; ok {
+			a.Key = n
+		}
+
+		return a
+	}
+}
+
+// keyWords splits key into lower case words on separators and case changes, so HTTPStatus,
+// http_status and http-status all give http and status
+func keyWords(key string) []string {
+	var words []string
+	var word []rune
+	flush := func() {
+		if len(word) > 0 {
+			words = append(words, strings.ToLower(string(word)))
+			word = word[:0]
+		}
+	}
+
+	rs := []rune(key)
+	for i, r := range rs {
+		switch {
+		case r == '_' || r == '-' || r == '.' || unicode.IsSpace(r):
+			flush()
+			continue
+		case unicode.IsUpper(r) && i > 0:
+			prev := rs[i-1]
+			nextLower := i+1 < len(rs) && unicode.IsLower(rs[i+1])
+			if unicode.IsLower(prev) || unicode.IsDigit(prev) || (unicode.IsUpper(prev) && nextLower) {
+				flush()
+			}
+		}
+
+		word = append(word, r)
+	}
+	flush()
+
+	if len(words) == 0 {
+		return []string{key}
+	}
+
+	return words
+}
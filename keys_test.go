@@ -0,0 +1,51 @@
+package serrors
+
+import (
+	"log/slog"
+	"testing"
+)
+
+func TestSErrorsKeyCase(t *testing.T) {
+	tests := []struct {
+		key   string
+		snake string
+		camel string
+	}{
+		{key: "msg", snake: "msg", camel: "msg"},
+		{key: "requestID", snake: "request_id", camel: "requestId"},
+		{key: "HTTPStatus", snake: "http_status", camel: "httpStatus"},
+		{key: "user_name", snake: "user_name", camel: "userName"},
+		{key: "retry-after", snake: "retry_after", camel: "retryAfter"},
+		{key: "ipv4Addr", snake: "ipv4_addr", camel: "ipv4Addr"},
+		{key: "_", snake: "_", camel: "_"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.key, func(t *testing.T) {
+			a := slog.String(test.key, "v")
+			if got := SnakeCaseKey(nil, a).Key; got != test.snake {
+				t.Fatalf("\ngot  %s\nwant %s", got, test.snake)
+			}
+
+			if got := CamelCaseKey(nil, a).Key; got != test.camel {
+				t.Fatalf("\ngot  %s\nwant %s", got, test.camel)
+			}
+		})
+	}
+}
+
+func TestSErrorsRenameKeys(t *testing.T) {
+	opts := slog.HandlerOptions{ReplaceAttr: RenameKeys(map[string]string{
+		slog.TimeKey:    "timestamp",
+		slog.MessageKey: "message",
+		"req.method":    "verb",
+	})}
+
+	e := New(nil, &opts)
+	e.Add(testTime, slog.LevelError, "m", slog.String("method", "top"), slog.Group("req", slog.String("method", "GET")))
+
+	want := `{"timestamp":"2000-01-02T03:04:05Z","level":"ERROR","message":"m","method":"top","req":{"verb":"GET"}}`
+	if got := e.RtoString(e.First()); got != want+"\n" {
+		t.Fatalf("\ngot  %s\nwant %s", got, want)
+	}
+}
@@ -0,0 +1,87 @@
+package serrors
+
+import (
+	"fmt"
+	"log/slog"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// customLevels holds the names registered with RegisterLevel
+var customLevels = struct {
+	sync.RWMutex
+	byName  map[string]slog.Level
+	byLevel map[slog.Level]string
+}{byName: map[string]slog.Level{}, byLevel: map[slog.Level]string{}}
+
+// RegisterLevel names a custom level, e.g. RegisterLevel("FATAL", slog.LevelError+4), for
+// ParseLevel, LevelString and LevelNames. Names are matched case-insensitively. Registering a
+// name or level again replaces it.
+func RegisterLevel(name string, l slog.Level) {
+	customLevels.Lock()
+	defer customLevels.Unlock()
+
+	if old, ok := customLevels.byLevel[l]; ok {
+		delete(customLevels.byName, strings.ToUpper(old))
+	}
+
+	if old, ok := customLevels.byName[strings.ToUpper(name)]; ok {
+		delete(customLevels.byLevel, old)
+	}
+
+	customLevels.byName[strings.ToUpper(name)] = l
+	customLevels.byLevel[l] = name
+}
+
+// ParseLevel parses a level written by the slog handlers, such as "warn" or "ERROR+2", a name
+// registered with RegisterLevel, or a plain integer, so config files and APIs can give thresholds
+// by name
+func ParseLevel(s string) (slog.Level, error) {
+	s = strings.TrimSpace(s)
+	customLevels.RLock()
+	l, ok := customLevels.byName[strings.ToUpper(s)]
+	customLevels.RUnlock()
+	if ok {
+		return l, nil
+	}
+
+	if n, err := strconv.Atoi(s); err == nil {
+		return slog.Level(n), nil
+	}
+
+	if err := l.UnmarshalText([]byte(s)); err != nil {
+		return 0, fmt.Errorf("serrors: unknown level %q", s)
+	}
+
+	return l, nil
+}
+
+// levelName returns the registered name of l or l.String()
+func levelName(l slog.Level) string {
+	customLevels.RLock()
+	defer customLevels.RUnlock()
+
+	if name, ok := customLevels.byLevel[l]; ok {
+		return name
+	}
+
+	return l.String()
+}
+
+// LevelString returns the name of Level, using the names registered with RegisterLevel
+func (e *SErrors) LevelString() string {
+	return levelName(e.Level())
+}
+
+// LevelNames is a ReplaceAttr function that writes the names registered with RegisterLevel in
+// place of levels such as ERROR+4
+func LevelNames(groups []string, a slog.Attr) slog.Attr {
+	if len(groups) == 0 && a.Key == slog.LevelKey {
+		if l, ok := a.Value.Any().(slog.Level); ok {
+			a.Value = slog.StringValue(levelName(l))
+		}
+	}
+
+	return a
+}
@@ -0,0 +1,61 @@
+package serrors
+
+import (
+	"log/slog"
+	"strings"
+	"testing"
+)
+
+func TestSErrorsParseLevel(t *testing.T) {
+	RegisterLevel("Fatal", slog.LevelError+4)
+	RegisterLevel("TRACE", slog.LevelDebug-4)
+
+	tests := []struct {
+		in   string
+		want slog.Level
+		err  bool
+	}{
+		{in: "warn", want: slog.LevelWarn},
+		{in: "ERROR+2", want: slog.LevelError + 2},
+		{in: " info ", want: slog.LevelInfo},
+		{in: "fatal", want: slog.LevelError + 4},
+		{in: "Trace", want: slog.LevelDebug - 4},
+		{in: "-8", want: slog.Level(-8)},
+		{in: "loud", err: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.in, func(t *testing.T) {
+			got, err := ParseLevel(test.in)
+			if (err != nil) != test.err || got != test.want {
+				t.Fatalf("\ngot  %s %v\nwant %s", got, err, test.want)
+			}
+		})
+	}
+}
+
+func TestSErrorsLevelString(t *testing.T) {
+	RegisterLevel("FATAL", slog.LevelError+4)
+
+	e := NewTextHandler(nil, &slog.HandlerOptions{ReplaceAttr: LevelNames})
+	e.Add(testTime, slog.LevelWarn, "a")
+	if got := e.LevelString(); got != "WARN" {
+		t.Fatalf("\ngot  %s\nwant WARN", got)
+	}
+
+	e.Add(testTime, slog.LevelError+4, "b")
+	if got := e.LevelString(); got != "FATAL" {
+		t.Fatalf("\ngot  %s\nwant FATAL", got)
+	}
+
+	want := "time=2000-01-02T03:04:05.000Z level=FATAL msg=b\n"
+	if got := e.RtoString(e.Last()); got != want {
+		t.Fatalf("\ngot  %s\nwant %s", got, want)
+	}
+
+	// Custom names are read back by the parsers.
+	p, err := ParseText(strings.NewReader(want))
+	if err != nil || p.Level() != slog.LevelError+4 {
+		t.Fatalf("\ngot  %v %v\nwant FATAL", p, err)
+	}
+}
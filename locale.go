@@ -0,0 +1,121 @@
+package serrors
+
+import (
+	"log/slog"
+	"time"
+
+	"golang.org/x/text/language"
+	"golang.org/x/text/message"
+)
+
+// humanTimeLayout is the time layout of human-facing output when no locale is set
+const humanTimeLayout = "2006-01-02T15:04:05.000Z07:00"
+
+// localeLayouts are the time layouts of the locales WithLocale knows. Other tags use the closest
+// match, falling back to the first entry.
+var localeLayouts = []struct {
+	tag    language.Tag
+	layout string
+}{
+	{language.AmericanEnglish, "01/02/2006 3:04:05 PM MST"},
+	{language.BritishEnglish, "02/01/2006 15:04:05 MST"},
+	{language.German, "02.01.2006 15:04:05 MST"},
+	{language.French, "02/01/2006 15:04:05 MST"},
+	{language.Spanish, "02/01/2006 15:04:05 MST"},
+	{language.Italian, "02/01/2006 15:04:05 MST"},
+	{language.Dutch, "02-01-2006 15:04:05 MST"},
+	{language.BrazilianPortuguese, "02/01/2006 15:04:05 MST"},
+	{language.Russian, "02.01.2006 15:04:05 MST"},
+	{language.Polish, "02.01.2006 15:04:05 MST"},
+	{language.Japanese, "2006/01/02 15:04:05 MST"},
+	{language.Chinese, "2006/01/02 15:04:05 MST"},
+	{language.Korean, "2006. 01. 02. 15:04:05 MST"},
+}
+
+// localeMatcher picks the entry of localeLayouts for a tag
+var localeMatcher = func() language.Matcher {
+	tags := make([]language.Tag, len(localeLayouts))
+	for i, l := range localeLayouts {
+		tags[i] = l.tag
+	}
+
+	return language.NewMatcher(tags)
+}()
+
+// locale formats times and numbers in human-facing output. A nil locale uses RFC 3339 times and
+// plain numbers.
+type locale struct {
+	printer *message.Printer
+	layout  string
+}
+
+// WithLocale shows times and numbers in the text handler, pretty text and the HTML report in the
+// conventions of tag, e.g. language.German for 02.01.2006 and 1.234,5, for operators who do not
+// read English. Localized text output cannot be read back by ParseText or Scanner. Output read by
+// machines, including the JSON handler, MarshalJSON, MarshalText and WriteNDJSON, is not affected
+// and keeps RFC 3339 times.
+func WithLocale(tag language.Tag) Option {
+	return func(e *SErrors) {
+		_, i, _ := localeMatcher.Match(tag)
+		e.locale = &locale{printer: message.NewPrinter(tag), layout: localeLayouts[i].layout}
+	}
+}
+
+// textOpts returns the options of text handlers, localizing times and numbers after the
+// ReplaceAttr of the slog.HandlerOptions when WithLocale is used
+func (e *SErrors) textOpts() *slog.HandlerOptions {
+	if e.locale == nil {
+		return e.opts
+	}
+
+	opts := *e.opts
+	user, l := opts.ReplaceAttr, e.locale
+	opts.ReplaceAttr = func(groups []string, a slog.Attr) slog.Attr {
+		if user != nil {
+			a = user(groups, a)
+		}
+
+		return l.localized(a)
+	}
+
+	return &opts
+}
+
+// localized returns a with a time or number value replaced by its text in the locale
+func (l *locale) localized(a slog.Attr) slog.Attr {
+	switch a.Value.Kind() {
+	case slog.KindInt64, slog.KindUint64, slog.KindFloat64, slog.KindTime:
+		a.Value = slog.StringValue(l.formatValue(a.Value))
+	}
+
+	return a
+}
+
+// formatTime returns t in the layout of the locale
+func (l *locale) formatTime(t time.Time) string {
+	if l == nil {
+		return t.Format(humanTimeLayout)
+	}
+
+	return t.Format(l.layout)
+}
+
+// formatValue returns v with numbers and times in the conventions of the locale
+func (l *locale) formatValue(v slog.Value) string {
+	if l == nil {
+		return v.String()
+	}
+
+	switch v.Kind() {
+	case slog.KindInt64:
+		return l.printer.Sprint(v.Int64())
+	case slog.KindUint64:
+		return l.printer.Sprint(v.Uint64())
+	case slog.KindFloat64:
+		return l.printer.Sprint(v.Float64())
+	case slog.KindTime:
+		return l.formatTime(v.Time())
+	default:
+		return v.String()
+	}
+}
@@ -0,0 +1,81 @@
+package serrors
+
+import (
+	"bytes"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"golang.org/x/text/language"
+)
+
+func TestSErrorsLocale(t *testing.T) {
+	tests := []struct {
+		name    string
+		options []Option
+		want    string
+	}{
+		{
+			name: "none",
+			want: "2000-01-02T03:04:05.000Z ERROR m\n  rows: 1234567\n  ratio: 0.5\n",
+		},
+		{
+			name:    "en-US",
+			options: []Option{WithLocale(language.AmericanEnglish)},
+			want:    "01/02/2000 3:04:05 AM UTC ERROR m\n  rows: 1,234,567\n  ratio: 0.5\n",
+		},
+		{
+			name:    "de-DE",
+			options: []Option{WithLocale(language.MustParse("de-DE"))},
+			want:    "02.01.2000 03:04:05 UTC ERROR m\n  rows: 1.234.567\n  ratio: 0,5\n",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			e := New(nil, nil, test.options...)
+			e.Add(testTime, slog.LevelError, "m", slog.Int("rows", 1234567), slog.Float64("ratio", 0.5))
+
+			if got := e.PrettyString(); got != test.want {
+				t.Fatalf("\ngot  %s\nwant %s", got, test.want)
+			}
+
+			// Machine output is not localized.
+			want := `[{"time":"2000-01-02T03:04:05Z","level":"ERROR","msg":"m","rows":1234567,"ratio":0.5}]`
+			if got, _ := e.MarshalJSON(); string(got) != want {
+				t.Fatalf("\ngot  %s\nwant %s", got, want)
+			}
+		})
+	}
+}
+
+func TestSErrorsLocaleHTML(t *testing.T) {
+	e := New(nil, nil, WithLocale(language.German))
+	e.Add(testTime, slog.LevelError, "m", slog.Int("rows", 1234567))
+
+	b := bytes.NewBuffer(nil)
+	if err := e.WriteHTML(b); err != nil {
+		t.Fatalf("\ngot  %s\nwant nil", err)
+	}
+
+	for _, want := range []string{"02.01.2000 03:04:05 UTC", "rows=1.234.567"} {
+		if !strings.Contains(b.String(), want) {
+			t.Fatalf("\ngot  %s\nwant %s", b, want)
+		}
+	}
+}
+
+func TestSErrorsLocaleText(t *testing.T) {
+	e := NewTextHandler(nil, nil, WithLocale(language.German))
+	e.Add(testTime, slog.LevelError, "m", slog.Int("rows", 1234567), slog.Float64("ratio", 0.5))
+
+	want := "time=\"02.01.2000 03:04:05 UTC\" level=ERROR msg=m rows=1.234.567 ratio=0,5\n"
+	if got := e.String(); got != want {
+		t.Fatalf("\ngot  %s\nwant %s", got, want)
+	}
+
+	want = "time=2000-01-02T03:04:05.000Z level=ERROR msg=m rows=1234567 ratio=0.5\n"
+	if got, _ := e.MarshalText(); string(got) != want {
+		t.Fatalf("\ngot  %s\nwant %s", got, want)
+	}
+}
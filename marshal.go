@@ -0,0 +1,63 @@
+package serrors
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+)
+
+// encodeRecords renders rs as a JSON array with a JSON handler using opts, passing each record
+// through prep first if it is set
+func encodeRecords(
+	rs []slog.Record,
+	opts *slog.HandlerOptions,
+	prep func(slog.Record) slog.Record,
+) ([]byte, error) {
+	raw, err := jsonRecords(rs, opts, prep)
+	if err != nil {
+		return nil, err
+	}
+
+	return encodeArray(raw)
+}
+
+// jsonRecords renders each of rs into its own json.RawMessage with a JSON handler using opts,
+// passing each record through prep first if it is set
+func jsonRecords(
+	rs []slog.Record,
+	opts *slog.HandlerOptions,
+	prep func(slog.Record) slog.Record,
+) ([]json.RawMessage, error) {
+	var b bytes.Buffer
+	h := slog.NewJSONHandler(&b, opts)
+	raw := make([]json.RawMessage, len(rs))
+	for i, r := range rs {
+		if prep != nil {
+			r = prep(r)
+		}
+
+		b.Reset()
+		if err := h.Handle(context.Background(), r); err != nil {
+			return nil, fmt.Errorf("serrors: record %d: %w", i, err)
+		}
+		raw[i] = bytes.Clone(bytes.TrimSuffix(b.Bytes(), []byte("\n")))
+	}
+
+	return raw, nil
+}
+
+// encodeArray writes raw as a JSON array. The encoder validates every message, so a record that
+// cannot be rendered returns an error instead of a broken array. HTML characters are left
+// unescaped, as the handler writes them.
+func encodeArray(raw []json.RawMessage) ([]byte, error) {
+	var out bytes.Buffer
+	enc := json.NewEncoder(&out)
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(raw); err != nil {
+		return nil, err
+	}
+
+	return bytes.TrimSuffix(out.Bytes(), []byte("\n")), nil
+}
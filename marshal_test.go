@@ -0,0 +1,85 @@
+package serrors
+
+import (
+	"encoding/json"
+	"log/slog"
+	"testing"
+	"unicode/utf8"
+)
+
+func TestSErrorsMarshalJSONEncoder(t *testing.T) {
+	tests := []struct {
+		name string
+		e    *SErrors
+		want string
+	}{
+		{
+			"text",
+			NewTextHandler(nil, nil),
+			`[{"time":"2000-01-02T03:04:05Z","level":"ERROR","msg":"<a> & \"b\"","k":"v\n"}]`,
+		},
+		{
+			"replace attr",
+			New(nil, &slog.HandlerOptions{ReplaceAttr: func(_ []string, a slog.Attr) slog.Attr {
+				if a.Key == "k" {
+					return slog.Any("k\"", []string{"}", "]"})
+				}
+				return a
+			}}),
+			`[{"time":"2000-01-02T03:04:05Z","level":"ERROR","msg":"<a> & \"b\"","k\"":["}","]"]}]`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			test.e.Add(testTime, slog.LevelError, `<a> & "b"`, slog.String("k", "v\n"))
+			got, err := test.e.MarshalJSON()
+			if err != nil || string(got) != test.want {
+				t.Fatalf("\ngot  %s, %v\nwant %s", got, err, test.want)
+			}
+		})
+	}
+}
+
+func FuzzMarshalJSON(f *testing.F) {
+	f.Add("msg", "key", "value", "group", false)
+	f.Add(`"quoted" <html> & \`, `"k"`, "line\nbreak\ttab", "", true)
+	f.Add("\x00\x1f  ", "msg", "\xff\xfe", "level", false)
+	f.Add("", "", "", "", true)
+
+	f.Fuzz(func(t *testing.T, msg, key, value, group string, text bool) {
+		e := New(nil, nil)
+		if text {
+			e = NewTextHandler(nil, nil)
+		}
+
+		attr := slog.String(key, value)
+		if group != "" {
+			attr = slog.Group(group, attr, slog.Any("list", []string{value, key}))
+		}
+		e.Add(testTime, slog.LevelError, msg, attr)
+		e.Add(testTime, slog.LevelInfo, value)
+
+		b, err := e.MarshalJSON()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if !json.Valid(b) {
+			t.Fatalf("invalid JSON %s", b)
+		}
+
+		var raw []map[string]any
+		if err := json.Unmarshal(b, &raw); err != nil || len(raw) != 2 {
+			t.Fatalf("\ngot  %d records, %v\nwant 2", len(raw), err)
+		}
+
+		if !utf8.ValidString(msg) || (group == "" && key == slog.MessageKey) {
+			return
+		}
+
+		if got := raw[0][slog.MessageKey]; got != msg {
+			t.Fatalf("\ngot  %q\nwant %q", got, msg)
+		}
+	})
+}
@@ -0,0 +1,72 @@
+package serrors
+
+import (
+	"bytes"
+	"encoding/json"
+	"slices"
+)
+
+// metaField is a key set with SetMeta
+type metaField struct {
+	key   string
+	value any
+}
+
+// WithMetaBlock makes MarshalJSON write {"meta":{...},"errors":[...]} instead of a bare array, so
+// consumers get a self-describing document. meta holds the fields set with SetMeta, in the order
+// they were first set.
+func WithMetaBlock() Option {
+	return func(e *SErrors) {
+		e.metaBlock = true
+	}
+}
+
+// SetMeta sets a collection-level field such as a job id, version or duration. v must marshal to
+// JSON. Fields are only written by MarshalJSON when WithMetaBlock is used.
+func (e *SErrors) SetMeta(key string, v any) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	i := slices.IndexFunc(e.meta, func(f metaField) bool { return f.key == key })
+	if i < 0 {
+		e.meta = append(e.meta, metaField{key: key, value: v})
+		return
+	}
+
+	e.meta[i].value = v
+}
+
+// marshalMeta returns the records wrapped in a meta block. e.mu must be held.
+func (e *SErrors) marshalMeta() ([]byte, error) {
+	b := bytes.NewBufferString(`{"meta":{`)
+	for i, f := range e.meta {
+		if i > 0 {
+			b.WriteByte(',')
+		}
+
+		k, err := json.Marshal(f.key)
+		if err != nil {
+			return nil, err
+		}
+
+		v, err := json.Marshal(f.value)
+		if err != nil {
+			return nil, err
+		}
+
+		b.Write(k)
+		b.WriteByte(':')
+		b.Write(v)
+	}
+
+	rs, err := e.recordsArray()
+	if err != nil {
+		return nil, err
+	}
+
+	b.WriteString(`},"errors":`)
+	b.Write(rs)
+	b.WriteString("}")
+
+	return b.Bytes(), nil
+}
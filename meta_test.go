@@ -0,0 +1,54 @@
+package serrors
+
+import (
+	"log/slog"
+	"testing"
+)
+
+func TestSErrorsMetaBlock(t *testing.T) {
+	tests := []struct {
+		name    string
+		options []Option
+		want    string
+	}{
+		{
+			name: "bare",
+			want: `[{"time":"2000-01-02T03:04:05Z","level":"ERROR","msg":"m"}]`,
+		},
+		{
+			name:    "meta",
+			options: []Option{WithMetaBlock()},
+			want: `{"meta":{"job":"nightly","version":3,"duration":"1.5s"},` +
+				`"errors":[{"time":"2000-01-02T03:04:05Z","level":"ERROR","msg":"m"}]}`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			e := New(nil, nil, test.options...)
+			e.SetMeta("job", "nightly")
+			e.SetMeta("version", 2)
+			e.SetMeta("duration", "1.5s")
+			e.SetMeta("version", 3)
+			e.Add(testTime, slog.LevelError, "m")
+
+			got, err := e.MarshalJSON()
+			if err != nil {
+				t.Fatalf("\ngot  %s\nwant nil", err)
+			}
+
+			if string(got) != test.want {
+				t.Fatalf("\ngot  %s\nwant %s", got, test.want)
+			}
+		})
+	}
+}
+
+func TestSErrorsMetaBlockError(t *testing.T) {
+	e := New(nil, nil, WithMetaBlock())
+	e.SetMeta("bad", func() {})
+
+	if _, err := e.MarshalJSON(); err == nil {
+		t.Fatalf("\ngot  nil\nwant error")
+	}
+}
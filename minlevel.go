@@ -0,0 +1,28 @@
+package serrors
+
+import "log/slog"
+
+// WithMinLevel drops records added below l, so Debug and Info records can be left out of memory
+// and not only out of the log output. Records added by Stack, Append and Recover are not filtered.
+func WithMinLevel(l slog.Level) Option {
+	return func(e *SErrors) {
+		e.minLevel = l
+	}
+}
+
+// SetMinLevel changes the level records added must reach to be kept, see WithMinLevel. Records
+// already collected are kept. LevelDiscard keeps every level.
+func (e *SErrors) SetMinLevel(l slog.Level) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	e.minLevel = l
+}
+
+// MinLevel returns the level records added must reach to be kept, LevelDiscard if there is none
+func (e *SErrors) MinLevel() slog.Level {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+
+	return e.minLevel
+}
@@ -0,0 +1,28 @@
+package serrors
+
+import (
+	"log/slog"
+	"testing"
+)
+
+func TestSErrorsMinLevel(t *testing.T) {
+	e := NewTextHandler(nil, nil, WithMinLevel(slog.LevelWarn))
+	e.Info(testTime, "a")
+	e.Warn(testTime, "b")
+
+	e.SetMinLevel(slog.LevelError)
+	e.Warn(testTime, "c")
+	e.Error(testTime, "d")
+
+	want := "time=2000-01-02T03:04:05.000Z level=WARN msg=b\n" +
+		"time=2000-01-02T03:04:05.000Z level=ERROR msg=d\n"
+	if got := e.String(); got != want {
+		t.Fatalf("\ngot  %s\nwant %s", got, want)
+	}
+
+	e.SetMinLevel(LevelDiscard)
+	e.Debug(testTime, "e")
+	if n := len(e.Records()); e.MinLevel() != LevelDiscard || n != 3 {
+		t.Fatalf("\ngot  %s, %d records\nwant %s, 3 records", e.MinLevel(), n, LevelDiscard)
+	}
+}
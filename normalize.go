@@ -0,0 +1,113 @@
+package serrors
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"slices"
+	"time"
+)
+
+// DefaultVolatileKeys are the attr keys NormalizedJSON drops unless NormalizeOptions.DropKeys is
+// set: ids, hashes, sequence numbers, durations and stacks that change from run to run
+var DefaultVolatileKeys = []string{
+	IDKey, OpIDKey, DurationKey, StackKey, slog.SourceKey, AuditSeqKey, AuditPrevHashKey, AuditHashKey,
+}
+
+// NormalizeOptions configures NormalizedJSON
+type NormalizeOptions struct {
+	// TimePrecision truncates record times and time attrs, in UTC. 0 removes them.
+	TimePrecision time.Duration
+	// DropKeys are attr keys removed at any depth. nil drops DefaultVolatileKeys and an empty
+	// slice drops nothing.
+	DropKeys []string
+	// SortRecords orders the records by their normalized JSON instead of the order they were added,
+	// for jobs whose records are added concurrently
+	SortRecords bool
+}
+
+// NormalizedJSON returns every record, including spilled ones, in a stable form for byte
+// comparison with golden files in CI: volatile fields are dropped or truncated as set by opts,
+// each record is canonical JSON with sorted keys, see WithCanonicalJSON, and the array holds one
+// record per line. Profiles and handler options are not applied.
+func (e *SErrors) NormalizedJSON(opts NormalizeOptions) ([]byte, error) {
+	drop := opts.DropKeys
+	if drop == nil {
+		drop = DefaultVolatileKeys
+	}
+
+	var lines [][]byte
+	var b bytes.Buffer
+	h := slog.NewJSONHandler(&b, nil)
+	err := e.eachRecord(func(r slog.Record) error {
+		b.Reset()
+		r = normalizeRecord(r, opts.TimePrecision, drop)
+		if err := h.Handle(context.Background(), r); err != nil {
+			return err
+		}
+
+		line, err := canonicalJSON(b.Bytes())
+		lines = append(lines, line)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if opts.SortRecords {
+		slices.SortFunc(lines, bytes.Compare)
+	}
+
+	if len(lines) == 0 {
+		return []byte("[]\n"), nil
+	}
+
+	return append(append([]byte("[\n"), bytes.Join(lines, []byte(",\n"))...), "\n]\n"...), nil
+}
+
+// normalizeRecord returns r with its time truncated to precision, or removed if it is 0, and the
+// attrs in drop removed
+func normalizeRecord(r slog.Record, precision time.Duration, drop []string) slog.Record {
+	n := slog.NewRecord(normalizeTime(r.Time, precision), r.Level, r.Message, 0)
+	r.Attrs(func(a slog.Attr) bool {
+		if a, ok := normalizeAttr(a, precision, drop); ok {
+			n.AddAttrs(a)
+		}
+		return true
+	})
+
+	return n
+}
+
+// normalizeAttr returns a normalized like normalizeRecord and false if it is dropped
+func normalizeAttr(a slog.Attr, precision time.Duration, drop []string) (slog.Attr, bool) {
+	if slices.Contains(drop, a.Key) {
+		return a, false
+	}
+
+	a.Value = a.Value.Resolve()
+	switch a.Value.Kind() {
+	case slog.KindTime:
+		t := normalizeTime(a.Value.Time(), precision)
+		return slog.Time(a.Key, t), !t.IsZero()
+	case slog.KindGroup:
+		var attrs []slog.Attr
+		for _, ga := range a.Value.Group() {
+			if ga, ok := normalizeAttr(ga, precision, drop); ok {
+				attrs = append(attrs, ga)
+			}
+		}
+		return slog.Attr{Key: a.Key, Value: slog.GroupValue(attrs...)}, len(attrs) > 0
+	}
+
+	return a, true
+}
+
+// normalizeTime truncates t to precision in UTC, or returns the zero time if precision is 0
+func normalizeTime(t time.Time, precision time.Duration) time.Time {
+	if precision <= 0 {
+		return time.Time{}
+	}
+
+	return t.UTC().Truncate(precision)
+}
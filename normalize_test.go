@@ -0,0 +1,52 @@
+package serrors
+
+import (
+	"log/slog"
+	"testing"
+	"time"
+)
+
+func TestSErrorsNormalizedJSON(t *testing.T) {
+	e := NewTextHandler(nil, nil)
+	e.Error(testTime.Add(1500*time.Millisecond), "b", slog.String(OpIDKey, "x1"),
+		slog.Group("req", slog.Time("at", testTime.Add(time.Minute+time.Second)), slog.String("z", "1"), slog.Int("a", 2)))
+	e.Warn(testTime, "a", slog.Duration(DurationKey, time.Second))
+
+	tests := []struct {
+		name string
+		opts NormalizeOptions
+		want string
+	}{
+		{
+			"defaults",
+			NormalizeOptions{},
+			"[\n" +
+				`{"level":"ERROR","msg":"b","req":{"a":2,"z":"1"}},` + "\n" +
+				`{"level":"WARN","msg":"a"}` + "\n]\n",
+		},
+		{
+			"precision sorted",
+			NormalizeOptions{TimePrecision: time.Minute, DropKeys: []string{}, SortRecords: true},
+			"[\n" +
+				`{"duration":1000000000,"level":"WARN","msg":"a","time":"2000-01-02T03:04:00Z"},` + "\n" +
+				`{"level":"ERROR","msg":"b","op_id":"x1","req":{"a":2,"at":"2000-01-02T03:05:00Z","z":"1"},"time":"2000-01-02T03:04:00Z"}` + "\n]\n",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := e.NormalizedJSON(test.opts)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if string(got) != test.want {
+				t.Fatalf("\ngot  %s\nwant %s", got, test.want)
+			}
+		})
+	}
+
+	if got, _ := New(nil, nil).NormalizedJSON(NormalizeOptions{}); string(got) != "[]\n" {
+		t.Fatalf("\ngot  %s\nwant []", got)
+	}
+}
@@ -0,0 +1,60 @@
+package serrors
+
+import (
+	"log/slog"
+	"time"
+)
+
+// AddNow adds a new slog.Record timestamped with the current time from slog.Attr(s), see Add and
+// WithClock
+func (e *SErrors) AddNow(l slog.Level, msg string, attrs ...slog.Attr) {
+	e.addAttrs(e.pc(), e.now(), l, msg, attrs)
+}
+
+// AddAnyNow adds a new slog.Record timestamped with the current time from generics, see AddAny
+func (e *SErrors) AddAnyNow(l slog.Level, msg string, args ...any) {
+	e.addArgs(e.pc(), e.now(), l, msg, args)
+}
+
+// DebugNow adds a new Debug Level slog.Record timestamped with the current time
+func (e *SErrors) DebugNow(msg string, attrs ...slog.Attr) {
+	e.addAttrs(e.pc(), e.now(), slog.LevelDebug, msg, attrs)
+}
+
+// InfoNow adds a new Info Level slog.Record timestamped with the current time
+func (e *SErrors) InfoNow(msg string, attrs ...slog.Attr) {
+	e.addAttrs(e.pc(), e.now(), slog.LevelInfo, msg, attrs)
+}
+
+// WarnNow adds a new Warn Level slog.Record timestamped with the current time
+func (e *SErrors) WarnNow(msg string, attrs ...slog.Attr) {
+	e.addAttrs(e.pc(), e.now(), slog.LevelWarn, msg, attrs)
+}
+
+// ErrorNow adds a new Error Level slog.Record timestamped with the current time
+func (e *SErrors) ErrorNow(msg string, attrs ...slog.Attr) {
+	e.addAttrs(e.pc(), e.now(), slog.LevelError, msg, attrs)
+}
+
+// Clock tells the current time, see WithClock
+type Clock interface {
+	Now() time.Time
+}
+
+// WithClock makes the collection read the current time from c instead of time.Now, for AddNow and
+// its variants, operation durations, summaries, provenance, record TTLs and crash reports, so
+// tests can fake time. Watch still ticks in real time.
+func WithClock(c Clock) Option {
+	return func(e *SErrors) {
+		e.clock = c
+	}
+}
+
+// now returns the current time from the clock
+func (e *SErrors) now() time.Time {
+	if e.clock == nil {
+		return time.Now()
+	}
+
+	return e.clock.Now()
+}
@@ -0,0 +1,51 @@
+package serrors
+
+import (
+	"log/slog"
+	"testing"
+	"time"
+)
+
+func TestSErrorsAddNow(t *testing.T) {
+	e := NewTextHandler(nil, nil)
+	before := time.Now()
+	e.AddNow(slog.LevelInfo, "a", slog.Int("i", 1))
+	e.AddAnyNow(slog.LevelInfo, "b", "i", 2)
+	e.DebugNow("c")
+	e.InfoNow("d")
+	e.WarnNow("e")
+	e.ErrorNow("f")
+	after := time.Now()
+
+	rs := e.Records()
+	want := []slog.Level{
+		slog.LevelInfo, slog.LevelInfo, slog.LevelDebug, slog.LevelInfo, slog.LevelWarn, slog.LevelError,
+	}
+	if len(rs) != len(want) {
+		t.Fatalf("\ngot  %d records\nwant %d", len(rs), len(want))
+	}
+
+	for i, r := range rs {
+		if r.Level != want[i] || r.Time.Before(before) || r.Time.After(after) {
+			t.Fatalf("\ngot  %s at %s\nwant %s between %s and %s", r.Level, r.Time, want[i], before, after)
+		}
+	}
+}
+
+// fixedClock is a Clock always telling the same time
+type fixedClock time.Time
+
+func (c fixedClock) Now() time.Time { return time.Time(c) }
+
+func TestSErrorsWithClock(t *testing.T) {
+	e := NewTextHandler(nil, nil, WithClock(fixedClock(testTime)), WithRecordTTL(time.Hour))
+	e.Info(testTime.Add(-2*time.Hour), "expired")
+	e.WarnNow("a")
+	e.StopWatch("step")(slog.LevelInfo)
+
+	want := "time=2000-01-02T03:04:05.000Z level=WARN msg=a\n" +
+		"time=2000-01-02T03:04:05.000Z level=INFO msg=step duration=0s\n"
+	if got := e.String(); got != want {
+		t.Fatalf("\ngot  %s\nwant %s", got, want)
+	}
+}
@@ -0,0 +1,82 @@
+package serrors
+
+import (
+	"log/slog"
+	"sync"
+	"time"
+)
+
+// Attr keys of the records added by Begin, Op.End and StopWatch
+const (
+	// OpIDKey links the begin and end records of an operation
+	OpIDKey = "op_id"
+	// PhaseKey is "begin" or "end"
+	PhaseKey = "phase"
+	// OutcomeKey is "ok" or "error" on end records
+	OutcomeKey = "outcome"
+	// DurationKey is the time between Begin and Op.End, or the time measured by StopWatch
+	DurationKey = "duration"
+)
+
+// Op is an operation started with Begin
+type Op struct {
+	e     *SErrors
+	name  string
+	id    uint64
+	start time.Time
+	attrs []slog.Attr
+	once  sync.Once
+}
+
+// Begin adds a Debug record for the start of the operation name and returns a handle to end it.
+// attrs are added to both the begin and end records.
+func (e *SErrors) Begin(name string, attrs ...slog.Attr) *Op {
+	o := &Op{e: e, name: name, id: e.opSeq.Add(1), start: e.now(), attrs: attrs}
+
+	r := slog.NewRecord(o.start, slog.LevelDebug, name, e.pc())
+	r.AddAttrs(slog.Uint64(OpIDKey, o.id), slog.String(PhaseKey, "begin"))
+	r.AddAttrs(attrs...)
+	e.add(r)
+
+	return o
+}
+
+// End adds the end record of the operation with its duration. The record is Info with outcome ok
+// if err is nil, otherwise Error with outcome error and err under ErrKey, so errors.Is and
+// errors.As find it through Err. Only the first call adds a record.
+func (o *Op) End(err error) {
+	pc := o.e.pc()
+	o.once.Do(func() {
+		now := o.e.now()
+		l, outcome := slog.LevelInfo, "ok"
+		if err != nil {
+			l, outcome = slog.LevelError, "error"
+		}
+
+		r := slog.NewRecord(now, l, o.name, pc)
+		r.AddAttrs(
+			slog.Uint64(OpIDKey, o.id),
+			slog.String(PhaseKey, "end"),
+			slog.String(OutcomeKey, outcome),
+			slog.Duration(DurationKey, now.Sub(o.start)),
+		)
+		if err != nil {
+			r.AddAttrs(slog.Any(ErrKey, err))
+		}
+		r.AddAttrs(o.attrs...)
+		o.e.add(r)
+	})
+}
+
+// StopWatch starts timing the step name. Calling the returned function adds a record for name at
+// level l with the elapsed time as the duration attr, followed by attrs.
+func (e *SErrors) StopWatch(name string) func(l slog.Level, attrs ...slog.Attr) {
+	start := e.now()
+	return func(l slog.Level, attrs ...slog.Attr) {
+		now := e.now()
+		r := slog.NewRecord(now, l, name, e.pc())
+		r.AddAttrs(slog.Duration(DurationKey, now.Sub(start)))
+		r.AddAttrs(attrs...)
+		e.add(r)
+	}
+}
@@ -0,0 +1,86 @@
+package serrors
+
+import (
+	"errors"
+	"log/slog"
+	"testing"
+)
+
+// attrMap returns the attrs of r by key
+func attrMap(r slog.Record) map[string]slog.Value {
+	m := map[string]slog.Value{}
+	r.Attrs(func(a slog.Attr) bool {
+		m[a.Key] = a.Value
+		return true
+	})
+
+	return m
+}
+
+func TestSErrorsBegin(t *testing.T) {
+	tests := []struct {
+		name    string
+		err     error
+		level   slog.Level
+		outcome string
+	}{
+		{name: "ok", level: slog.LevelInfo, outcome: "ok"},
+		{name: "error", err: errors.New("boom"), level: slog.LevelError, outcome: "error"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			e := NewTextHandler(nil, nil)
+			e.Begin("first").End(nil)
+			op := e.Begin("load users", slog.String("table", "users"))
+			op.End(test.err)
+			op.End(nil)
+
+			rs := e.Records()
+			if len(rs) != 4 {
+				t.Fatalf("\ngot  %d records\nwant 4", len(rs))
+			}
+
+			begin, end := attrMap(rs[2]), attrMap(rs[3])
+			if rs[2].Level != slog.LevelDebug || begin[PhaseKey].String() != "begin" || begin["table"].String() != "users" {
+				t.Fatalf("\ngot  %s %v\nwant DEBUG begin", rs[2].Level, begin)
+			}
+
+			if rs[3].Level != test.level || end[OutcomeKey].String() != test.outcome || end["table"].String() != "users" {
+				t.Fatalf("\ngot  %s %v\nwant %s %s", rs[3].Level, end, test.level, test.outcome)
+			}
+
+			if begin[OpIDKey].Uint64() != 2 || end[OpIDKey].Uint64() != 2 {
+				t.Fatalf("\ngot  %s %s\nwant 2 2", begin[OpIDKey], end[OpIDKey])
+			}
+
+			if end[DurationKey].Kind() != slog.KindDuration || end[DurationKey].Duration() < 0 {
+				t.Fatalf("\ngot  %s\nwant duration", end[DurationKey])
+			}
+
+			if test.err != nil && end[ErrKey].String() != "boom" {
+				t.Fatalf("\ngot  %s\nwant boom", end[ErrKey])
+			}
+
+			if test.err != nil && !errors.Is(e.Err(), test.err) {
+				t.Fatalf("\ngot  %v\nwant errors.Is %v", e.Err(), test.err)
+			}
+		})
+	}
+}
+
+func TestSErrorsStopWatch(t *testing.T) {
+	e := NewTextHandler(nil, nil)
+	stop := e.StopWatch("resize images")
+	stop(slog.LevelWarn, slog.Int("count", 3))
+
+	r := e.Last()
+	m := attrMap(r)
+	if r.Level != slog.LevelWarn || r.Message != "resize images" || m["count"].Int64() != 3 {
+		t.Fatalf("\ngot  %s %s %v\nwant WARN resize images count=3", r.Level, r.Message, m)
+	}
+
+	if m[DurationKey].Kind() != slog.KindDuration || m[DurationKey].Duration() < 0 {
+		t.Fatalf("\ngot  %s\nwant duration", m[DurationKey])
+	}
+}
@@ -0,0 +1,71 @@
+package serrors
+
+import (
+	"log/slog"
+	"slices"
+	"strings"
+)
+
+// WithAttrOrder sorts the attrs of each record in text output so important fields are always in
+// the same place. Attrs whose keys are in priority come first, in that order, followed by the rest
+// sorted by key. With no priority keys all attrs are sorted by key. JSON output is not affected.
+func WithAttrOrder(priority ...string) Option {
+	return func(e *SErrors) {
+		e.attrOrder = append([]string{}, priority...)
+	}
+}
+
+// prepare returns r as it is rendered by String, RtoString and Log. e.mu must be held.
+func (e *SErrors) prepare(r slog.Record) slog.Record {
+	return e.prepareFormat(r, e.json)
+}
+
+// prepareFormat returns r as it is rendered in JSON if json is set, otherwise in text. e.mu must
+// be held.
+func (e *SErrors) prepareFormat(r slog.Record, json bool) slog.Record {
+	if json {
+		return e.profiled(r)
+	}
+
+	return e.textRecord(r)
+}
+
+// textRecord returns r reduced to the verbosity level with its attrs ordered, as it is shown to
+// humans. e.mu must be held.
+func (e *SErrors) textRecord(r slog.Record) slog.Record {
+	r = e.verbose(r)
+	if e.attrOrder == nil {
+		return r
+	}
+
+	return e.ordered(r)
+}
+
+// ordered returns a copy of r with its attrs sorted by e.attrOrder
+func (e *SErrors) ordered(r slog.Record) slog.Record {
+	attrs := make([]slog.Attr, 0, r.NumAttrs())
+	r.Attrs(func(a slog.Attr) bool {
+		attrs = append(attrs, a)
+		return true
+	})
+
+	rank := func(key string) int {
+		if i := slices.Index(e.attrOrder, key); i >= 0 {
+			return i
+		}
+
+		return len(e.attrOrder)
+	}
+
+	slices.SortStableFunc(attrs, func(a, b slog.Attr) int {
+		if ra, rb := rank(a.Key), rank(b.Key); ra != rb {
+			return ra - rb
+		}
+
+		return strings.Compare(a.Key, b.Key)
+	})
+
+	n := slog.NewRecord(r.Time, r.Level, r.Message, r.PC)
+	n.AddAttrs(attrs...)
+	return n
+}
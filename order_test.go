@@ -0,0 +1,41 @@
+package serrors
+
+import (
+	"log/slog"
+	"testing"
+)
+
+func TestSErrorsAttrOrder(t *testing.T) {
+	tests := []struct {
+		name    string
+		options []Option
+		want    string
+	}{
+		{
+			name: "unsorted",
+			want: "time=2000-01-02T03:04:05.000Z level=ERROR msg=m zone=b request_id=7 alpha=1 code=500\n",
+		},
+		{
+			name:    "sorted",
+			options: []Option{WithAttrOrder()},
+			want:    "time=2000-01-02T03:04:05.000Z level=ERROR msg=m alpha=1 code=500 request_id=7 zone=b\n",
+		},
+		{
+			name:    "priority",
+			options: []Option{WithAttrOrder("code", "request_id")},
+			want:    "time=2000-01-02T03:04:05.000Z level=ERROR msg=m code=500 request_id=7 alpha=1 zone=b\n",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			e := NewTextHandler(nil, nil, test.options...)
+			e.Add(testTime, slog.LevelError, "m",
+				slog.String("zone", "b"), slog.Int("request_id", 7), slog.Int("alpha", 1), slog.Int("code", 500))
+
+			if got := e.String(); got != test.want {
+				t.Fatalf("\ngot  %s\nwant %s", got, test.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,350 @@
+package serrors
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// maxLine is the longest log line the parsers accept
+const maxLine = 64 << 20
+
+// ParseJSON reads slog JSON handler output, one object per line, back into records. The time,
+// level and msg keys are matched case-insensitively so output written with UpperCaseKey parses too.
+// Nested objects become groups, integers become Int64 attrs and other numbers Float64 attrs.
+// Blank lines are skipped. The returned SErrors uses the JSON handler writing to os.Stderr; use
+// Append to move the records into a configured collection.
+func ParseJSON(r io.Reader) (*SErrors, error) {
+	e := New(os.Stderr, nil)
+	err := scanLines(r, func(line []byte) error {
+		rec, err := parseJSONRecord(line)
+		if err != nil {
+			return err
+		}
+
+		e.AddRecord(rec)
+		return nil
+	})
+
+	return e, err
+}
+
+// scanLines calls fn for each non-blank line of r, adding the line number to errors
+func scanLines(r io.Reader, fn func(line []byte) error) error {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(nil, maxLine)
+	n := 0
+	for scanner.Scan() {
+		n++
+		line := bytes.TrimSpace(scanner.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+
+		if err := fn(line); err != nil {
+			return fmt.Errorf("serrors: line %d: %w", n, err)
+		}
+	}
+
+	return scanner.Err()
+}
+
+// parseJSONRecord converts one line of slog JSON output to a record
+func parseJSONRecord(line []byte) (slog.Record, error) {
+	d := json.NewDecoder(bytes.NewReader(line))
+	d.UseNumber()
+	attrs, err := decodeObject(d)
+	if err != nil {
+		return slog.Record{}, err
+	}
+
+	var t time.Time
+	var level slog.Level
+	var msg string
+	rest := make([]slog.Attr, 0, len(attrs))
+	for _, a := range attrs {
+		switch {
+		case strings.EqualFold(a.Key, slog.TimeKey) && a.Value.Kind() == slog.KindString:
+			if t, err = time.Parse(time.RFC3339Nano, a.Value.String()); err != nil {
+				return slog.Record{}, err
+			}
+		case strings.EqualFold(a.Key, slog.LevelKey) && a.Value.Kind() == slog.KindString:
+			if level, err = ParseLevel(a.Value.String()); err != nil {
+				return slog.Record{}, err
+			}
+		case strings.EqualFold(a.Key, slog.MessageKey) && a.Value.Kind() == slog.KindString:
+			msg = a.Value.String()
+		default:
+			rest = append(rest, a)
+		}
+	}
+
+	r := slog.NewRecord(t, level, msg, 0)
+	r.AddAttrs(rest...)
+	return r, nil
+}
+
+// decodeObject reads a JSON object from d as attrs, keeping the order of its keys
+func decodeObject(d *json.Decoder) ([]slog.Attr, error) {
+	tok, err := d.Token()
+	if err != nil {
+		return nil, err
+	}
+
+	if tok != json.Delim('{') {
+		return nil, fmt.Errorf("expected object, got %v", tok)
+	}
+
+	var attrs []slog.Attr
+	for d.More() {
+		tok, err := d.Token()
+		if err != nil {
+			return nil, err
+		}
+
+		key, _ := tok.(string)
+		v, err := decodeValue(d)
+		if err != nil {
+			return nil, err
+		}
+
+		attrs = append(attrs, slog.Attr{Key: key, Value: v})
+	}
+
+	// Consume the closing brace.
+	if _, err := d.Token(); err != nil {
+		return nil, err
+	}
+
+	return attrs, nil
+}
+
+// decodeValue reads the next JSON value from d as a slog.Value
+func decodeValue(d *json.Decoder) (slog.Value, error) {
+	if !d.More() {
+		return slog.Value{}, io.ErrUnexpectedEOF
+	}
+
+	// Peek at objects so they keep their key order as groups.
+	var raw json.RawMessage
+	if err := d.Decode(&raw); err != nil {
+		return slog.Value{}, err
+	}
+
+	if len(raw) > 0 && raw[0] == '{' {
+		od := json.NewDecoder(bytes.NewReader(raw))
+		od.UseNumber()
+		attrs, err := decodeObject(od)
+		if err != nil {
+			return slog.Value{}, err
+		}
+		return slog.GroupValue(attrs...), nil
+	}
+
+	vd := json.NewDecoder(bytes.NewReader(raw))
+	vd.UseNumber()
+	var v any
+	if err := vd.Decode(&v); err != nil {
+		return slog.Value{}, err
+	}
+
+	switch v := v.(type) {
+	case json.Number:
+		if i, err := v.Int64(); err == nil {
+			return slog.Int64Value(i), nil
+		}
+
+		f, err := v.Float64()
+		return slog.Float64Value(f), err
+	case string:
+		return slog.StringValue(v), nil
+	case bool:
+		return slog.BoolValue(v), nil
+	default:
+		return slog.AnyValue(v), nil
+	}
+}
+
+// ParseText reads slog text handler output or logfmt, one record per line, back into records.
+// Values may be quoted with Go escapes. Unquoted integers, floats and booleans become Int64,
+// Float64 and Bool attrs; everything else is a String. Dotted keys, which the text handler writes
+// for groups, are nested back into groups, and a key without a value is a true Bool. The time,
+// level and msg keys are matched case-insensitively. The returned SErrors uses the text handler
+// writing to os.Stderr; use Append to move the records into a configured collection.
+func ParseText(r io.Reader) (*SErrors, error) {
+	e := NewTextHandler(os.Stderr, nil)
+	err := scanLines(r, func(line []byte) error {
+		rec, err := parseTextRecord(string(line))
+		if err != nil {
+			return err
+		}
+
+		e.AddRecord(rec)
+		return nil
+	})
+
+	return e, err
+}
+
+// textPair is a key=value pair of a text line
+type textPair struct {
+	key    string
+	value  string
+	quoted bool
+	bare   bool
+}
+
+// parseTextRecord converts one line of slog text or logfmt output to a record
+func parseTextRecord(line string) (slog.Record, error) {
+	pairs, err := splitPairs(line)
+	if err != nil {
+		return slog.Record{}, err
+	}
+
+	var t time.Time
+	var level slog.Level
+	var msg string
+	root := &attrNode{}
+	for _, p := range pairs {
+		switch {
+		case strings.EqualFold(p.key, slog.TimeKey) && !p.bare:
+			if t, err = time.Parse(time.RFC3339Nano, p.value); err != nil {
+				return slog.Record{}, err
+			}
+		case strings.EqualFold(p.key, slog.LevelKey) && !p.bare:
+			if level, err = ParseLevel(p.value); err != nil {
+				return slog.Record{}, err
+			}
+		case strings.EqualFold(p.key, slog.MessageKey) && !p.bare:
+			msg = p.value
+		default:
+			root.insert(strings.Split(p.key, "."), textValue(p))
+		}
+	}
+
+	r := slog.NewRecord(t, level, msg, 0)
+	r.AddAttrs(root.attrs()...)
+	return r, nil
+}
+
+// splitPairs tokenizes a text line into key=value pairs
+func splitPairs(line string) ([]textPair, error) {
+	var pairs []textPair
+	for {
+		line = strings.TrimLeft(line, " \t")
+		if line == "" {
+			return pairs, nil
+		}
+
+		var p textPair
+		var err error
+		if p.key, line, _, err = textToken(line, true); err != nil {
+			return nil, err
+		}
+
+		if !strings.HasPrefix(line, "=") {
+			p.bare = true
+			pairs = append(pairs, p)
+			continue
+		}
+
+		if p.value, line, p.quoted, err = textToken(line[1:], false); err != nil {
+			return nil, err
+		}
+
+		pairs = append(pairs, p)
+	}
+}
+
+// textToken reads a quoted or bare token from the start of s and returns it with the rest of s.
+// Bare keys end at '=' as well as whitespace.
+func textToken(s string, key bool) (tok, rest string, quoted bool, err error) {
+	if strings.HasPrefix(s, `"`) {
+		q, err := strconv.QuotedPrefix(s)
+		if err != nil {
+			return "", "", false, fmt.Errorf("bad quoted value %.20q: %w", s, err)
+		}
+
+		tok, err = strconv.Unquote(q)
+		return tok, s[len(q):], true, err
+	}
+
+	end := strings.IndexFunc(s, func(r rune) bool { return r == ' ' || r == '\t' || (key && r == '=') })
+	if end < 0 {
+		return s, "", false, nil
+	}
+
+	return s[:end], s[end:], false, nil
+}
+
+// textValue types the value of p
+func textValue(p textPair) slog.Value {
+	switch {
+	case p.bare:
+		return slog.BoolValue(true)
+	case p.quoted:
+		return slog.StringValue(p.value)
+	}
+
+	if i, err := strconv.ParseInt(p.value, 10, 64); err == nil {
+		return slog.Int64Value(i)
+	}
+
+	if f, err := strconv.ParseFloat(p.value, 64); err == nil {
+		return slog.Float64Value(f)
+	}
+
+	if b, err := strconv.ParseBool(p.value); err == nil && (p.value == "true" || p.value == "false") {
+		return slog.BoolValue(b)
+	}
+
+	return slog.StringValue(p.value)
+}
+
+// attrNode builds nested groups from dotted keys while keeping the order keys were first seen
+type attrNode struct {
+	key      string
+	value    slog.Value
+	children []*attrNode
+}
+
+// insert adds v at path, creating groups as needed
+func (n *attrNode) insert(path []string, v slog.Value) {
+	if len(path) == 1 {
+		n.children = append(n.children, &attrNode{key: path[0], value: v})
+		return
+	}
+
+	for _, c := range n.children {
+		if c.key == path[0] && c.children != nil {
+			c.insert(path[1:], v)
+			return
+		}
+	}
+
+	c := &attrNode{key: path[0], children: []*attrNode{}}
+	n.children = append(n.children, c)
+	c.insert(path[1:], v)
+}
+
+// attrs converts the children of n to attrs
+func (n *attrNode) attrs() []slog.Attr {
+	attrs := make([]slog.Attr, len(n.children))
+	for i, c := range n.children {
+		if c.children != nil {
+			attrs[i] = slog.Attr{Key: c.key, Value: slog.GroupValue(c.attrs()...)}
+			continue
+		}
+
+		attrs[i] = slog.Attr{Key: c.key, Value: c.value}
+	}
+
+	return attrs
+}
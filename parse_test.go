@@ -0,0 +1,137 @@
+package serrors
+
+import (
+	"bytes"
+	"log/slog"
+	"strings"
+	"testing"
+)
+
+func TestParseJSON(t *testing.T) {
+	for _, test := range testAttrParamsJSON {
+		t.Run(test.name, func(t *testing.T) {
+			var want string
+			for _, p := range test.params {
+				want += p.want + "\n"
+			}
+
+			e, err := ParseJSON(strings.NewReader(want + "\n"))
+			if err != nil {
+				t.Fatalf("\ngot  %s\nwant nil", err.Error())
+			}
+
+			got := bytes.NewBuffer(nil)
+			if err := e.WriteNDJSON(got); err != nil {
+				t.Fatalf("\ngot  %s\nwant nil", err.Error())
+			}
+
+			// Keys written by UpperCaseKey are read back as the builtin keys.
+			want = strings.NewReplacer(`"TIME"`, `"time"`, `"LEVEL"`, `"level"`, `"MSG"`, `"msg"`).Replace(want)
+			if got.String() != want {
+				t.Fatalf("\ngot  %s\nwant %s", got, want)
+			}
+
+			if e.Level() != slog.LevelError {
+				t.Fatalf("\ngot  %s\nwant %s", e.Level(), slog.LevelError)
+			}
+		})
+	}
+}
+
+func TestParseJSONValues(t *testing.T) {
+	in := `{"time":"2000-01-02T03:04:05.5Z","level":"WARN+2","msg":"m","i":-3,"f":1.5,"b":false,"n":null,"a":[1,"x"],"g":{"z":1,"y":{"x":"w"}}}`
+	e, err := ParseJSON(strings.NewReader(in))
+	if err != nil {
+		t.Fatalf("\ngot  %s\nwant nil", err.Error())
+	}
+
+	r := e.records[0]
+	if r.Level != slog.LevelWarn+2 || r.Message != "m" || r.Time.Nanosecond() != 500000000 {
+		t.Fatalf("\ngot  %s %s %s\nwant WARN+2 m .5s", r.Level, r.Message, r.Time)
+	}
+
+	kinds := []slog.Kind{slog.KindInt64, slog.KindFloat64, slog.KindBool, slog.KindAny, slog.KindAny, slog.KindGroup}
+	var got []slog.Kind
+	r.Attrs(func(a slog.Attr) bool {
+		got = append(got, a.Value.Kind())
+		return true
+	})
+
+	if len(got) != len(kinds) {
+		t.Fatalf("\ngot  %v\nwant %v", got, kinds)
+	}
+
+	for i := range kinds {
+		if got[i] != kinds[i] {
+			t.Fatalf("\ngot  %v\nwant %v", got, kinds)
+		}
+	}
+
+	want := `time=2000-01-02T03:04:05.500Z level=WARN+2 msg=m i=-3 f=1.5 b=false n=<nil> a="[1 x]" g.z=1 g.y.x=w` + "\n"
+	tErrs := NewTextHandler(nil, nil)
+	tErrs.Append(e)
+	if tErrs.String() != want {
+		t.Fatalf("\ngot  %s\nwant %s", tErrs.String(), want)
+	}
+}
+
+func TestParseJSONError(t *testing.T) {
+	_, err := ParseJSON(strings.NewReader("{\"msg\":\"m\"}\n{\"level\":\"LOUD\"}\n"))
+	if err == nil || !strings.HasPrefix(err.Error(), "serrors: line 2: ") {
+		t.Fatalf("\ngot  %v\nwant line 2 error", err)
+	}
+
+	_, err = ParseJSON(strings.NewReader("[1]"))
+	if err == nil {
+		t.Fatalf("\ngot  nil\nwant error")
+	}
+}
+
+func TestParseText(t *testing.T) {
+	for _, test := range testAttrParamsText {
+		t.Run(test.name, func(t *testing.T) {
+			var want string
+			for _, p := range test.params {
+				want += p.want + "\n"
+			}
+
+			e, err := ParseText(strings.NewReader(want))
+			if err != nil {
+				t.Fatalf("\ngot  %s\nwant nil", err.Error())
+			}
+
+			want = strings.NewReplacer("TIME=", "time=", "LEVEL=", "level=", "MSG=", "msg=").Replace(want)
+			if got := e.String(); got != want {
+				t.Fatalf("\ngot  %s\nwant %s", got, want)
+			}
+		})
+	}
+}
+
+func TestParseTextValues(t *testing.T) {
+	in := `time=2000-01-02T03:04:05.000Z level=ERROR msg="a \"quoted\" msg" i=3 f=2.5 b=true s=x q="1" g.a=1 g.h.c=y g.d=2 flag "odd key"=v`
+	e, err := ParseText(strings.NewReader(in))
+	if err != nil {
+		t.Fatalf("\ngot  %s\nwant nil", err.Error())
+	}
+
+	r := e.records[0]
+	if r.Message != `a "quoted" msg` || r.Level != slog.LevelError || !r.Time.Equal(testTime) {
+		t.Fatalf("\ngot  %s %s %s\nwant quoted msg", r.Message, r.Level, r.Time)
+	}
+
+	jErrs := New(nil, nil)
+	jErrs.Append(e)
+	want := `{"time":"2000-01-02T03:04:05Z","level":"ERROR","msg":"a \"quoted\" msg","i":3,"f":2.5,"b":true,"s":"x","q":"1","g":{"a":1,"h":{"c":"y"},"d":2},"flag":true,"odd key":"v"}` + "\n"
+	if got := jErrs.String(); got != want {
+		t.Fatalf("\ngot  %s\nwant %s", got, want)
+	}
+}
+
+func TestParseTextError(t *testing.T) {
+	for _, in := range []string{`msg="unterminated`, `level=LOUD`, `time=yesterday`} {
+		if _, err := ParseText(strings.NewReader(in)); err == nil || !strings.HasPrefix(err.Error(), "serrors: line 1: ") {
+			t.Fatalf("\ngot  %v\nwant line 1 error for %s", err, in)
+		}
+	}
+}
@@ -0,0 +1,149 @@
+package serrors
+
+import (
+	"fmt"
+	"log/slog"
+	"runtime"
+	"slices"
+	"strings"
+)
+
+// prettyIndent is the indent of each level of attrs in pretty text
+const prettyIndent = "  "
+
+// PrettyString returns all records in pretty text, see RtoPretty
+func (e *SErrors) PrettyString() string {
+	e.expire()
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+
+	var b strings.Builder
+	for _, r := range e.records {
+		e.pretty(&b, r)
+	}
+
+	return b.String()
+}
+
+// RtoPretty converts a slog.Record to pretty text: a header line with the time, level and message
+// followed by an indented "key: value" line per attr. Groups and multi-line values such as stack
+// traces are indented below their key. It is meant for error reports read by humans and honours
+// RenderVerbosity and WithAttrOrder whichever handler e uses.
+func (e *SErrors) RtoPretty(r slog.Record) string {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+
+	var b strings.Builder
+	e.pretty(&b, r)
+	return b.String()
+}
+
+// pretty writes r to b in pretty text. The built-in attrs and the attrs of r go through the
+// ReplaceAttr of the handler options, as in the text and JSON output, and levels use the names
+// registered with RegisterLevel. e.mu must be held.
+func (e *SErrors) pretty(b *strings.Builder, r slog.Record) {
+	r = e.textRecord(r)
+	rep := e.opts.ReplaceAttr
+	if s := levelSymbol(e.levelSymbols, r.Level); s != "" {
+		b.WriteString(s + " ")
+	}
+
+	if !r.Time.IsZero() {
+		a, ok := replaced(rep, nil, slog.Time(slog.TimeKey, r.Time))
+		switch {
+		case !ok:
+		case a.Value.Kind() == slog.KindTime:
+			b.WriteString(e.locale.formatTime(a.Value.Time()) + " ")
+		default:
+			b.WriteString(e.locale.formatValue(a.Value) + " ")
+		}
+	}
+
+	if a, ok := replaced(rep, nil, slog.Any(slog.LevelKey, r.Level)); ok {
+		if l, isLevel := a.Value.Any().(slog.Level); isLevel {
+			b.WriteString(levelName(l) + " ")
+		} else {
+			b.WriteString(e.locale.formatValue(a.Value) + " ")
+		}
+	}
+
+	if a, ok := replaced(rep, nil, slog.String(slog.MessageKey, r.Message)); ok {
+		b.WriteString(e.locale.formatValue(a.Value))
+	}
+	b.WriteByte('\n')
+
+	if e.opts.AddSource && r.PC != 0 {
+		f, _ := runtime.CallersFrames([]uintptr{r.PC}).Next()
+		writePretty(b, prettyIndent, e.locale, rep, nil,
+			slog.String(slog.SourceKey, fmt.Sprintf("%s:%d", f.File, f.Line)))
+	}
+
+	r.Attrs(func(a slog.Attr) bool {
+		writePretty(b, prettyIndent, e.locale, rep, nil, a)
+		return true
+	})
+}
+
+// replaced returns a passed through rep, if not nil, with groups, and false if it is to be left out
+func replaced(rep func([]string, slog.Attr) slog.Attr, groups []string, a slog.Attr) (slog.Attr, bool) {
+	if rep != nil {
+		a = rep(groups, a)
+		a.Value = a.Value.Resolve()
+	}
+
+	return a, a.Key != ""
+}
+
+// writePretty writes a to b as "key: value" lines starting with indent, with values formatted for
+// l. Attrs other than groups go through rep, which sees the names of the enclosing groups.
+func writePretty(
+	b *strings.Builder,
+	indent string,
+	l *locale,
+	rep func([]string, slog.Attr) slog.Attr,
+	groups []string,
+	a slog.Attr,
+) {
+	v := a.Value.Resolve()
+	if v.Kind() == slog.KindGroup {
+		attrs := v.Group()
+		if len(attrs) == 0 {
+			return
+		}
+
+		// Attrs of a group without a key are inlined, as slog does.
+		if a.Key != "" {
+			b.WriteString(indent + a.Key + ":\n")
+			indent += prettyIndent
+			groups = append(slices.Clip(groups), a.Key)
+		}
+
+		for _, ga := range attrs {
+			writePretty(b, indent, l, rep, groups, ga)
+		}
+
+		return
+	}
+
+	a.Value = v
+	a, ok := replaced(rep, groups, a)
+	if !ok {
+		return
+	}
+
+	if a.Value.Kind() == slog.KindGroup {
+		writePretty(b, indent, l, nil, groups, a)
+		return
+	}
+
+	s := l.formatValue(a.Value)
+	if !strings.Contains(s, "\n") {
+		b.WriteString(indent + a.Key + ": " + s + "\n")
+		return
+	}
+
+	b.WriteString(indent + a.Key + ":\n")
+	for _, line := range strings.Split(strings.TrimRight(s, "\n"), "\n") {
+		b.WriteString(indent + prettyIndent + line + "\n")
+	}
+}
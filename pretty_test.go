@@ -0,0 +1,90 @@
+package serrors
+
+import (
+	"log/slog"
+	"testing"
+)
+
+func TestSErrorsPretty(t *testing.T) {
+	tests := []struct {
+		name      string
+		verbosity int
+		want      string
+	}{
+		{
+			name:      "debug",
+			verbosity: VerbosityDebug,
+			want: "2000-01-02T03:04:05.000Z ERROR disk full\n" +
+				"  path: /var/a b\n" +
+				"  req:\n" +
+				"    id: 7\n" +
+				"    user: bob\n" +
+				"  stack:\n" +
+				"    main.main()\n" +
+				"    \t/src/main.go:12\n" +
+				"2000-01-02T03:04:05.000Z WARN retrying\n",
+		},
+		{
+			name:      "all",
+			verbosity: VerbosityAll,
+			want: "2000-01-02T03:04:05.000Z ERROR disk full\n" +
+				"  path: /var/a b\n" +
+				"  req:\n" +
+				"    id: 7\n" +
+				"    user: bob\n" +
+				"2000-01-02T03:04:05.000Z WARN retrying\n",
+		},
+		{
+			name:      "quiet",
+			verbosity: VerbosityQuiet,
+			want:      "ERROR disk full\nWARN retrying\n",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			e := New(nil, nil)
+			e.RenderVerbosity(test.verbosity)
+			e.Add(testTime, slog.LevelError, "disk full",
+				slog.String("path", "/var/a b"),
+				slog.Group("req", slog.Int("id", 7), slog.String("user", "bob")),
+				slog.String(StackKey, "main.main()\n\t/src/main.go:12\n"),
+			)
+			e.Add(testTime, slog.LevelWarn, "retrying")
+
+			if got := e.PrettyString(); got != test.want {
+				t.Fatalf("\ngot  %s\nwant %s", got, test.want)
+			}
+		})
+	}
+}
+
+func TestSErrorsPrettyReplaceAttr(t *testing.T) {
+	RegisterLevel("FATAL", slog.LevelError+4)
+	opts := &slog.HandlerOptions{ReplaceAttr: func(groups []string, a slog.Attr) slog.Attr {
+		switch {
+		case a.Key == slog.TimeKey:
+			return slog.Attr{}
+		case a.Key == "password":
+			return slog.String(a.Key, "***")
+		case len(groups) > 0 && a.Key == "user":
+			return slog.String("login", a.Value.String())
+		}
+
+		return a
+	}}
+
+	e := New(nil, opts)
+	e.Add(testTime, slog.LevelError+4, "auth failed",
+		slog.String("password", "hunter2"),
+		slog.Group("req", slog.String("user", "bob")),
+	)
+
+	want := "FATAL auth failed\n" +
+		"  password: ***\n" +
+		"  req:\n" +
+		"    login: bob\n"
+	if got := e.PrettyString(); got != want {
+		t.Fatalf("\ngot  %s\nwant %s", got, want)
+	}
+}
@@ -0,0 +1,64 @@
+package serrors
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+)
+
+// Addf adds a new slog.Record whose message is formatted like fmt.Errorf. If format wraps errors
+// with %w, the formatted error is added under ErrKey so errors.Is and errors.As find them through
+// Err.
+func (e *SErrors) Addf(t time.Time, l slog.Level, format string, args ...any) {
+	e.addf(e.pc(), t, l, nil, format, args)
+}
+
+// AddfAttrs is Addf followed by attrs
+func (e *SErrors) AddfAttrs(
+	t time.Time,
+	l slog.Level,
+	attrs []slog.Attr,
+	format string,
+	args ...any,
+) {
+	e.addf(e.pc(), t, l, attrs, format, args)
+}
+
+// Debugf adds a new Debug Level slog.Record with a formatted message, see Addf
+func (e *SErrors) Debugf(t time.Time, format string, args ...any) {
+	e.addf(e.pc(), t, slog.LevelDebug, nil, format, args)
+}
+
+// Infof adds a new Info Level slog.Record with a formatted message, see Addf
+func (e *SErrors) Infof(t time.Time, format string, args ...any) {
+	e.addf(e.pc(), t, slog.LevelInfo, nil, format, args)
+}
+
+// Warnf adds a new Warn Level slog.Record with a formatted message, see Addf
+func (e *SErrors) Warnf(t time.Time, format string, args ...any) {
+	e.addf(e.pc(), t, slog.LevelWarn, nil, format, args)
+}
+
+// Errorf adds a new Error Level slog.Record with a formatted message, see Addf
+func (e *SErrors) Errorf(t time.Time, format string, args ...any) {
+	e.addf(e.pc(), t, slog.LevelError, nil, format, args)
+}
+
+// addf adds a record with the source pc, a message formatted from format and args and attrs
+func (e *SErrors) addf(
+	pc uintptr,
+	t time.Time,
+	l slog.Level,
+	attrs []slog.Attr,
+	format string,
+	args []any,
+) {
+	err := fmt.Errorf(format, args...)
+	_, multi := err.(interface{ Unwrap() []error })
+	if errors.Unwrap(err) != nil || multi {
+		attrs = append([]slog.Attr{slog.Any(ErrKey, err)}, attrs...)
+	}
+
+	e.addAttrs(pc, t, l, err.Error(), attrs)
+}
@@ -0,0 +1,32 @@
+package serrors
+
+import (
+	"errors"
+	"io"
+	"log/slog"
+	"testing"
+)
+
+func TestSErrorsAddf(t *testing.T) {
+	e := NewTextHandler(nil, nil)
+	e.Debugf(testTime, "d %d", 1)
+	e.Infof(testTime, "i %s", "x")
+	e.Warnf(testTime, "w %q", "y")
+	e.Errorf(testTime, "read config: %w", io.EOF)
+	e.Addf(testTime, slog.LevelWarn+2, "%d%%", 50)
+	e.AddfAttrs(testTime, slog.LevelInfo, []slog.Attr{slog.String("op", "load")}, "tries=%d", 3)
+
+	want := "time=2000-01-02T03:04:05.000Z level=DEBUG msg=\"d 1\"\n" +
+		"time=2000-01-02T03:04:05.000Z level=INFO msg=\"i x\"\n" +
+		"time=2000-01-02T03:04:05.000Z level=WARN msg=\"w \\\"y\\\"\"\n" +
+		"time=2000-01-02T03:04:05.000Z level=ERROR msg=\"read config: EOF\" err=\"read config: EOF\"\n" +
+		"time=2000-01-02T03:04:05.000Z level=WARN+2 msg=50%\n" +
+		"time=2000-01-02T03:04:05.000Z level=INFO msg=\"tries=3\" op=load\n"
+	if got := e.String(); got != want {
+		t.Fatalf("\ngot  %s\nwant %s", got, want)
+	}
+
+	if !errors.Is(e.Err(), io.EOF) {
+		t.Fatal("errors.Is did not find io.EOF")
+	}
+}
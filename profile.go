@@ -0,0 +1,197 @@
+package serrors
+
+import (
+	"log/slog"
+	"strconv"
+	"strings"
+)
+
+// Profile maps records to the field names of a log pipeline in JSON output, see WithProfile
+type Profile struct {
+	// Record rewrites each record before it is handled. It may be nil.
+	Record func(r slog.Record) slog.Record
+	// ReplaceAttr renames attrs, including the built-in time, level, msg and source attrs. It runs
+	// before the ReplaceAttr of the slog.HandlerOptions, which sees the renamed attrs, and may be
+	// nil.
+	ReplaceAttr func(groups []string, a slog.Attr) slog.Attr
+}
+
+// WithProfile applies p to JSON output: the JSON handler, MarshalJSON and WriteNDJSON. Text and
+// human-facing output, and the dashboard, are not affected.
+func WithProfile(p Profile) Option {
+	return func(e *SErrors) {
+		e.profile = &p
+		opts := *e.opts
+		if p.ReplaceAttr != nil {
+			user := opts.ReplaceAttr
+			opts.ReplaceAttr = func(groups []string, a slog.Attr) slog.Attr {
+				a = p.ReplaceAttr(groups, a)
+				if user == nil || a.Key == "" {
+					return a
+				}
+
+				return user(groups, a)
+			}
+		}
+
+		e.profileOpts = &opts
+	}
+}
+
+// jsonOpts returns the options of JSON handlers, including the profile
+func (e *SErrors) jsonOpts() *slog.HandlerOptions {
+	if e.profileOpts != nil {
+		return e.profileOpts
+	}
+
+	return e.opts
+}
+
+// profiled returns r rewritten by the profile
+func (e *SErrors) profiled(r slog.Record) slog.Record {
+	if e.profile == nil || e.profile.Record == nil {
+		return r
+	}
+
+	return e.profile.Record(r)
+}
+
+// ECSVersion is the Elastic Common Schema version written by ProfileECS
+const ECSVersion = "8.11.0"
+
+// ProfileECS renders records with Elastic Common Schema field names so they can be shipped
+// straight into Elastic: time is @timestamp, level is log.level in lower case, msg is message and
+// source is log.origin. err and error attrs become error.message, the stack attr
+// error.stack_trace, and an error group error.*. Every other attr becomes a string label, with
+// group keys joined by underscores, e.g. labels.req_method.
+var ProfileECS = Profile{
+	Record: func(r slog.Record) slog.Record {
+		n := slog.NewRecord(r.Time, r.Level, r.Message, r.PC)
+		n.AddAttrs(slog.String("ecs.version", ECSVersion))
+		r.Attrs(func(a slog.Attr) bool {
+			a.Value = a.Value.Resolve()
+			switch {
+			case (a.Key == "err" || a.Key == "error") && a.Value.Kind() != slog.KindGroup:
+				n.AddAttrs(slog.String("error.message", a.Value.String()))
+			case a.Key == StackKey:
+				n.AddAttrs(slog.String("error.stack_trace", a.Value.String()))
+			case a.Key == "error":
+				n.AddAttrs(a)
+			default:
+				n.AddAttrs(ecsLabels("", a)...)
+			}
+			return true
+		})
+
+		return n
+	},
+	ReplaceAttr: func(groups []string, a slog.Attr) slog.Attr {
+		if len(groups) > 0 {
+			return a
+		}
+
+		switch a.Key {
+		case slog.TimeKey:
+			a.Key = "@timestamp"
+		case slog.LevelKey:
+			return slog.String("log.level", strings.ToLower(a.Value.String()))
+		case slog.MessageKey:
+			a.Key = "message"
+		case slog.SourceKey:
+			if src, ok := a.Value.Any().(*slog.Source); ok {
+				return slog.Group("log.origin",
+					slog.String("function", src.Function),
+					slog.Group("file", slog.String("name", src.File), slog.Int("line", src.Line)),
+				)
+			}
+		}
+
+		return a
+	},
+}
+
+// ecsLabels returns a as labels, flattening groups with underscores
+func ecsLabels(prefix string, a slog.Attr) []slog.Attr {
+	key := a.Key
+	if prefix != "" && key != "" {
+		key = prefix + "_" + key
+	} else if key == "" {
+		key = prefix
+	}
+
+	if a.Value.Kind() != slog.KindGroup {
+		return []slog.Attr{slog.String("labels."+key, a.Value.String())}
+	}
+
+	var attrs []slog.Attr
+	for _, g := range a.Value.Group() {
+		g.Value = g.Value.Resolve()
+		attrs = append(attrs, ecsLabels(key, g)...)
+	}
+
+	return attrs
+}
+
+// ProfileGCP is GCPProfile without a project id, for trace attrs that already hold the full
+// projects/PROJECT_ID/traces/TRACE_ID name
+var ProfileGCP = GCPProfile("")
+
+// GCPProfile renders records as Google Cloud Logging structured logs, so Cloud Run and GKE show
+// native severities and correlate traces: level is severity, msg is message and source is
+// logging.googleapis.com/sourceLocation. The top-level trace, span_id and trace_sampled attrs
+// become the logging.googleapis.com/trace, spanId and trace_sampled fields. If projectID is set
+// bare trace ids are expanded to projects/projectID/traces/id. ERROR+4 and above is CRITICAL.
+func GCPProfile(projectID string) Profile {
+	return Profile{
+		ReplaceAttr: func(groups []string, a slog.Attr) slog.Attr {
+			if len(groups) > 0 {
+				return a
+			}
+
+			switch a.Key {
+			case slog.LevelKey:
+				if l, ok := a.Value.Any().(slog.Level); ok {
+					return slog.String("severity", gcpSeverity(l))
+				}
+			case slog.MessageKey:
+				a.Key = "message"
+			case slog.SourceKey:
+				if src, ok := a.Value.Any().(*slog.Source); ok {
+					return slog.Group("logging.googleapis.com/sourceLocation",
+						slog.String("file", src.File),
+						slog.String("line", strconv.Itoa(src.Line)),
+						slog.String("function", src.Function),
+					)
+				}
+			case "trace":
+				trace := a.Value.String()
+				if projectID != "" && !strings.HasPrefix(trace, "projects/") {
+					trace = "projects/" + projectID + "/traces/" + trace
+				}
+				return slog.String("logging.googleapis.com/trace", trace)
+			case "span_id":
+				a.Key = "logging.googleapis.com/spanId"
+			case "trace_sampled":
+				a.Key = "logging.googleapis.com/trace_sampled"
+			}
+
+			return a
+		},
+	}
+}
+
+// gcpSeverity returns the Cloud Logging severity of l
+func gcpSeverity(l slog.Level) string {
+	switch {
+	case l < slog.LevelInfo:
+		return "DEBUG"
+	case l < slog.LevelWarn:
+		return "INFO"
+	case l < slog.LevelError:
+		return "WARNING"
+	case l < slog.LevelError+4:
+		return "ERROR"
+	default:
+		return "CRITICAL"
+	}
+}
@@ -0,0 +1,121 @@
+package serrors
+
+import (
+	"bytes"
+	"log/slog"
+	"testing"
+)
+
+func TestSErrorsProfileECS(t *testing.T) {
+	got := bytes.NewBuffer(nil)
+	e := New(got, nil, WithProfile(ProfileECS))
+	e.Add(testTime, slog.LevelError, "save failed",
+		slog.String("err", "disk full"),
+		slog.String(StackKey, "main.main()"),
+		slog.Int("code", 28),
+		slog.Group("req", slog.String("method", "PUT"), slog.Group("", slog.Int("size", 3))),
+	)
+
+	want := `{"@timestamp":"2000-01-02T03:04:05Z","log.level":"error","message":"save failed",` +
+		`"ecs.version":"8.11.0","error.message":"disk full","error.stack_trace":"main.main()",` +
+		`"labels.code":"28","labels.req_method":"PUT","labels.req_size":"3"}`
+
+	b, err := e.MarshalJSON()
+	if err != nil || string(b) != "["+want+"]" {
+		t.Fatalf("\ngot  %s\nwant [%s]", b, want)
+	}
+
+	if err := e.Log(); err != nil || got.String() != want+"\n" {
+		t.Fatalf("\ngot  %s\nwant %s", got, want)
+	}
+
+	nd := bytes.NewBuffer(nil)
+	if err := e.WriteNDJSON(nd); err != nil || nd.String() != want+"\n" {
+		t.Fatalf("\ngot  %s\nwant %s", nd, want)
+	}
+}
+
+func TestSErrorsProfileECSSource(t *testing.T) {
+	e := New(nil, &slog.HandlerOptions{AddSource: true}, WithProfile(ProfileECS))
+	e.Add(testTime, slog.LevelError, "m", slog.Group("error", slog.String("type", "io")))
+
+	// Records added with Add have no PC, so the source is empty.
+	want := `{"@timestamp":"2000-01-02T03:04:05Z","log.level":"error",` +
+		`"log.origin":{"function":"","file":{"name":"","line":0}},"message":"m",` +
+		`"ecs.version":"8.11.0","error":{"type":"io"}}` + "\n"
+	if got := e.RtoString(e.First()); got != want {
+		t.Fatalf("\ngot  %s\nwant %s", got, want)
+	}
+}
+
+func TestSErrorsProfileGCP(t *testing.T) {
+	tests := []struct {
+		name    string
+		profile Profile
+		level   slog.Level
+		want    string
+	}{
+		{
+			name:    "bare",
+			profile: ProfileGCP,
+			level:   slog.LevelWarn,
+			want: `{"time":"2000-01-02T03:04:05Z","severity":"WARNING","message":"m",` +
+				`"logging.googleapis.com/trace":"abc","logging.googleapis.com/spanId":"01",` +
+				`"logging.googleapis.com/trace_sampled":true,"req":{"trace":"x"}}`,
+		},
+		{
+			name:    "project",
+			profile: GCPProfile("my-proj"),
+			level:   slog.LevelError + 4,
+			want: `{"time":"2000-01-02T03:04:05Z","severity":"CRITICAL","message":"m",` +
+				`"logging.googleapis.com/trace":"projects/my-proj/traces/abc","logging.googleapis.com/spanId":"01",` +
+				`"logging.googleapis.com/trace_sampled":true,"req":{"trace":"x"}}`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			e := New(nil, nil, WithProfile(test.profile))
+			e.Add(testTime, test.level, "m",
+				slog.String("trace", "abc"),
+				slog.String("span_id", "01"),
+				slog.Bool("trace_sampled", true),
+				slog.Group("req", slog.String("trace", "x")),
+			)
+
+			if got := e.RtoString(e.First()); got != test.want+"\n" {
+				t.Fatalf("\ngot  %s\nwant %s", got, test.want)
+			}
+		})
+	}
+}
+
+func TestSErrorsGCPSeverity(t *testing.T) {
+	tests := []struct {
+		level slog.Level
+		want  string
+	}{
+		{level: slog.LevelDebug, want: "DEBUG"},
+		{level: slog.LevelInfo, want: "INFO"},
+		{level: slog.LevelWarn, want: "WARNING"},
+		{level: slog.LevelError, want: "ERROR"},
+		{level: slog.LevelError + 4, want: "CRITICAL"},
+	}
+
+	for _, test := range tests {
+		if got := gcpSeverity(test.level); got != test.want {
+			t.Fatalf("\ngot  %s\nwant %s", got, test.want)
+		}
+	}
+}
+
+func TestSErrorsProfileUserReplaceAttr(t *testing.T) {
+	e := New(nil, &slog.HandlerOptions{ReplaceAttr: UpperCaseKey}, WithProfile(ProfileECS))
+	e.Add(testTime, slog.LevelError, "m", slog.String("err", "disk full"))
+
+	want := `{"@TIMESTAMP":"2000-01-02T03:04:05Z","LOG.LEVEL":"error","MESSAGE":"m",` +
+		`"ECS.VERSION":"8.11.0","ERROR.MESSAGE":"disk full"}` + "\n"
+	if got := e.RtoString(e.First()); got != want {
+		t.Fatalf("\ngot  %s\nwant %s", got, want)
+	}
+}
@@ -0,0 +1,135 @@
+package serrors
+
+import (
+	"log/slog"
+	"os"
+	"strings"
+	"time"
+)
+
+// Keys of the Provenance group
+const (
+	ProvenanceKey          = "provenance"
+	ProvenanceHostKey      = "host"
+	ProvenanceCollectorKey = "collector"
+	ReceivedAtKey          = "received_at"
+)
+
+// Provenance returns a group attr recording where a merged record came from: the host and ID of
+// the collector it was added to and when it was received. Empty values are left out.
+func Provenance(host, collector string, receivedAt time.Time) slog.Attr {
+	attrs := make([]slog.Attr, 0, 3)
+	if host != "" {
+		attrs = append(attrs, slog.String(ProvenanceHostKey, host))
+	}
+
+	if collector != "" {
+		attrs = append(attrs, slog.String(ProvenanceCollectorKey, collector))
+	}
+
+	attrs = append(attrs, slog.Time(ReceivedAtKey, receivedAt))
+	return slog.Attr{Key: ProvenanceKey, Value: slog.GroupValue(attrs...)}
+}
+
+// WithSource names the host and collector ID the records of the collection come from, recorded in
+// their Provenance when they are merged into a collection using WithProvenance. The host defaults
+// to os.Hostname.
+func WithSource(host, collector string) Option {
+	return func(e *SErrors) {
+		e.sourceHost, e.sourceID = host, collector
+	}
+}
+
+// WithProvenance makes Stack, Append and Merge add a Provenance attr naming the source of errs,
+// see WithSource, to each merged record that does not already have one, so a fleet-wide
+// collection can still be broken down by origin with BySource
+func WithProvenance() Option {
+	return func(e *SErrors) {
+		e.provenance = true
+	}
+}
+
+// source returns the host and collector ID of e
+func (e *SErrors) source() (host, collector string) {
+	e.mu.RLock()
+	host, collector = e.sourceHost, e.sourceID
+	e.mu.RUnlock()
+
+	if host == "" {
+		host, _ = os.Hostname()
+	}
+
+	return host, collector
+}
+
+// AddFrom adds rs, received from the collector with the given host and ID, with a Provenance attr
+// if they do not already have one
+func (e *SErrors) AddFrom(host, collector string, rs ...slog.Record) {
+	now := e.now()
+	for _, r := range rs {
+		e.add(withProvenance(r.Clone(), host, collector, now))
+	}
+}
+
+// withProvenance adds a Provenance attr to r unless it already has one. r must not share its
+// attrs with other records.
+func withProvenance(r slog.Record, host, collector string, at time.Time) slog.Record {
+	if _, ok := GetAttrPath(r, ProvenanceKey); !ok {
+		r.AddAttrs(Provenance(host, collector, at))
+	}
+
+	return r
+}
+
+// provenanced returns rs from errs with Provenance attrs if e uses WithProvenance
+func (e *SErrors) provenanced(rs []slog.Record, errs *SErrors) []slog.Record {
+	e.mu.RLock()
+	on := e.provenance
+	e.mu.RUnlock()
+
+	if !on {
+		return rs
+	}
+
+	host, collector := errs.source()
+	now := e.now()
+	for i, r := range rs {
+		rs[i] = withProvenance(r.Clone(), host, collector, now)
+	}
+
+	return rs
+}
+
+// BySource splits the records by their Provenance into collections that render like e, keyed by
+// "host/collector", or whichever of the two is set. Records without a Provenance are under "".
+// Spilled records are included.
+func (e *SErrors) BySource() (map[string]*SErrors, error) {
+	sources := map[string]*SErrors{}
+	err := e.eachRecord(func(r slog.Record) error {
+		key := sourceKey(r)
+		s, ok := sources[key]
+		if !ok {
+			e.mu.RLock()
+			s = e.emptyCopy()
+			e.mu.RUnlock()
+			sources[key] = s
+		}
+
+		s.store(r)
+		return nil
+	})
+
+	return sources, err
+}
+
+// sourceKey returns the BySource key of r
+func sourceKey(r slog.Record) string {
+	var parts []string
+	for _, k := range []string{ProvenanceHostKey, ProvenanceCollectorKey} {
+		if v, ok := GetAttrPath(r, ProvenanceKey+"."+k); ok && v.String() != "" {
+			parts = append(parts, v.String())
+		}
+	}
+
+	return strings.Join(parts, "/")
+}
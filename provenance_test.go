@@ -0,0 +1,44 @@
+package serrors
+
+import (
+	"log/slog"
+	"testing"
+)
+
+func TestSErrorsBySource(t *testing.T) {
+	a := NewTextHandler(nil, nil, WithSource("web1", "api"))
+	a.Error(testTime, "a")
+	b := NewTextHandler(nil, nil, WithSource("web2", ""))
+	b.Warn(testTime, "b")
+
+	e := NewTextHandler(nil, nil, WithProvenance())
+	e.Info(testTime, "local")
+	e.Append(a)
+	e.Stack(b)
+	e.AddFrom("", "batch", slog.NewRecord(testTime, slog.LevelDebug, "c", 0))
+
+	sources, err := e.BySource()
+	if err != nil {
+		t.Fatalf("\ngot  %s\nwant nil", err.Error())
+	}
+
+	want := map[string]string{"": "local", "web1/api": "a", "web2": "b", "batch": "c"}
+	if len(sources) != len(want) {
+		t.Fatalf("\ngot  %d sources\nwant %d", len(sources), len(want))
+	}
+
+	for key, msg := range want {
+		rs := sources[key].Records()
+		if len(rs) != 1 || rs[0].Message != msg {
+			t.Fatalf("\ngot  %v\nwant %s under %q", rs, msg, key)
+		}
+	}
+
+	if _, ok := GetAttrPath(a.Records()[0], ProvenanceKey); ok {
+		t.Fatal("Append changed the records of errs")
+	}
+
+	if sources["web1/api"].Level() != slog.LevelError {
+		t.Fatalf("\ngot  %s\nwant %s", sources["web1/api"].Level(), slog.LevelError)
+	}
+}
@@ -0,0 +1,104 @@
+package serrors
+
+import (
+	"log/slog"
+	"runtime"
+	"strconv"
+	"strings"
+)
+
+// CodeKey is the attr key set by WithCode. It is one of the default key attrs shown at
+// VerbosityKeys.
+const CodeKey = "code"
+
+// The per-record options below are attrs, so they can be passed to any Add-style method along
+// with the record's own attrs or args:
+//
+//	e.Error(t, "write failed", slog.String("path", p), serrors.WithStack(), serrors.WithCode("E1001"))
+
+// WithStack returns a StackKey attr holding the stack trace of the caller
+func WithStack() slog.Attr {
+	return slog.String(StackKey, stack(3))
+}
+
+// WithCode returns a CodeKey attr holding code
+func WithCode(code string) slog.Attr {
+	return slog.String(CodeKey, code)
+}
+
+// noSample marks a record that skips sampling, see NoSample
+type noSample struct{}
+
+// noSampleKey is the key of the NoSample attr. It is removed before the record is stored.
+const noSampleKey = "!serrors.nosample"
+
+// NoSample returns an attr that makes the record skip WithSampling and WithSampler, so a rare
+// record on a sampled hot path is always kept. The attr is not stored with the record.
+func NoSample() slog.Attr {
+	return slog.Any(noSampleKey, noSample{})
+}
+
+// sampleExempt returns r without the NoSample attr and whether it had one
+func sampleExempt(r slog.Record) (slog.Record, bool) {
+	found := false
+	r.Attrs(func(a slog.Attr) bool {
+		found = isNoSample(a)
+		return !found
+	})
+	if !found {
+		return r, false
+	}
+
+	n := slog.NewRecord(r.Time, r.Level, r.Message, r.PC)
+	r.Attrs(func(a slog.Attr) bool {
+		if !isNoSample(a) {
+			n.AddAttrs(a)
+		}
+		return true
+	})
+
+	return n, true
+}
+
+// isNoSample reports whether a was returned by NoSample
+func isNoSample(a slog.Attr) bool {
+	_, ok := a.Value.Any().(noSample)
+	return a.Key == noSampleKey && ok
+}
+
+// stack returns the stack trace of the calling goroutine, skipping skip frames as runtime.Callers
+// does, with a function and its file:line on each pair of lines
+func stack(skip int) string {
+	return stackFrom(skip+1, nil)
+}
+
+// stackFrom is stack, also skipping the leading frames whose function internal returns true for
+func stackFrom(skip int, internal func(function string) bool) string {
+	pcs := make([]uintptr, 32)
+	pcs = pcs[:runtime.Callers(skip, pcs)]
+
+	var b strings.Builder
+	frames := runtime.CallersFrames(pcs)
+	leading := internal != nil
+	for {
+		f, more := frames.Next()
+		if leading && internal(f.Function) && more {
+			continue
+		}
+		leading = false
+
+		if b.Len() > 0 {
+			b.WriteByte('\n')
+		}
+		b.WriteString(f.Function)
+		b.WriteString("\n\t")
+		b.WriteString(f.File)
+		b.WriteByte(':')
+		b.WriteString(strconv.Itoa(f.Line))
+		if !more {
+			break
+		}
+	}
+
+	return b.String()
+}
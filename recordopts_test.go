@@ -0,0 +1,44 @@
+package serrors
+
+import (
+	"log/slog"
+	"strings"
+	"testing"
+)
+
+func TestSErrorsRecordOptions(t *testing.T) {
+	e := NewTextHandler(nil, nil, WithSampler(func(slog.Record) bool { return false }))
+	e.Error(testTime, "m", slog.Int("a", 1), WithCode("E1001"), NoSample())
+	e.ErrorAny(testTime, "m2", "a", 2, NoSample())
+	e.Error(testTime, "dropped")
+
+	want := "time=2000-01-02T03:04:05.000Z level=ERROR msg=m a=1 code=E1001\n" +
+		"time=2000-01-02T03:04:05.000Z level=ERROR msg=m2 a=2\n"
+	if got := e.String(); got != want {
+		t.Fatalf("\ngot  %s\nwant %s", got, want)
+	}
+
+	if e.SampledOut() != 1 {
+		t.Fatalf("\ngot  %d\nwant 1", e.SampledOut())
+	}
+}
+
+func TestWithStack(t *testing.T) {
+	e := New(nil, nil)
+	e.Error(testTime, "m", WithStack())
+
+	v, ok := GetAttrPath(e.First(), StackKey)
+	if !ok {
+		t.Fatal("missing stack attr")
+	}
+
+	got := v.String()
+	want := "github.com/chadeldridge/serrors.TestWithStack\n\t"
+	if !strings.HasPrefix(got, want) {
+		t.Fatalf("\ngot  %s\nwant %s...", got, want)
+	}
+
+	if !strings.Contains(got, "recordopts_test.go:") {
+		t.Fatalf("\ngot  %s\nwant recordopts_test.go", got)
+	}
+}
@@ -0,0 +1,64 @@
+package serrors
+
+import "log/slog"
+
+// Redacted replaces the values of attrs redacted with WithRedactKeys
+const Redacted = "[REDACTED]"
+
+// WithRedactKeys replaces the value of every attr with one of keys, at any depth within groups,
+// with Redacted as records are added, so secrets never reach memory, the WAL or the output. Records
+// added by Stack, Append and Recover are not redacted.
+func WithRedactKeys(keys ...string) Option {
+	return func(e *SErrors) {
+		e.redactKeys = redactSet(keys)
+	}
+}
+
+// redactSet returns keys as a set, or nil if there are none
+func redactSet(keys []string) map[string]struct{} {
+	if len(keys) == 0 {
+		return nil
+	}
+
+	set := make(map[string]struct{}, len(keys))
+	for _, k := range keys {
+		set[k] = struct{}{}
+	}
+
+	return set
+}
+
+// redact returns r with the values of attrs in keys replaced by Redacted
+func redact(r slog.Record, keys map[string]struct{}) slog.Record {
+	if len(keys) == 0 {
+		return r
+	}
+
+	n := slog.NewRecord(r.Time, r.Level, r.Message, r.PC)
+	r.Attrs(func(a slog.Attr) bool {
+		n.AddAttrs(redactAttr(a, keys))
+		return true
+	})
+
+	return n
+}
+
+// redactAttr returns a with its value, or the values of the attrs in its group, redacted
+func redactAttr(a slog.Attr, keys map[string]struct{}) slog.Attr {
+	if _, ok := keys[a.Key]; ok {
+		return slog.String(a.Key, Redacted)
+	}
+
+	a.Value = a.Value.Resolve()
+	if a.Value.Kind() != slog.KindGroup {
+		return a
+	}
+
+	g := a.Value.Group()
+	attrs := make([]slog.Attr, len(g))
+	for i, ga := range g {
+		attrs[i] = redactAttr(ga, keys)
+	}
+
+	return slog.Attr{Key: a.Key, Value: slog.GroupValue(attrs...)}
+}
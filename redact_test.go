@@ -0,0 +1,18 @@
+package serrors
+
+import (
+	"log/slog"
+	"testing"
+)
+
+func TestSErrorsWithRedactKeys(t *testing.T) {
+	e := NewTextHandler(nil, nil, WithRedactKeys("password", "token"))
+	e.Error(testTime, "m", slog.String("user", "u"), slog.String("password", "p"),
+		slog.Group("auth", slog.String("token", "t"), slog.Int("ttl", 1)))
+
+	want := "time=2000-01-02T03:04:05.000Z level=ERROR msg=m user=u password=[REDACTED] " +
+		"auth.token=[REDACTED] auth.ttl=1\n"
+	if got := e.String(); got != want {
+		t.Fatalf("\ngot  %s\nwant %s", got, want)
+	}
+}
@@ -0,0 +1,29 @@
+package serrors
+
+import (
+	"log/slog"
+	"math"
+)
+
+// LevelDiscard is returned by a WithLevelRemap function to drop a record
+const LevelDiscard slog.Level = math.MinInt
+
+// WithLevelRemap calls remap for each record added and stores the record at the level it returns,
+// or drops it if that is LevelDiscard. Use it to downgrade known-noisy messages, such as a
+// dependency's spurious errors, while everything else keeps its level. remap must be safe to call
+// from multiple goroutines. Records added by Stack, Append and Recover are not remapped.
+func WithLevelRemap(remap func(r slog.Record) slog.Level) Option {
+	return func(e *SErrors) {
+		e.remap = remap
+	}
+}
+
+// remapped returns r at the level chosen by e.remap and false if it is to be discarded
+func (e *SErrors) remapped(r slog.Record) (slog.Record, bool) {
+	if e.remap == nil {
+		return r, true
+	}
+
+	r.Level = e.remap(r)
+	return r, r.Level != LevelDiscard
+}
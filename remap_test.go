@@ -0,0 +1,32 @@
+package serrors
+
+import (
+	"log/slog"
+	"testing"
+)
+
+func TestSErrorsLevelRemap(t *testing.T) {
+	e := NewTextHandler(nil, nil, WithLevelRemap(func(r slog.Record) slog.Level {
+		switch r.Message {
+		case "spurious":
+			return slog.LevelDebug
+		case "noise":
+			return LevelDiscard
+		default:
+			return r.Level
+		}
+	}))
+	e.Add(testTime, slog.LevelError, "spurious")
+	e.Add(testTime, slog.LevelError, "noise")
+	e.Add(testTime, slog.LevelWarn, "real")
+
+	want := "time=2000-01-02T03:04:05.000Z level=DEBUG msg=spurious\n" +
+		"time=2000-01-02T03:04:05.000Z level=WARN msg=real\n"
+	if got := e.String(); got != want {
+		t.Fatalf("\ngot  %s\nwant %s", got, want)
+	}
+
+	if e.Level() != slog.LevelWarn {
+		t.Fatalf("\ngot  %s\nwant %s", e.Level(), slog.LevelWarn)
+	}
+}
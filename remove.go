@@ -0,0 +1,52 @@
+package serrors
+
+import "log/slog"
+
+// Remove deletes the record at index i and recomputes the level. It panics
+// if i is out of range.
+func (e *SErrors) Remove(i int) {
+	defer e.syncWAL()
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	e.deleteRecord(i)
+	e.recomputeLevel()
+	e.invalidateAt(i)
+}
+
+// RemoveIf deletes every record for which pred returns true, recomputes
+// the level and returns the number of records removed. Use it to prune transient errors that
+// were later resolved before the final report.
+func (e *SErrors) RemoveIf(pred func(slog.Record) bool) int {
+	defer e.syncWAL()
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	n := e.deleteRecordsFunc(pred)
+	e.recomputeLevel()
+	e.invalidate()
+
+	return n
+}
+
+// recomputeLevel sets the level to the highest level of the records left, or the zero Level if
+// there are none. Spilled records count and resolved records do not. e.mu must be held.
+func (e *SErrors) recomputeLevel() {
+	var l slog.Level
+	first := true
+	if e.spill != nil && e.spill.count > 0 {
+		l, first = e.spill.level, false
+	}
+
+	for i, r := range e.records {
+		if e.isResolved(i) {
+			continue
+		}
+
+		if first || r.Level > l {
+			l, first = r.Level, false
+		}
+	}
+
+	e.level = l
+}
@@ -0,0 +1,71 @@
+package serrors
+
+import (
+	"log/slog"
+	"testing"
+)
+
+func TestSErrorsRemove(t *testing.T) {
+	e := NewTextHandler(nil, nil)
+	e.Debug(testTime, "a")
+	e.Error(testTime, "b")
+	e.Warn(testTime, "c")
+
+	e.Remove(1)
+	want := "time=2000-01-02T03:04:05.000Z level=DEBUG msg=a\ntime=2000-01-02T03:04:05.000Z level=WARN msg=c\n"
+	if got := e.String(); got != want {
+		t.Fatalf("\ngot  %s\nwant %s", got, want)
+	}
+
+	if e.Level() != slog.LevelWarn {
+		t.Fatalf("\ngot  %s\nwant %s", e.Level(), slog.LevelWarn)
+	}
+
+	e.Remove(1)
+	if e.Level() != slog.LevelDebug {
+		t.Fatalf("\ngot  %s\nwant %s", e.Level(), slog.LevelDebug)
+	}
+
+	e.Remove(0)
+	if !e.IsEmpty() || e.Level() != 0 {
+		t.Fatalf("\ngot  %d %s\nwant empty INFO", len(e.records), e.Level())
+	}
+}
+
+func TestSErrorsRemoveIf(t *testing.T) {
+	e := NewTextHandler(nil, nil)
+	e.Warn(testTime, "a", slog.Bool("transient", true))
+	e.Error(testTime, "b", slog.Bool("transient", true))
+	e.Warn(testTime, "c")
+
+	n := e.RemoveIf(func(r slog.Record) bool {
+		transient := false
+		r.Attrs(func(a slog.Attr) bool {
+			transient = transient || a.Key == "transient"
+			return true
+		})
+		return transient
+	})
+
+	if n != 2 || len(e.records) != 1 || e.records[0].Message != "c" {
+		t.Fatalf("\ngot  %d %d\nwant 2 removed, c left", n, len(e.records))
+	}
+
+	if e.Level() != slog.LevelWarn {
+		t.Fatalf("\ngot  %s\nwant %s", e.Level(), slog.LevelWarn)
+	}
+}
+
+func TestSErrorsRemoveIfSpilled(t *testing.T) {
+	e := New(nil, nil, WithSpillDir(t.TempDir(), 2))
+	defer e.RemoveSpill()
+	e.Error(testTime, "a")
+	e.Info(testTime, "b")
+	e.Info(testTime, "c")
+	e.Warn(testTime, "d")
+
+	e.RemoveIf(func(r slog.Record) bool { return r.Level == slog.LevelWarn })
+	if e.Level() != slog.LevelError {
+		t.Fatalf("\ngot  %s\nwant %s", e.Level(), slog.LevelError)
+	}
+}
@@ -0,0 +1,67 @@
+package serrors
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+)
+
+// Reset empties the collection, including spilled records and the WAL, and resets Level so a
+// long-lived collector can be reused across batches. The memory holding the records is kept for
+// the next batch. Meta fields, Dropped and the options are not changed. An error removing the
+// spilled records is returned by the next Log, and an error pruning the WAL by CloseWAL.
+func (e *SErrors) Reset() {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	e.reset()
+}
+
+// LogAndClear logs the records like Log and empties the collection like Reset. The records are
+// taken out in one step, so records added while they are logged are kept for the next call
+// instead of being logged twice or lost. Spilled records are read back into memory to be logged;
+// if that fails nothing is logged or removed.
+func (e *SErrors) LogAndClear() error {
+	e.expire()
+	e.mu.Lock()
+
+	var rs []slog.Record
+	if e.spill != nil && e.spill.store != nil && e.spill.count > 0 {
+		err := e.spill.store.LoadRange(0, e.spill.count, func(r slog.Record) error {
+			rs = append(rs, r)
+			return nil
+		})
+		if err != nil {
+			e.mu.Unlock()
+			return err
+		}
+	}
+
+	rs = append(rs, e.records...)
+	e.reset()
+	e.mu.Unlock()
+
+	var errs []error
+	for _, r := range rs {
+		errs = append(errs, e.logRecord(context.Background(), r))
+	}
+
+	return errors.Join(errs...)
+}
+
+// reset empties e. e.mu must be held for writing.
+func (e *SErrors) reset() {
+	clear(e.records)
+	e.records = e.records[:0]
+	e.seqs = e.seqs[:0]
+	e.shards.list = nil
+	clear(e.resolved)
+	e.level = 0
+	e.invalidate()
+	e.pruneJournal()
+
+	if e.spill != nil {
+		e.spill.err = e.removeSpill()
+		e.spill.level = 0
+	}
+}
@@ -0,0 +1,68 @@
+package serrors
+
+import (
+	"bytes"
+	"os"
+	"testing"
+)
+
+func TestSErrorsReset(t *testing.T) {
+	dir := t.TempDir()
+	got := bytes.NewBuffer(nil)
+	e := NewTextHandler(got, nil, WithSpillDir(dir, 2))
+	for range 5 {
+		e.Error(testTime, "m")
+	}
+
+	e.Reset()
+	if n, s := len(e.Records()), e.Spilled(); n != 0 || s != 0 || e.Level() != 0 {
+		t.Fatalf("\ngot  %d in memory, %d spilled, %s\nwant 0, 0, INFO", n, s, e.Level())
+	}
+
+	if files, _ := os.ReadDir(dir); len(files) != 0 {
+		t.Fatalf("\ngot  %d spill files\nwant 0", len(files))
+	}
+
+	e.Warn(testTime, "w")
+	if err := e.Log(); err != nil {
+		t.Fatal(err)
+	}
+
+	if want := "time=2000-01-02T03:04:05.000Z level=WARN msg=w\n"; got.String() != want {
+		t.Fatalf("\ngot  %s\nwant %s", got, want)
+	}
+}
+
+func TestSErrorsLogAndClear(t *testing.T) {
+	got := bytes.NewBuffer(nil)
+	e := NewTextHandler(got, nil, WithArena(2))
+	for _, msg := range []string{"a", "b", "c", "d"} {
+		e.Error(testTime, msg)
+	}
+
+	if err := e.LogAndClear(); err != nil {
+		t.Fatal(err)
+	}
+
+	want := "time=2000-01-02T03:04:05.000Z level=ERROR msg=a\n" +
+		"time=2000-01-02T03:04:05.000Z level=ERROR msg=b\n" +
+		"time=2000-01-02T03:04:05.000Z level=ERROR msg=c\n" +
+		"time=2000-01-02T03:04:05.000Z level=ERROR msg=d\n"
+	if got.String() != want {
+		t.Fatalf("\ngot  %s\nwant %s", got, want)
+	}
+
+	if n, s := len(e.Records()), e.Spilled(); n != 0 || s != 0 || e.Level() != 0 {
+		t.Fatalf("\ngot  %d in memory, %d spilled, %s\nwant 0, 0, INFO", n, s, e.Level())
+	}
+
+	got.Reset()
+	e.Info(testTime, "e")
+	if err := e.LogAndClear(); err != nil {
+		t.Fatal(err)
+	}
+
+	if want := "time=2000-01-02T03:04:05.000Z level=INFO msg=e\n"; got.String() != want {
+		t.Fatalf("\ngot  %s\nwant %s", got, want)
+	}
+}
@@ -0,0 +1,60 @@
+package serrors
+
+import "log/slog"
+
+// ResolvedKey is the attr key Resolve adds to the records it marks, so reports show them as
+// resolved. It is only a label: a record added with this attr is not resolved.
+const ResolvedKey = "resolved"
+
+// Resolve marks every record for which pred returns true as resolved, adding resolved=true, and
+// returns the number of records newly marked. Resolved records stay in the collection but no
+// longer count towards Level, so triaged errors stop affecting health reports.
+func (e *SErrors) Resolve(pred func(slog.Record) bool) int {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	n := 0
+	for i, r := range e.records {
+		if !e.isResolved(i) && pred(r) {
+			if e.resolved == nil {
+				e.resolved = map[uint64]struct{}{}
+			}
+			e.resolved[e.seqs[i]] = struct{}{}
+			e.records[i] = annotated(r, []slog.Attr{slog.Bool(ResolvedKey, true)})
+			n++
+		}
+	}
+
+	if n > 0 {
+		e.recomputeLevel()
+		e.invalidate()
+	}
+
+	return n
+}
+
+// Unresolved returns a new SErrors holding the records not marked by Resolve, rendered like e
+func (e *SErrors) Unresolved() *SErrors {
+	return e.filter(func(i int, _ slog.Record) bool { return !e.isResolved(i) })
+}
+
+// markResolved marks the records with sequence numbers seqs as resolved where resolved is true,
+// carrying the state of records taken from another collection. e.mu must be held for writing.
+func (e *SErrors) markResolved(seqs []uint64, resolved []bool) {
+	for i, ok := range resolved {
+		if !ok {
+			continue
+		}
+
+		if e.resolved == nil {
+			e.resolved = map[uint64]struct{}{}
+		}
+		e.resolved[seqs[i]] = struct{}{}
+	}
+}
+
+// isResolved reports whether the record at index i has been marked by Resolve. e.mu must be held.
+func (e *SErrors) isResolved(i int) bool {
+	_, ok := e.resolved[e.seqs[i]]
+	return ok
+}
@@ -0,0 +1,81 @@
+package serrors
+
+import (
+	"log/slog"
+	"testing"
+)
+
+func TestSErrorsResolve(t *testing.T) {
+	e := NewTextHandler(nil, nil)
+	e.Add(testTime, slog.LevelWarn, "slow")
+	e.Add(testTime, slog.LevelError, "db down")
+	e.Add(testTime, slog.LevelError, "db down")
+
+	isDB := func(r slog.Record) bool { return r.Message == "db down" }
+	if n := e.Resolve(isDB); n != 2 {
+		t.Fatalf("\ngot  %d\nwant 2", n)
+	}
+
+	if n := e.Resolve(isDB); n != 0 {
+		t.Fatalf("\ngot  %d\nwant 0", n)
+	}
+
+	if e.Level() != slog.LevelWarn {
+		t.Fatalf("\ngot  %s\nwant %s", e.Level(), slog.LevelWarn)
+	}
+
+	want := "time=2000-01-02T03:04:05.000Z level=ERROR msg=\"db down\" resolved=true\n"
+	if got := e.RtoString(e.Last()); got != want {
+		t.Fatalf("\ngot  %s\nwant %s", got, want)
+	}
+
+	u := e.Unresolved()
+	want = "time=2000-01-02T03:04:05.000Z level=WARN msg=slow\n"
+	if got := u.String(); got != want {
+		t.Fatalf("\ngot  %s\nwant %s", got, want)
+	}
+
+	if len(e.Records()) != 3 || u.Level() != slog.LevelWarn {
+		t.Fatalf("\ngot  %d %s\nwant 3 WARN", len(e.Records()), u.Level())
+	}
+
+	// The resolved attr is only a label: a record added with it counts towards Level.
+	e.Add(testTime, slog.LevelError, "new", slog.Bool(ResolvedKey, true))
+	if e.Level() != slog.LevelError || e.Unresolved().Count() != 2 {
+		t.Fatalf("\ngot  %s %d\nwant ERROR 2", e.Level(), e.Unresolved().Count())
+	}
+}
+
+func TestSErrorsResolveCarriedOver(t *testing.T) {
+	src := NewTextHandler(nil, nil)
+	src.Add(testTime, slog.LevelWarn, "slow")
+	src.Add(testTime, slog.LevelError, "db down")
+	src.Resolve(func(r slog.Record) bool { return r.Message == "db down" })
+
+	tests := []struct {
+		name string
+		add  func(e, src *SErrors)
+	}{
+		{"stack", (*SErrors).Stack},
+		{"append", (*SErrors).Append},
+		{"merge", (*SErrors).Merge},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			e := NewTextHandler(nil, nil)
+			e.Add(testTime, slog.LevelInfo, "start")
+			test.add(e, src)
+
+			if e.Level() != slog.LevelWarn || e.Unresolved().Count() != 2 {
+				t.Fatalf("\ngot  %s %d\nwant WARN 2", e.Level(), e.Unresolved().Count())
+			}
+
+			// Removing a record recomputes the level without the resolved one.
+			e.RemoveIf(func(r slog.Record) bool { return r.Message == "slow" })
+			if e.Level() != slog.LevelInfo {
+				t.Fatalf("\ngot  %s\nwant %s", e.Level(), slog.LevelInfo)
+			}
+		})
+	}
+}
@@ -0,0 +1,35 @@
+package serrors
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+)
+
+// route is a destination added with WithRoute
+type route struct {
+	pred func(slog.Record) bool
+	h    slog.Handler
+}
+
+// WithRoute makes Log also send the records for which pred returns true to h, e.g. records with
+// audit=true to an audit sink. Every record still goes to the logger handler, and a record may
+// match several routes. Records are passed to h as they were added, without the text rendering
+// options, and only if h is enabled for their level.
+func WithRoute(pred func(r slog.Record) bool, h slog.Handler) Option {
+	return func(e *SErrors) {
+		e.routes = append(e.routes, route{pred: pred, h: h})
+	}
+}
+
+// route sends r to the handler of each matching route
+func (e *SErrors) route(ctx context.Context, r slog.Record) error {
+	var errs []error
+	for _, rt := range e.routes {
+		if rt.h.Enabled(ctx, r.Level) && rt.pred(r) {
+			errs = append(errs, rt.h.Handle(ctx, r))
+		}
+	}
+
+	return errors.Join(errs...)
+}
@@ -0,0 +1,69 @@
+package serrors
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"log/slog"
+	"testing"
+)
+
+// failHandler is a slog.Handler whose Handle always fails
+type failHandler struct{ slog.Handler }
+
+func (failHandler) Handle(context.Context, slog.Record) error { return errors.New("sink down") }
+
+func TestSErrorsRoute(t *testing.T) {
+	def, audit, siem := bytes.NewBuffer(nil), bytes.NewBuffer(nil), bytes.NewBuffer(nil)
+	hasAttr := func(key string) func(slog.Record) bool {
+		return func(r slog.Record) bool {
+			_, ok := GetAttrPath(r, key)
+			return ok
+		}
+	}
+
+	e := NewTextHandler(def, nil,
+		WithRoute(hasAttr("audit"), slog.NewJSONHandler(audit, nil)),
+		WithRoute(hasAttr("security"), slog.NewTextHandler(siem, &slog.HandlerOptions{Level: slog.LevelWarn})),
+	)
+	e.Add(testTime, slog.LevelInfo, "login", slog.Bool("audit", true), slog.Bool("security", true))
+	e.Add(testTime, slog.LevelWarn, "brute force", slog.Bool("security", true))
+	e.Add(testTime, slog.LevelError, "crash")
+
+	if err := e.Log(); err != nil {
+		t.Fatalf("\ngot  %s\nwant nil", err)
+	}
+
+	want := "time=2000-01-02T03:04:05.000Z level=INFO msg=login audit=true security=true\n" +
+		"time=2000-01-02T03:04:05.000Z level=WARN msg=\"brute force\" security=true\n" +
+		"time=2000-01-02T03:04:05.000Z level=ERROR msg=crash\n"
+	if def.String() != want {
+		t.Fatalf("\ngot  %s\nwant %s", def, want)
+	}
+
+	want = `{"time":"2000-01-02T03:04:05Z","level":"INFO","msg":"login","audit":true,"security":true}` + "\n"
+	if audit.String() != want {
+		t.Fatalf("\ngot  %s\nwant %s", audit, want)
+	}
+
+	// The SIEM handler is only enabled from WARN.
+	want = "time=2000-01-02T03:04:05.000Z level=WARN msg=\"brute force\" security=true\n"
+	if siem.String() != want {
+		t.Fatalf("\ngot  %s\nwant %s", siem, want)
+	}
+}
+
+func TestSErrorsRouteError(t *testing.T) {
+	def := bytes.NewBuffer(nil)
+	all := func(slog.Record) bool { return true }
+	e := NewTextHandler(def, nil, WithRoute(all, failHandler{slog.NewTextHandler(nil, nil)}))
+	e.Add(testTime, slog.LevelError, "m")
+
+	if err := e.Log(); err == nil {
+		t.Fatalf("\ngot  nil\nwant error")
+	}
+
+	if def.Len() == 0 {
+		t.Fatalf("\ngot  nothing\nwant the record logged")
+	}
+}
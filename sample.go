@@ -0,0 +1,67 @@
+package serrors
+
+import (
+	"log/slog"
+	"math/rand"
+	"sync"
+)
+
+// WithSampling keeps each record added with probability rate, between 0 and 1, so hot paths do not
+// flood the collection. Use WithSampler with RandomSampler or a custom decision function when
+// tests need stable output.
+func WithSampling(rate float64) Option {
+	return WithSampler(rateSampler(rate))
+}
+
+// rateSampler returns a decision function keeping records with probability rate
+func rateSampler(rate float64) func(slog.Record) bool {
+	return func(slog.Record) bool { return rand.Float64() < rate }
+}
+
+// WithSampler calls keep for each record added and drops the record if it returns false. keep must
+// be safe to call from multiple goroutines. Records added by Stack, Append and Recover or with a
+// NoSample attr are not sampled.
+func WithSampler(keep func(r slog.Record) bool) Option {
+	return func(e *SErrors) {
+		e.sampler = keep
+	}
+}
+
+// RandomSampler returns a decision function for WithSampler that keeps records with probability
+// rate, using a random source seeded with seed. The same seed gives the same decisions for the
+// same sequence of records.
+func RandomSampler(rate float64, seed int64) func(r slog.Record) bool {
+	var mu sync.Mutex
+	rnd := rand.New(rand.NewSource(seed))
+	return func(slog.Record) bool {
+		mu.Lock()
+		defer mu.Unlock()
+
+		return rnd.Float64() < rate
+	}
+}
+
+// SampledOut returns the number of records dropped by sampling
+func (e *SErrors) SampledOut() int {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+
+	return e.sampledOut
+}
+
+// sample reports whether r should be kept
+func (e *SErrors) sample(r slog.Record) bool {
+	e.mu.RLock()
+	keep := e.sampler
+	e.mu.RUnlock()
+
+	if keep == nil || keep(r) {
+		return true
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	e.sampledOut++
+	return false
+}
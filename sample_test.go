@@ -0,0 +1,42 @@
+package serrors
+
+import (
+	"log/slog"
+	"testing"
+)
+
+func TestSErrorsSampler(t *testing.T) {
+	e := NewTextHandler(nil, nil, WithSampler(func(r slog.Record) bool { return r.Level >= slog.LevelWarn }))
+	e.Add(testTime, slog.LevelInfo, "a")
+	e.Add(testTime, slog.LevelWarn, "b")
+	e.Add(testTime, slog.LevelDebug, "c")
+
+	want := "time=2000-01-02T03:04:05.000Z level=WARN msg=b\n"
+	if got := e.String(); got != want {
+		t.Fatalf("\ngot  %s\nwant %s", got, want)
+	}
+
+	if e.SampledOut() != 2 {
+		t.Fatalf("\ngot  %d\nwant 2", e.SampledOut())
+	}
+}
+
+func TestSErrorsRandomSampler(t *testing.T) {
+	run := func(seed int64) string {
+		e := NewTextHandler(nil, nil, WithSampler(RandomSampler(0.5, seed)))
+		for i := 0; i < 50; i++ {
+			e.Add(testTime, slog.LevelInfo, "m", slog.Int("i", i))
+		}
+
+		return e.String()
+	}
+
+	first := run(42)
+	if got := run(42); got != first {
+		t.Fatalf("\ngot  %s\nwant %s", got, first)
+	}
+
+	if first == "" || first == run(7) {
+		t.Fatalf("\ngot  %s\nwant a seeded sample", first)
+	}
+}
@@ -0,0 +1,95 @@
+package serrors
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"log/slog"
+)
+
+// Scanner reads slog output back into records one line at a time, so logs of any size can be
+// ingested and analyzed without loading them whole. Lines starting with '{' are parsed like
+// ParseJSON and other lines like ParseText, so JSON and text output may be mixed. Blank lines are
+// skipped.
+type Scanner struct {
+	s    *bufio.Scanner
+	line int
+	r    slog.Record
+	err  error
+}
+
+// NewScanner returns a Scanner reading from r
+func NewScanner(r io.Reader) *Scanner {
+	s := bufio.NewScanner(r)
+	s.Buffer(nil, maxLine)
+	return &Scanner{s: s}
+}
+
+// Scan advances to the next record, which is returned by Record. It returns false at the end of
+// the input or on the first error, which is returned by Err.
+func (s *Scanner) Scan() bool {
+	for s.err == nil && s.s.Scan() {
+		s.line++
+		line := bytes.TrimSpace(s.s.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+
+		var err error
+		if line[0] == '{' {
+			s.r, err = parseJSONRecord(line)
+		} else {
+			s.r, err = parseTextRecord(string(line))
+		}
+
+		if err != nil {
+			s.err = fmt.Errorf("serrors: line %d: %w", s.line, err)
+			return false
+		}
+
+		return true
+	}
+
+	return false
+}
+
+// Record returns the record read by the last call to Scan
+func (s *Scanner) Record() slog.Record {
+	return s.r
+}
+
+// Err returns the first error reading or parsing the input
+func (s *Scanner) Err() error {
+	if s.err != nil {
+		return s.err
+	}
+
+	return s.s.Err()
+}
+
+// ReadFrom adds the records read from r with a Scanner until the end of r or a line that cannot be
+// parsed, and returns the number of bytes read. Records go through Add like AddRecord. It
+// implements io.ReaderFrom.
+func (e *SErrors) ReadFrom(r io.Reader) (int64, error) {
+	cr := &countReader{r: r}
+	s := NewScanner(cr)
+	for s.Scan() {
+		e.add(s.Record())
+	}
+
+	return cr.n, s.Err()
+}
+
+// countReader counts the bytes read from r
+type countReader struct {
+	r io.Reader
+	n int64
+}
+
+// Read reads from r into p
+func (c *countReader) Read(p []byte) (int, error) {
+	n, err := c.r.Read(p)
+	c.n += int64(n)
+	return n, err
+}
@@ -0,0 +1,59 @@
+package serrors
+
+import (
+	"log/slog"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestScanner(t *testing.T) {
+	in := `{"time":"2000-01-02T03:04:05Z","level":"ERROR","msg":"a","g":{"i":1}}` + "\n" +
+		"\n" +
+		"time=2000-01-02T03:04:05.000Z level=WARN msg=b g.i=2\n"
+
+	s := NewScanner(strings.NewReader(in))
+	var got []string
+	for s.Scan() {
+		r := s.Record()
+		v, _ := GetAttrPath(r, "g.i")
+		got = append(got, r.Level.String()+" "+r.Message+" "+v.String())
+	}
+
+	if err := s.Err(); err != nil {
+		t.Fatal(err)
+	}
+
+	if want := []string{"ERROR a 1", "WARN b 2"}; !slices.Equal(got, want) {
+		t.Fatalf("\ngot  %v\nwant %v", got, want)
+	}
+}
+
+func TestScannerError(t *testing.T) {
+	s := NewScanner(strings.NewReader("msg=a\n{\"msg\":\n"))
+	n := 0
+	for s.Scan() {
+		n++
+	}
+
+	if err := s.Err(); n != 1 || err == nil || !strings.HasPrefix(err.Error(), "serrors: line 2:") {
+		t.Fatalf("\ngot  %d records, %v\nwant 1, line 2 error", n, err)
+	}
+}
+
+func TestSErrorsReadFrom(t *testing.T) {
+	in := "time=2000-01-02T03:04:05.000Z level=INFO msg=a\n" +
+		"time=2000-01-02T03:04:05.000Z level=ERROR msg=b password=p\n"
+
+	e := NewTextHandler(nil, nil, WithRedactKeys("password"))
+	n, err := e.ReadFrom(strings.NewReader(in))
+	if err != nil || n != int64(len(in)) {
+		t.Fatalf("\ngot  %d, %v\nwant %d, nil", n, err, len(in))
+	}
+
+	want := "time=2000-01-02T03:04:05.000Z level=INFO msg=a\n" +
+		"time=2000-01-02T03:04:05.000Z level=ERROR msg=b password=[REDACTED]\n"
+	if got := e.String(); got != want || e.Level() != slog.LevelError {
+		t.Fatalf("\ngot  %s %s\nwant %s", got, e.Level(), want)
+	}
+}
@@ -0,0 +1,128 @@
+package serrors
+
+import (
+	"log/slog"
+	"time"
+)
+
+// WithDefaultAttrs adds attrs, such as a service name, to every record added to the collection,
+// before the attrs of a Scope
+func WithDefaultAttrs(attrs ...slog.Attr) Option {
+	return func(e *SErrors) {
+		e.scope = e.scope.withAttrs(attrs)
+	}
+}
+
+// Scope adds records to an SErrors with attrs and groups of its own, as slog.Logger.With does.
+// It is a lightweight view: records go into the SErrors it came from, which is read as usual,
+// while the attrs only apply to records added through the Scope. A Scope is safe to use from
+// multiple goroutines, so each request can have its own without tagging the others' records.
+type Scope struct {
+	e     *SErrors
+	scope attrScope
+}
+
+// WithAttrs returns a Scope adding attrs, such as a request or user ID, to every record added
+// through it, before the record's own attrs. e is not changed; use WithDefaultAttrs for attrs every
+// record should carry.
+func (e *SErrors) WithAttrs(attrs ...slog.Attr) *Scope {
+	return &Scope{e: e, scope: attrScope{}.withAttrs(attrs)}
+}
+
+// WithGroup returns a Scope nesting the attrs of every record added through it, and the attrs of
+// later WithAttrs calls, in a group called name, as slog.Logger.WithGroup does, so subsystems
+// sharing a collector keep their attrs apart. An empty name adds no group.
+func (e *SErrors) WithGroup(name string) *Scope {
+	return &Scope{e: e, scope: attrScope{}.withGroup(name)}
+}
+
+// WithAttrs returns a Scope adding attrs after the attrs of s, inside its groups
+func (s *Scope) WithAttrs(attrs ...slog.Attr) *Scope {
+	return &Scope{e: s.e, scope: s.scope.withAttrs(attrs)}
+}
+
+// WithGroup returns a Scope nesting the attrs added after it in a group called name, inside the
+// groups of s
+func (s *Scope) WithGroup(name string) *Scope {
+	return &Scope{e: s.e, scope: s.scope.withGroup(name)}
+}
+
+// SErrors returns the collection s adds records to
+func (s *Scope) SErrors() *SErrors {
+	return s.e
+}
+
+// Handler returns a slog.Handler adding records to the collection with the attrs and groups of s,
+// see SErrors.Handler
+func (s *Scope) Handler() slog.Handler {
+	return &collectHandler{e: s.e, scope: s.scope}
+}
+
+// Add creates a new slog.Record from slog.Attr(s) and adds it with the attrs of s
+func (s *Scope) Add(t time.Time, l slog.Level, msg string, attrs ...slog.Attr) {
+	s.addAttrs(s.e.pc(), t, l, msg, attrs)
+}
+
+// AddAny creates a new slog.Record from key-value pairs and adds it with the attrs of s
+func (s *Scope) AddAny(t time.Time, l slog.Level, msg string, args ...any) {
+	s.add(s.e.argsRecord(s.e.pc(), t, l, msg, args))
+}
+
+// AddNow is Add timestamped with the current time
+func (s *Scope) AddNow(l slog.Level, msg string, attrs ...slog.Attr) {
+	s.addAttrs(s.e.pc(), s.e.now(), l, msg, attrs)
+}
+
+// AddError adds err with the attrs of s, see SErrors.AddError
+func (s *Scope) AddError(t time.Time, err error, attrs ...slog.Attr) {
+	if err == nil {
+		return
+	}
+
+	s.add(errorRecord(s.e.pc(), t, err, attrs))
+}
+
+// Debug adds a new Debug Level slog.Record with the attrs of s
+func (s *Scope) Debug(t time.Time, msg string, attrs ...slog.Attr) {
+	s.addAttrs(s.e.pc(), t, slog.LevelDebug, msg, attrs)
+}
+
+// Info adds a new Info Level slog.Record with the attrs of s
+func (s *Scope) Info(t time.Time, msg string, attrs ...slog.Attr) {
+	s.addAttrs(s.e.pc(), t, slog.LevelInfo, msg, attrs)
+}
+
+// Warn adds a new Warn Level slog.Record with the attrs of s
+func (s *Scope) Warn(t time.Time, msg string, attrs ...slog.Attr) {
+	s.addAttrs(s.e.pc(), t, slog.LevelWarn, msg, attrs)
+}
+
+// Error adds a new Error Level slog.Record with the attrs of s
+func (s *Scope) Error(t time.Time, msg string, attrs ...slog.Attr) {
+	s.addAttrs(s.e.pc(), t, slog.LevelError, msg, attrs)
+}
+
+// addAttrs adds a record with the source pc made from attrs
+func (s *Scope) addAttrs(pc uintptr, t time.Time, l slog.Level, msg string, attrs []slog.Attr) {
+	r := slog.NewRecord(t, l, msg, pc)
+	r.AddAttrs(attrs...)
+	s.add(r)
+}
+
+// add adds r to the collection with the attrs of s
+func (s *Scope) add(r slog.Record) {
+	s.e.add(s.scope.record(r))
+}
+
+// scoped returns r with the attrs of e's scope
+func (e *SErrors) scoped(r slog.Record) slog.Record {
+	e.mu.RLock()
+	s := e.scope
+	e.mu.RUnlock()
+
+	if s.isEmpty() {
+		return r
+	}
+
+	return s.record(r)
+}
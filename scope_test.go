@@ -0,0 +1,103 @@
+package serrors
+
+import (
+	"log/slog"
+	"sync"
+	"testing"
+)
+
+func TestSErrorsWithAttrs(t *testing.T) {
+	e := NewTextHandler(nil, nil, WithDefaultAttrs(slog.String("service", "api")))
+	e.Info(testTime, "a")
+	s := e.WithAttrs(slog.String("req", "r1")).WithAttrs(slog.Int("user", 7))
+	s.Error(testTime, "b", slog.Int("i", 1))
+	e.WithAttrs(slog.String("req", "r2")).AddAny(testTime, slog.LevelWarn, "c", "j", 2)
+	e.Info(testTime, "d")
+	slog.New(s.Handler()).With("h", true).Warn("e")
+
+	want := "time=2000-01-02T03:04:05.000Z level=INFO msg=a service=api\n" +
+		"time=2000-01-02T03:04:05.000Z level=ERROR msg=b service=api req=r1 user=7 i=1\n" +
+		"time=2000-01-02T03:04:05.000Z level=WARN msg=c service=api req=r2 j=2\n" +
+		"time=2000-01-02T03:04:05.000Z level=INFO msg=d service=api\n"
+	rs := e.Records()
+	got := ""
+	for _, r := range rs[:4] {
+		got += e.RtoString(r)
+	}
+
+	if got != want {
+		t.Fatalf("\ngot  %s\nwant %s", got, want)
+	}
+
+	want = "service=api req=r1 user=7 h=true"
+	if got := attrString(rs[4]); got != want {
+		t.Fatalf("\ngot  %s\nwant %s", got, want)
+	}
+
+	if s.SErrors() != e {
+		t.Fatalf("\ngot  %p\nwant %p", s.SErrors(), e)
+	}
+}
+
+// attrString returns the attrs of r as key=value pairs
+func attrString(r slog.Record) string {
+	s := ""
+	r.Attrs(func(a slog.Attr) bool {
+		if s != "" {
+			s += " "
+		}
+		s += a.String()
+		return true
+	})
+
+	return s
+}
+
+func TestSErrorsWithGroup(t *testing.T) {
+	e := New(nil, nil, WithDefaultAttrs(slog.String("service", "api")))
+	s := e.WithGroup("db").WithAttrs(slog.String("table", "users")).WithGroup("")
+	s.Error(testTime, "a", slog.Int("rows", 0))
+	s.Info(testTime, "b")
+
+	want := `[{"time":"2000-01-02T03:04:05Z","level":"ERROR","msg":"a","service":"api",` +
+		`"db":{"table":"users","rows":0}},` +
+		`{"time":"2000-01-02T03:04:05Z","level":"INFO","msg":"b","service":"api",` +
+		`"db":{"table":"users"}}]`
+	got, err := e.MarshalJSON()
+	if err != nil {
+		t.Fatalf("\ngot  %s\nwant nil", err.Error())
+	}
+
+	if string(got) != want {
+		t.Fatalf("\ngot  %s\nwant %s", got, want)
+	}
+
+	text := NewTextHandler(nil, nil)
+	text.WithGroup("db").Error(testTime, "c", slog.Int("rows", 1))
+	want = "time=2000-01-02T03:04:05.000Z level=ERROR msg=c db.rows=1\n"
+	if got := text.String(); got != want {
+		t.Fatalf("\ngot  %s\nwant %s", got, want)
+	}
+}
+
+func TestSErrorsScopeConcurrent(t *testing.T) {
+	e := New(nil, nil)
+	var wg sync.WaitGroup
+	for _, id := range []string{"r1", "r2", "r3"} {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			s := e.WithAttrs(slog.String("req", id))
+			for range 100 {
+				s.Info(testTime, id)
+			}
+		}()
+	}
+	wg.Wait()
+
+	for _, r := range e.Records() {
+		if got, want := attrString(r), "req="+r.Message; got != want {
+			t.Fatalf("\ngot  %s\nwant %s", got, want)
+		}
+	}
+}
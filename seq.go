@@ -0,0 +1,101 @@
+package serrors
+
+import (
+	"log/slog"
+	"slices"
+)
+
+// Since returns the records, spilled ones first, that entered e after the record with sequence
+// number seq, and the sequence number of the newest record. Every record added, stacked, appended,
+// merged or recovered is given the next sequence number, so passing the number returned to the
+// next call picks up only the records that came in between, however many were removed, expired,
+// dropped or spilled meanwhile. Pass 0 to get every record.
+func (e *SErrors) Since(seq uint64) ([]slog.Record, uint64, error) {
+	e.expire()
+	e.mu.RLock()
+	var rs []slog.Record
+	for i, r := range e.records {
+		if e.seqs[i] > seq {
+			rs = append(rs, r.Clone())
+		}
+	}
+
+	last := e.seq
+	var store Store
+	var seqs []uint64
+	if e.spill != nil && e.spill.store != nil && e.spill.count > 0 {
+		store, seqs = e.spill.store, e.spill.seqs
+	}
+	e.mu.RUnlock()
+
+	if store == nil || slices.Max(seqs) <= seq {
+		return rs, last, nil
+	}
+
+	var spilled []slog.Record
+	i := 0
+	err := store.LoadRange(0, len(seqs), func(r slog.Record) error {
+		if seqs[i] > seq {
+			spilled = append(spilled, r)
+		}
+		i++
+		return nil
+	})
+	if err != nil {
+		return nil, seq, err
+	}
+
+	return append(spilled, rs...), last, nil
+}
+
+// newSeqs returns the sequence numbers of n records entering e. e.mu must be held for writing.
+func (e *SErrors) newSeqs(n int) []uint64 {
+	seqs := make([]uint64, n)
+	for i := range seqs {
+		e.seq++
+		seqs[i] = e.seq
+	}
+
+	return seqs
+}
+
+// deleteRecord removes the record at index i. Removing the oldest record only moves the start of
+// the slices, so a full collection dropping its oldest record stays O(1); append reallocates once
+// the space in front adds up. e.mu must be held for writing.
+func (e *SErrors) deleteRecord(i int) {
+	defer e.compactWAL()
+	e.journalOp(walDelete, e.seqs[i])
+	delete(e.resolved, e.seqs[i])
+	e.shards.remove(e.seqs[i], e.records[i])
+	if i == 0 {
+		e.records[0] = slog.Record{}
+		e.records, e.seqs = e.records[1:], e.seqs[1:]
+		return
+	}
+
+	e.records = slices.Delete(e.records, i, i+1)
+	e.seqs = slices.Delete(e.seqs, i, i+1)
+}
+
+// deleteRecordsFunc removes the records del returns true for and returns how many were removed.
+// e.mu must be held for writing.
+func (e *SErrors) deleteRecordsFunc(del func(slog.Record) bool) int {
+	defer e.compactWAL()
+	n := 0
+	for i, r := range e.records {
+		if del(r) {
+			e.journalOp(walDelete, e.seqs[i])
+			delete(e.resolved, e.seqs[i])
+			e.shards.remove(e.seqs[i], r)
+			continue
+		}
+
+		e.records[n], e.seqs[n] = r, e.seqs[i]
+		n++
+	}
+
+	removed := len(e.records) - n
+	clear(e.records[n:])
+	e.records, e.seqs = e.records[:n], e.seqs[:n]
+	return removed
+}
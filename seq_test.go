@@ -0,0 +1,44 @@
+package serrors
+
+import (
+	"log/slog"
+	"slices"
+	"testing"
+)
+
+func TestSErrorsSince(t *testing.T) {
+	msgs := func(rs []slog.Record) []string {
+		s := []string{}
+		for _, r := range rs {
+			s = append(s, r.Message)
+		}
+		return s
+	}
+
+	e := New(nil, nil, WithMaxRecords(4, DropOldest), WithSpillDir(t.TempDir(), 3))
+	defer e.RemoveSpill()
+	e.Info(testTime, "a")
+	e.Info(testTime, "b")
+
+	rs, seq, err := e.Since(0)
+	if err != nil || !slices.Equal(msgs(rs), []string{"a", "b"}) {
+		t.Fatalf("\ngot  %v %v\nwant [a b] nil", msgs(rs), err)
+	}
+
+	e.RemoveIf(func(r slog.Record) bool { return r.Message == "a" })
+	for _, m := range []string{"c", "d", "e", "f", "g"} {
+		e.Info(testTime, m)
+	}
+	e.SortByLevel()
+
+	rs, seq, err = e.Since(seq)
+	if err != nil || !slices.Equal(msgs(rs), []string{"c", "d", "e", "f", "g"}) {
+		t.Fatalf("\ngot  %v %v\nwant [c d e f g] nil", msgs(rs), err)
+	}
+
+	e.Reset()
+	e.Info(testTime, "h")
+	if rs, _, _ = e.Since(seq); !slices.Equal(msgs(rs), []string{"h"}) {
+		t.Fatalf("\ngot  %v\nwant [h]", msgs(rs))
+	}
+}
@@ -3,26 +3,135 @@ package serrors
 import (
 	"bytes"
 	"context"
+	"errors"
 	"io"
 	"log/slog"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"time"
 )
 
-// SErrors contains the highest slog.Level and a list of errors (slog.Record)
+// SErrors collects errors as slog.Records and tracks the highest slog.Level added. Create one with
+// New, NewJSONHandler or NewTextHandler and pass it around as a *SErrors; all of its state is
+// internal and guarded by a mutex, so it is safe to use from multiple goroutines. An SErrors must
+// not be copied after first use, which go vet reports; use Clone for an independent copy.
 type SErrors struct {
-	// buf used for SErrors.handler to return records instead of logging them
-	buf *bytes.Buffer
+	// mu guards the records and level while they are added and read from other goroutines. It is a
+	// value so go vet reports copies.
+	mu sync.RWMutex
 	// json flag to use JSON instead of text
 	json bool
+	// opts the handlers are created with
+	opts *slog.HandlerOptions
 	// logger handler for writing logs
 	logger slog.Handler
-	// logger handler for writing to SErrors.buf
-	handler slog.Handler
-	// Level shows the highest slog.Level of errors added
-	Level slog.Level
-	// Errors is a list of slog.Record
-	Errors []slog.Record
+	// tees are extra destinations of Log, see WithTee
+	tees []tee
+	// writers pools the recordWriters used to render single records
+	writers sync.Pool
+	// cache holds rendered records, see WithRenderCache
+	cache *renderCache
+	// verbosity controls how much of each record is rendered in text output
+	verbosity int
+	// keyAttrs are the attr keys shown at VerbosityKeys
+	keyAttrs []string
+	// lineWidth wraps text output with lineIndent on continuation lines, see WithLineWidth
+	lineWidth  int
+	lineIndent string
+	// levelSymbols prefix text output, see WithLevelSymbols
+	levelSymbols map[slog.Level]string
+	// locale formats human-facing output, see WithLocale
+	locale *locale
+	// ttl drops records older than it, see WithRecordTTL
+	ttl time.Duration
+	// profile maps JSON output to a log pipeline, see WithProfile
+	profile     *Profile
+	profileOpts *slog.HandlerOptions
+	// routes send records to extra handlers in Log, see WithRoute
+	routes []route
+	// attrOrder sorts attrs in text output when not nil, see WithAttrOrder
+	attrOrder []string
+	// subs receive every record added, see SErrors.subscribe
+	subs map[chan slog.Record]struct{}
+	// spill writes old records to disk, see WithSpillDir
+	spill *spill
+	// wal journals every record entering and leaving the collection, see WithWAL
+	wal *wal
+	// meta is written by MarshalJSON when metaBlock is set, see SetMeta and WithMetaBlock
+	meta      []metaField
+	metaBlock bool
+	// transforms are run on records added, see WithTransform
+	transforms []func(*slog.Record)
+	// minLevel drops records added below it. LevelDiscard, the default, keeps every level.
+	minLevel slog.Level
+	// redactKeys are the attr keys redacted from records added, see WithRedactKeys
+	redactKeys map[string]struct{}
+	// remap changes the level of records added, see WithLevelRemap
+	remap func(slog.Record) slog.Level
+	// sampler decides which records are kept, see WithSampler
+	sampler    func(slog.Record) bool
+	sampledOut int
+	// sourceHost and sourceID name where the records come from, see WithSource
+	sourceHost string
+	sourceID   string
+	// provenance tags records merged by Stack and Append, see WithProvenance
+	provenance bool
+	// caller records the source of records added, skipping callerSkip frames, see WithCaller
+	caller     bool
+	callerSkip int
+	// stackTraces adds stack traces to records at or above stackMin, see WithStackTraces
+	stackTraces bool
+	stackMin    slog.Level
+	// scope holds the attrs and groups added to every record, see WithDefaultAttrs
+	scope attrScope
+	// clock tells the time when set, see WithClock
+	clock Clock
+	// stallIntervals is the number of quiet intervals before Watch warns, see WithStallIntervals
+	stallIntervals int
+	// idGen and fingerprinter are set by WithIDGenerator and WithFingerprinter
+	idGen         IDGenerator
+	fingerprinter Fingerprinter
+	// flush logs the records on a level, count or interval, see WithAutoFlush
+	flush *flush
+	// strictArgs checks the args of AddAny, see WithStrictArgs
+	strictArgs bool
+	// errFormat formats the message of Err, see WithErrorFormat
+	errFormat func(ErrorSummary) string
+	// capacity bounds the records held, see WithMaxRecords
+	capacity *capacity
+	// added counts the records stored, including ones since removed or spilled
+	added uint64
+	// out is the log writer, shut down by Close
+	out io.Writer
+	// done is closed by Close to stop Watch, which wg tracks
+	done      chan struct{}
+	closeOnce sync.Once
+	wg        sync.WaitGroup
+	// opSeq numbers the operations started with Begin
+	opSeq atomic.Uint64
+	// emptyJSON is what MarshalJSON writes for no records, see WithEmptyJSON
+	emptyJSON EmptyJSON
+	// compression compresses WriteNDJSON, see WithCompression
+	compression Compression
+	// canonical makes MarshalJSON write canonical JSON, see WithCanonicalJSON
+	canonical bool
+	// payloads are typed values carried with the records, see Attach
+	payloads map[string]any
+	// level is the highest slog.Level of the records added
+	level slog.Level
+	// records added, oldest first
+	records []slog.Record
+	// seqs holds the sequence number of each record in records, see Since
+	seqs []uint64
+	// seq is the sequence number of the newest record
+	seq uint64
+	// shards indexes the records by time, see Window
+	shards shards
+	// resolved holds the sequence numbers of the records marked by Resolve
+	resolved map[uint64]struct{}
+	// discard drops every record entering the collection, see FromContext
+	discard bool
 }
 
 // UpperCaseKey converts slog.Attr.Key to upper case and returns the new slog.Attr
@@ -37,197 +146,528 @@ func LowerCaseKey(_ []string, a slog.Attr) slog.Attr {
 	return a
 }
 
-// New creates a new SErrors struct using the slog.JSONHandler
-func New(logWriter io.Writer, opts *slog.HandlerOptions) SErrors {
-	return NewJSONHandler(logWriter, opts)
+// Option configures an SErrors when it is created
+type Option func(*SErrors)
+
+// New creates a new SErrors using the slog.JSONHandler
+func New(logWriter io.Writer, opts *slog.HandlerOptions, options ...Option) *SErrors {
+	return NewJSONHandler(logWriter, opts, options...)
+}
+
+// NewJSONHandler creates a new SErrors which uses the slog.JSONHandler
+func NewJSONHandler(logWriter io.Writer, opts *slog.HandlerOptions, options ...Option) *SErrors {
+	return newSErrors(true, logWriter, opts, options)
 }
 
-// NewJSONHandler creates a new SErrors struct which uses the slog.JSONHandler
-func NewJSONHandler(logWriter io.Writer, opts *slog.HandlerOptions) SErrors {
-	b := bytes.NewBuffer(nil)
+// NewTextHandler creates a new SErrors which uses the slog.TextHandler
+func NewTextHandler(logWriter io.Writer, opts *slog.HandlerOptions, options ...Option) *SErrors {
+	return newSErrors(false, logWriter, opts, options)
+}
 
+// newSErrors creates an SErrors using the JSON or text handler and applies options
+func newSErrors(json bool, logWriter io.Writer, opts *slog.HandlerOptions, options []Option) *SErrors {
 	if opts == nil {
 		opts = &slog.HandlerOptions{}
 	}
 
-	return SErrors{
-		buf:     b,
-		json:    true,
-		logger:  slog.NewJSONHandler(logWriter, opts),
-		handler: slog.NewJSONHandler(b, opts),
-		Errors:  []slog.Record{},
+	e := &SErrors{
+		json:      json,
+		opts:      opts,
+		verbosity: VerbosityDebug,
+		minLevel:  LevelDiscard,
+		subs:      map[chan slog.Record]struct{}{},
+		out:       logWriter,
+		done:      make(chan struct{}),
+		records:   []slog.Record{},
 	}
+
+	for _, o := range options {
+		o(e)
+	}
+
+	e.logger = e.newHandler(logWriter)
+	e.renderTees()
+	e.startFlusher()
+
+	return e
 }
 
-// NewTextHandler creates a new SErrors struct which uses the slog.TextHandler
-func NewTextHandler(logWriter io.Writer, opts *slog.HandlerOptions) SErrors {
-	b := bytes.NewBuffer(nil)
+// newHandler returns a JSON or text handler, matching e, that writes to w
+func (e *SErrors) newHandler(w io.Writer) slog.Handler {
+	return e.formatHandler(w, e.json)
+}
 
-	if opts == nil {
-		opts = &slog.HandlerOptions{}
+// formatHandler returns a JSON handler if json is set, otherwise a text handler, with the options
+// of e, that writes to w
+func (e *SErrors) formatHandler(w io.Writer, json bool) slog.Handler {
+	if json {
+		return slog.NewJSONHandler(w, e.jsonOpts())
 	}
 
-	return SErrors{
-		buf:     b,
-		json:    false,
-		logger:  slog.NewTextHandler(logWriter, opts),
-		handler: slog.NewTextHandler(b, opts),
-		Errors:  []slog.Record{},
+	if e.lineWidth > 0 || e.levelSymbols != nil {
+		return newTextHandler(w, e.textOpts(), e.levelSymbols, e.lineWidth, e.lineIndent)
 	}
+
+	return slog.NewTextHandler(w, e.textOpts())
+}
+
+// SetWriter makes Log write to w with a new JSON or text handler, matching e, so a collector
+// created before the configuration is loaded can be pointed at the real log sink. Close shuts
+// down w instead of the previous writer, which is not closed.
+func (e *SErrors) SetWriter(w io.Writer) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	e.out, e.logger = w, e.newHandler(w)
 }
 
-// Add creates a new slog.Record and adds it to SErrors.Errors from slog.Attr(s).
+// SetHandler makes Log write to h. Records are still prepared for the format of e, e.g. reduced to
+// the verbosity in text output. Close no longer shuts down the previous writer.
+func (e *SErrors) SetHandler(h slog.Handler) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	e.out, e.logger = nil, h
+}
+
+// Add creates a new slog.Record and adds it to SErrors from slog.Attr(s).
 func (e *SErrors) Add(t time.Time, l slog.Level, msg string, attrs ...slog.Attr) {
-	r := slog.NewRecord(t, l, msg, 0)
-	r.AddAttrs(attrs...)
-	e.Errors = append(e.Errors, r)
-	if l > e.Level {
-		e.Level = l
-	}
+	e.addAttrs(e.pc(), t, l, msg, attrs)
 }
 
-// Add creates a new slog.Record and adds it to SErrors.Errors from generics.
+// Add creates a new slog.Record and adds it to SErrors from generics.
 // args are grouped into key-value pairs.
 func (e *SErrors) AddAny(t time.Time, l slog.Level, msg string, args ...any) {
-	r := slog.NewRecord(time.Now(), l, msg, 0)
+	e.addArgs(e.pc(), t, l, msg, args)
+}
+
+// addAttrs adds a record with the source pc made from attrs
+func (e *SErrors) addAttrs(pc uintptr, t time.Time, l slog.Level, msg string, attrs []slog.Attr) {
+	r := slog.NewRecord(t, l, msg, pc)
+	r.AddAttrs(attrs...)
+	e.add(r)
+}
+
+// addArgs adds a record with the source pc made from key-value args
+func (e *SErrors) addArgs(pc uintptr, t time.Time, l slog.Level, msg string, args []any) {
+	e.add(e.argsRecord(pc, t, l, msg, args))
+}
+
+// argsRecord returns a record with the source pc made from key-value args
+func (e *SErrors) argsRecord(pc uintptr, t time.Time, l slog.Level, msg string, args []any) slog.Record {
+	r := slog.NewRecord(t, l, msg, pc)
 	r.Add(args...)
-	e.Errors = append(e.Errors, r)
-	if l > e.Level {
-		e.Level = l
+	if e.strictArgs {
+		if err := ValidateArgs(args...); err != nil {
+			r.AddAttrs(slog.String(ArgsErrorKey, err.Error()))
+		}
 	}
+
+	return r
 }
 
-// Debug creates a new Debug Level slog.Record and adds it to SErrors.Errors from slog.Attr(s)
+// AddRecord adds an existing slog.Record, such as one received from another process, to SErrors
+func (e *SErrors) AddRecord(r slog.Record) {
+	e.add(r.Clone())
+}
+
+// add appends r to the records and raises the level if needed. It returns the number of records
+// added so far.
+func (e *SErrors) add(r slog.Record) uint64 {
+	if e.discard {
+		return 0
+	}
+
+	r, exempt := sampleExempt(r)
+	r = e.scoped(r)
+	r, ok := e.remapped(e.transform(r))
+	if ok {
+		r, ok = e.admit(r)
+	}
+
+	if !ok || !exempt && !e.sample(r) {
+		return e.progress()
+	}
+
+	r = e.withStack(e.withID(r))
+
+	e.mu.Lock()
+	if !e.makeRoom(r) {
+		n := e.added
+		e.mu.Unlock()
+		return n
+	}
+
+	// store gives r the next sequence number.
+	e.journal(e.seq+1, r)
+	e.store(r)
+	n, flush := e.added, e.flushDue(r)
+	e.mu.Unlock()
+	e.syncWAL()
+
+	if flush {
+		e.autoFlush()
+	}
+
+	return n
+}
+
+// admit returns r redacted and false if it is below the minimum level
+func (e *SErrors) admit(r slog.Record) (slog.Record, bool) {
+	e.mu.RLock()
+	minLevel, keys := e.minLevel, e.redactKeys
+	e.mu.RUnlock()
+
+	if r.Level < minLevel {
+		return r, false
+	}
+
+	return redact(r, keys), true
+}
+
+// store appends r to the records without journaling it. e.mu must be held.
+func (e *SErrors) store(r slog.Record) {
+	if e.discard {
+		return
+	}
+
+	e.records = append(e.records, r)
+	e.seqs = append(e.seqs, e.newSeqs(1)...)
+	e.shards.add(e.seq, r)
+	e.added++
+	if r.Level > e.level {
+		e.level = r.Level
+	}
+
+	e.publish(r)
+	e.spillOldest()
+}
+
+// Debug creates a new Debug Level slog.Record and adds it to SErrors from slog.Attr(s)
 func (e *SErrors) Debug(t time.Time, msg string, attrs ...slog.Attr) {
-	e.Add(t, slog.LevelDebug, msg, attrs...)
+	e.addAttrs(e.pc(), t, slog.LevelDebug, msg, attrs)
 }
 
-// Debug adds a new Debug Level slog.Record and adds it to SErrors.Errors from generics
+// Debug adds a new Debug Level slog.Record and adds it to SErrors from generics
 // args are grouped into key-value pairs.
 func (e *SErrors) DebugAny(t time.Time, msg string, args ...any) {
-	e.AddAny(t, slog.LevelDebug, msg, args...)
+	e.addArgs(e.pc(), t, slog.LevelDebug, msg, args)
 }
 
-// Info adds a new Info Level slog.Record and adds it to SErrors.Errors from slog.Attr(s)
+// Info adds a new Info Level slog.Record and adds it to SErrors from slog.Attr(s)
 func (e *SErrors) Info(t time.Time, msg string, attrs ...slog.Attr) {
-	e.Add(t, slog.LevelInfo, msg, attrs...)
+	e.addAttrs(e.pc(), t, slog.LevelInfo, msg, attrs)
 }
 
-// Info adds a new Info Level slog.Record and adds it to SErrors.Errors from generics
+// Info adds a new Info Level slog.Record and adds it to SErrors from generics
 // args are grouped into key-value pairs.
 func (e *SErrors) InfoAny(t time.Time, msg string, args ...any) {
-	e.AddAny(t, slog.LevelInfo, msg, args...)
+	e.addArgs(e.pc(), t, slog.LevelInfo, msg, args)
 }
 
-// Warn adds a new Warn Level slog.Record and adds it to SErrors.Errors from slog.Attr(s)
+// Warn adds a new Warn Level slog.Record and adds it to SErrors from slog.Attr(s)
 func (e *SErrors) Warn(t time.Time, msg string, attrs ...slog.Attr) {
-	e.Add(t, slog.LevelWarn, msg, attrs...)
+	e.addAttrs(e.pc(), t, slog.LevelWarn, msg, attrs)
 }
 
-// Warn adds a new Warn Level slog.Record and adds it to SErrors.Errors from generics
+// Warn adds a new Warn Level slog.Record and adds it to SErrors from generics
 // args are grouped into key-value pairs.
 func (e *SErrors) WarnAny(t time.Time, msg string, args ...any) {
-	e.AddAny(t, slog.LevelWarn, msg, args...)
+	e.addArgs(e.pc(), t, slog.LevelWarn, msg, args)
 }
 
-// Error adds a new Error Level slog.Record and adds it to SErrors.Errors from slog.Attr(s)
+// Error adds a new Error Level slog.Record and adds it to SErrors from slog.Attr(s)
 func (e *SErrors) Error(t time.Time, msg string, attrs ...slog.Attr) {
-	e.Add(t, slog.LevelError, msg, attrs...)
+	e.addAttrs(e.pc(), t, slog.LevelError, msg, attrs)
 }
 
-// Error adds a new Error Level slog.Record and adds it to SErrors.Errors from generics
+// Error adds a new Error Level slog.Record and adds it to SErrors from generics
 // args are grouped into key-value pairs.
 func (e *SErrors) ErrorAny(t time.Time, msg string, args ...any) {
-	e.AddAny(t, slog.LevelError, msg, args...)
+	e.addArgs(e.pc(), t, slog.LevelError, msg, args)
 }
 
-// Stack adds the arguement to the beginning of e.Errors and sets e.Level to the highest Level between the two
-func (e *SErrors) Stack(errs SErrors) {
-	if e.Level < errs.Level {
-		e.Level = errs.Level
+// Stack adds the records of errs before the records of e and raises e's level to the highest of
+// the two. errs is not changed.
+func (e *SErrors) Stack(errs *SErrors) {
+	if e.discard {
+		return
 	}
 
-	e.Errors = append(errs.Errors, e.Errors...)
+	rs, resolved, l := errs.recordsAndLevel()
+	rs = e.provenanced(rs, errs)
+
+	defer e.syncWAL()
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	e.level = max(e.level, l)
+	seqs := e.newSeqs(len(rs))
+	e.journalRecords(rs, seqs)
+	e.markResolved(seqs, resolved)
+	e.records = append(rs, e.records...)
+	e.seqs = append(seqs, e.seqs...)
+	e.indexRecords(rs, seqs)
+	e.invalidate()
+	e.publish(rs...)
 }
 
-// Append appends arguement to e.Errors and sets e.Level to the highest Level between the two
-func (e *SErrors) Append(errs SErrors) {
-	if e.Level < errs.Level {
-		e.Level = errs.Level
+// Append adds the records of errs after the records of e and raises e's level to the highest of
+// the two. errs is not changed.
+func (e *SErrors) Append(errs *SErrors) {
+	if e.discard {
+		return
 	}
 
-	e.Errors = append(e.Errors, errs.Errors...)
+	rs, resolved, l := errs.recordsAndLevel()
+	rs = e.provenanced(rs, errs)
+
+	defer e.syncWAL()
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	e.level = max(e.level, l)
+	seqs := e.newSeqs(len(rs))
+	e.journalRecords(rs, seqs)
+	e.markResolved(seqs, resolved)
+	e.records = append(e.records, rs...)
+	e.seqs = append(e.seqs, seqs...)
+	e.indexRecords(rs, seqs)
+	e.publish(rs...)
 }
 
-func (e SErrors) IsEmpty() bool { return len(e.Errors) < 1 }
-
-// String returns all e.Errors as a sing string
-func (e SErrors) String() string {
-	var s string
-	for _, r := range e.Errors {
-		s += e.RtoString(r)
+// recordsAndLevel returns a copy of the records, which of them are resolved, nil if none are, and
+// the level, taken together so they agree
+func (e *SErrors) recordsAndLevel() ([]slog.Record, []bool, slog.Level) {
+	e.expire()
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+
+	var resolved []bool
+	if len(e.resolved) > 0 {
+		resolved = make([]bool, len(e.records))
+		for i := range e.records {
+			resolved[i] = e.isResolved(i)
+		}
 	}
 
-	return s
+	return e.copyRecords(), resolved, e.level
 }
 
-// RtoString converst a slog.Record to a string
-func (e SErrors) RtoString(r slog.Record) string {
-	if err := e.handler.Handle(context.Background(), r); err != nil {
-		return err.Error()
+// copyRecords returns a copy of the records in memory. e.mu must be held.
+func (e *SErrors) copyRecords() []slog.Record {
+	rs := make([]slog.Record, len(e.records))
+	copy(rs, e.records)
+	return rs
+}
+
+// IsEmpty returns true if no records are held in memory
+func (e *SErrors) IsEmpty() bool {
+	e.expire()
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+
+	return len(e.records) < 1
+}
+
+// Level returns the highest slog.Level of the records added
+func (e *SErrors) Level() slog.Level {
+	e.expire()
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+
+	return e.level
+}
+
+// Records returns a copy of the records that is safe to read while other goroutines add records
+func (e *SErrors) Records() []slog.Record {
+	e.expire()
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+
+	return e.copyRecords()
+}
+
+// String returns all records as a single string, rendered by the Formatter registered in place of
+// FormatJSON or FormatText if there is one
+func (e *SErrors) String() string {
+	e.expire()
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+
+	if f := e.outputFormat(); !isHandlerFormat(f) {
+		var b strings.Builder
+		for _, r := range e.records {
+			out, err := f.Render(r)
+			if err != nil {
+				b.WriteString(err.Error())
+			}
+			b.Write(out)
+		}
+
+		return b.String()
 	}
 
-	s := e.buf.String()
-	e.buf.Reset()
-	return s
+	if e.cache != nil {
+		return e.cachedString()
+	}
+
+	var b strings.Builder
+	h := e.newHandler(&b)
+	for i, r := range e.records {
+		if err := h.Handle(context.Background(), e.prepare(r)); err != nil {
+			b.WriteString(err.Error())
+		}
+
+		// Records render to similar lengths, so size the builder once from the first.
+		if i == 0 {
+			b.Grow(b.Len() * (len(e.records) - 1))
+		}
+	}
+
+	return b.String()
+}
+
+// RtoString converts a slog.Record to a string
+func (e *SErrors) RtoString(r slog.Record) string {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+
+	return e.render(r)
+}
+
+// render formats r with a pooled writer, so concurrent renders do not share state. e.mu must be
+// held.
+func (e *SErrors) render(r slog.Record) string {
+	w := e.getWriter()
+	defer e.putWriter(w)
+
+	w.write(e, r)
+	return w.b.String()
+}
+
+// maxPooledWriter is the largest buffer a recordWriter may have to be returned to the pool, so one
+// huge record does not pin its memory
+const maxPooledWriter = 64 << 10
+
+// recordWriter renders records into one buffer. Its handler is created on first use and reused for
+// every record after that, including by later renders when it is pooled.
+type recordWriter struct {
+	b bytes.Buffer
+	h slog.Handler
 }
 
-// First returns the first slog.Record added to SErrors.Errors
-func (e SErrors) First() slog.Record { return e.Errors[0] }
+// getWriter returns an empty recordWriter from the pool of e
+func (e *SErrors) getWriter() *recordWriter {
+	if w, ok := e.writers.Get().(*recordWriter); ok {
+		w.b.Reset()
+		return w
+	}
 
-// Last returns the last slog.Record added to SErrors.Errors
-func (e SErrors) Last() slog.Record { return e.Errors[len(e.Errors)-1] }
+	return &recordWriter{}
+}
 
-// ToArray returns SErrors.Errors as []string and an error
-func (e SErrors) ToArray() ([]string, error) {
-	s := make([]string, len(e.Errors))
-	for i, r := range e.Errors {
-		str := e.RtoString(r)
-		str = strings.TrimSuffix(str, "\n")
-		s[i] = str
+// putWriter returns w to the pool of e unless its buffer has grown too large
+func (e *SErrors) putWriter(w *recordWriter) {
+	if w.b.Cap() <= maxPooledWriter {
+		e.writers.Put(w)
 	}
-	return s, nil
 }
 
-// Log writes all SErrors.Errors using the SErrors.logger handler
-func (e SErrors) Log() error {
-	for _, r := range e.Errors {
-		if err := e.logger.Handle(context.Background(), r); err != nil {
-			return err
-		}
+// write renders r into w.b, or the error if it cannot be rendered. e.mu must be held.
+func (w *recordWriter) write(e *SErrors, r slog.Record) {
+	if w.h == nil {
+		w.h = e.newHandler(&w.b)
+	}
+
+	if err := w.h.Handle(context.Background(), e.prepare(r)); err != nil {
+		w.b.WriteString(err.Error())
 	}
+}
+
+// First returns the first record added. It panics if there are none.
+func (e *SErrors) First() slog.Record {
+	e.expire()
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+
+	return e.records[0]
+}
+
+// Last returns the last record added. It panics if there are none.
+func (e *SErrors) Last() slog.Record {
+	e.expire()
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+
+	return e.records[len(e.records)-1]
+}
+
+// ToArray returns the records as []string and an error
+func (e *SErrors) ToArray() ([]string, error) {
+	e.expire()
+	e.mu.RLock()
+	defer e.mu.RUnlock()
 
-	return nil
+	return e.toArray(), nil
 }
 
-// MarshalJSON converts SErrors.Errors to a JSON array
-func (e SErrors) MarshalJSON() ([]byte, error) {
-	s := "["
-	l := len(e.Errors) - 1
+// toArray renders each record without its trailing newline. e.mu must be held.
+func (e *SErrors) toArray() []string {
+	w := e.getWriter()
+	defer e.putWriter(w)
 
-	a, err := e.ToArray()
-	if err != nil {
-		return nil, err
+	s := make([]string, len(e.records))
+	for i, r := range e.records {
+		w.b.Reset()
+		w.write(e, r)
+		s[i] = strings.TrimSuffix(w.b.String(), "\n")
 	}
 
-	for c, r := range a {
-		if c < l {
-			r += ","
+	return s
+}
+
+// Log writes all records using the logger handler, to the tees enabled for their level, see
+// WithTee, and to the handlers of matching routes, see WithRoute
+func (e *SErrors) Log() error {
+	return e.LogContext(context.Background())
+}
+
+// LogContext is Log passing ctx to the handlers. It stops with ctx.Err() once ctx is done, leaving
+// the remaining records unlogged, so flushing a large collection respects deadlines.
+func (e *SErrors) LogContext(ctx context.Context) error {
+	return e.eachRecord(func(r slog.Record) error {
+		if err := ctx.Err(); err != nil {
+			return err
 		}
-		s += r
+
+		return e.logRecord(ctx, r)
+	})
+}
+
+// logRecord writes r to the routes, the logger and the tees
+func (e *SErrors) logRecord(ctx context.Context, r slog.Record) error {
+	routeErr := e.route(ctx, r)
+	return errors.Join(routeErr, e.output(ctx, r))
+}
+
+// MarshalJSON converts the records to a JSON array, or to an object with a meta block when
+// WithMetaBlock is used. Records are rendered by a JSON handler even if e uses the text handler.
+func (e *SErrors) MarshalJSON() ([]byte, error) {
+	e.expire()
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+
+	marshal := e.recordsArray
+	if e.metaBlock {
+		marshal = e.marshalMeta
 	}
 
-	s += "]"
+	b, err := marshal()
+
+	if err != nil || !e.canonical {
+		return b, err
+	}
 
-	return []byte(s), nil
+	return canonicalJSON(b)
 }
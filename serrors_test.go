@@ -2,10 +2,16 @@ package serrors
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
 	"log/slog"
 	"os"
+	"slices"
 	"strings"
+	"sync"
 	"testing"
 	"time"
 )
@@ -219,9 +225,9 @@ func TestSErrorsStructFormatting(t *testing.T) {
 	for _, test := range testAttrParamsJSON {
 		t.Run(test.name, func(t *testing.T) {
 			s := struct {
-				String string  `json:"string"`
-				Int    int     `json:"int"`
-				Errors SErrors `json:"errors,omitempty"`
+				String string   `json:"string"`
+				Int    int      `json:"int"`
+				Errors *SErrors `json:"errors,omitempty"`
 			}{
 				"m",
 				1,
@@ -297,3 +303,203 @@ func TestSErrorsLogText(t *testing.T) {
 		})
 	}
 }
+
+func TestSErrorsAddRecord(t *testing.T) {
+	r := slog.NewRecord(testTime, slog.LevelWarn, "m", 0)
+	r.AddAttrs(slog.Int("a", 1))
+
+	e := NewTextHandler(nil, nil)
+	e.AddRecord(r)
+	r.AddAttrs(slog.Int("b", 2))
+
+	want := "time=2000-01-02T03:04:05.000Z level=WARN msg=m a=1\n"
+	if got := e.String(); got != want {
+		t.Fatalf("\ngot  %s\nwant %s", got, want)
+	}
+
+	if e.Level() != slog.LevelWarn {
+		t.Fatalf("\ngot  %s\nwant %s", e.Level(), slog.LevelWarn)
+	}
+}
+
+func TestSErrorsAddAnyTime(t *testing.T) {
+	e := NewTextHandler(nil, nil)
+	e.AddAny(testTime, slog.LevelInfo, "m", "a", 1)
+
+	if got := e.First().Time; !got.Equal(testTime) {
+		t.Fatalf("\ngot  %s\nwant %s", got, testTime)
+	}
+}
+
+func TestSErrorsStack(t *testing.T) {
+	errs := NewTextHandler(nil, nil)
+	errs.Add(testTime, slog.LevelError, "a")
+	errs.Add(testTime, slog.LevelInfo, "b")
+	// Spare capacity in errs must not be shared with e.
+	errs.Remove(1)
+
+	e := NewTextHandler(nil, nil)
+	e.Add(testTime, slog.LevelWarn, "c")
+	e.Stack(errs)
+	errs.Add(testTime, slog.LevelDebug, "d")
+
+	want := "time=2000-01-02T03:04:05.000Z level=ERROR msg=a\n" +
+		"time=2000-01-02T03:04:05.000Z level=WARN msg=c\n"
+	if got := e.String(); got != want {
+		t.Fatalf("\ngot  %s\nwant %s", got, want)
+	}
+
+	if e.Level() != slog.LevelError {
+		t.Fatalf("\ngot  %s\nwant %s", e.Level(), slog.LevelError)
+	}
+}
+
+func TestSErrorsAppendSelf(t *testing.T) {
+	e := NewTextHandler(nil, nil)
+	e.Add(testTime, slog.LevelWarn, "a")
+	e.Append(e)
+
+	if got := len(e.Records()); got != 2 {
+		t.Fatalf("\ngot  %d\nwant 2", got)
+	}
+}
+
+func TestSErrorsConcurrentRender(t *testing.T) {
+	e := NewJSONHandler(nil, nil)
+	e.Add(testTime, slog.LevelWarn, "a")
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				e.Add(testTime, slog.LevelInfo, "b")
+				_ = e.String()
+				_, _ = e.MarshalJSON()
+			}
+		}()
+	}
+	wg.Wait()
+
+	if got := len(e.Records()); got != 801 {
+		t.Fatalf("\ngot  %d\nwant 801", got)
+	}
+}
+
+func TestSErrorsConcurrentUse(t *testing.T) {
+	e := NewTextHandler(io.Discard, nil, WithArena(64), WithRedactKeys("password"))
+	other := NewJSONHandler(nil, nil)
+	other.Warn(testTime, "other")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() {
+		for range e.Follow(ctx) {
+		}
+	}()
+
+	ops := []func(i int){
+		func(i int) { e.Error(testTime, "m", slog.Int("i", i), slog.String("password", "p")) },
+		func(i int) { e.AddAny(testTime, slog.LevelWarn, "m", "i", i) },
+		func(i int) { e.AddError(testTime, io.ErrUnexpectedEOF) },
+		func(int) { _ = e.String() },
+		func(int) { _ = e.RtoString(slog.NewRecord(testTime, slog.LevelInfo, "r", 0)) },
+		func(int) { _, _ = e.MarshalJSON() },
+		func(int) { _ = e.Log() },
+		func(int) { _ = e.Level() },
+		func(int) { _ = e.Records() },
+		func(int) { _ = e.WriteNDJSON(io.Discard) },
+		func(int) { _ = e.Snapshot().String() },
+		func(int) { e.Resolve(func(r slog.Record) bool { return r.Level == slog.LevelWarn }) },
+		func(int) { e.RemoveIf(func(r slog.Record) bool { return r.Level == slog.LevelInfo }) },
+		func(i int) { e.SetMeta("i", i) },
+		func(int) { e.Append(other) },
+		func(int) { _ = e.Err() },
+		func(int) { errors.Is(e.Err(), io.ErrUnexpectedEOF) },
+		func(int) { e.RenderVerbosity(VerbosityAll) },
+	}
+
+	var wg sync.WaitGroup
+	for _, op := range ops {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range 50 {
+				op(i)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if e.Level() != slog.LevelError {
+		t.Fatalf("\ngot  %s\nwant %s", e.Level(), slog.LevelError)
+	}
+}
+
+func TestSErrorsSetWriter(t *testing.T) {
+	early := bytes.NewBuffer(nil)
+	e := NewTextHandler(early, nil)
+	e.Error(testTime, "m")
+
+	got := bytes.NewBuffer(nil)
+	e.SetWriter(got)
+	if err := e.Log(); err != nil {
+		t.Fatal(err)
+	}
+
+	want := "time=2000-01-02T03:04:05.000Z level=ERROR msg=m\n"
+	if got.String() != want || early.Len() != 0 {
+		t.Fatalf("\ngot  %q, early %q\nwant %q", got, early, want)
+	}
+}
+
+func TestSErrorsSetHandler(t *testing.T) {
+	e := NewTextHandler(io.Discard, nil)
+	e.Error(testTime, "m", slog.String("k", "v"))
+
+	got := bytes.NewBuffer(nil)
+	e.SetHandler(slog.NewJSONHandler(got, nil))
+	if err := e.Log(); err != nil {
+		t.Fatal(err)
+	}
+
+	want := `{"time":"2000-01-02T03:04:05Z","level":"ERROR","msg":"m","k":"v"}` + "\n"
+	if got.String() != want {
+		t.Fatalf("\ngot  %s\nwant %s", got, want)
+	}
+}
+
+// ctxHandler records the messages it handles and the value of ctxKey in their context, cancelling
+// after the first
+type ctxHandler struct {
+	slog.Handler
+	cancel context.CancelFunc
+	got    []string
+}
+
+// ctxKey is a context key read by ctxHandler
+type ctxKey struct{}
+
+func (h *ctxHandler) Handle(ctx context.Context, r slog.Record) error {
+	h.got = append(h.got, fmt.Sprint(r.Message, "=", ctx.Value(ctxKey{})))
+	h.cancel()
+	return nil
+}
+
+func TestSErrorsLogContext(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.WithValue(context.Background(), ctxKey{}, "v"))
+	h := &ctxHandler{Handler: slog.NewTextHandler(io.Discard, nil), cancel: cancel}
+	e := NewTextHandler(io.Discard, nil)
+	e.SetHandler(h)
+	e.Error(testTime, "a")
+	e.Error(testTime, "b")
+
+	if err := e.LogContext(ctx); !errors.Is(err, context.Canceled) {
+		t.Fatalf("\ngot  %v\nwant %v", err, context.Canceled)
+	}
+
+	if want := []string{"a=v"}; !slices.Equal(h.got, want) {
+		t.Fatalf("\ngot  %v\nwant %v", h.got, want)
+	}
+}
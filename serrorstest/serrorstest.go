@@ -0,0 +1,170 @@
+// Package serrorstest provides test doubles for code that accepts a *serrors.SErrors. A Recorder
+// is a collector with a fake clock whose Log output is kept in memory, record by record, so tests
+// can check both what was collected and what was emitted without touching real writers.
+package serrorstest
+
+import (
+	"context"
+	"log/slog"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/chadeldridge/serrors"
+)
+
+// Start is the time a Recorder's clock starts at
+var Start = time.Date(2000, 1, 2, 3, 4, 5, 0, time.UTC)
+
+// Clock is a fake clock that only moves when told to. It is safe to use from multiple goroutines.
+type Clock struct {
+	mu  sync.Mutex
+	now time.Time
+}
+
+// NewClock creates a Clock set to t
+func NewClock(t time.Time) *Clock {
+	return &Clock{now: t}
+}
+
+// Now returns the time of the clock
+func (c *Clock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.now
+}
+
+// Advance moves the clock forward by d
+func (c *Clock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.now = c.now.Add(d)
+}
+
+// Set moves the clock to t
+func (c *Clock) Set(t time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.now = t
+}
+
+// Recorder is a text handler collector for tests using Clock, see serrors.WithClock, so AddNow and
+// its variants give stable times. Records written by Log are kept in the order they were
+// received, together with their rendered text.
+type Recorder struct {
+	*serrors.SErrors
+	// Clock is the fake clock of the Recorder, starting at Start
+	Clock *Clock
+
+	mu       sync.Mutex
+	logged   []slog.Record
+	rendered []string
+}
+
+// NewRecorder creates a Recorder using the slog.TextHandler and options
+func NewRecorder(options ...serrors.Option) *Recorder {
+	rec := &Recorder{Clock: NewClock(Start)}
+	capture := serrors.WithRoute(func(slog.Record) bool { return true }, recordSink{rec})
+	options = append([]serrors.Option{serrors.WithClock(rec.Clock)}, options...)
+	rec.SErrors = serrors.NewTextHandler(renderSink{rec}, nil, append(options, capture)...)
+	return rec
+}
+
+// Logged returns the records received by Log, in order, as they were added
+func (rec *Recorder) Logged() []slog.Record {
+	rec.mu.Lock()
+	defer rec.mu.Unlock()
+
+	return slices.Clone(rec.logged)
+}
+
+// Rendered returns the text Log wrote for each record, in order
+func (rec *Recorder) Rendered() []string {
+	rec.mu.Lock()
+	defer rec.mu.Unlock()
+
+	return slices.Clone(rec.rendered)
+}
+
+// ResetLogged forgets the records received by Log. The collected records are kept.
+func (rec *Recorder) ResetLogged() {
+	rec.mu.Lock()
+	defer rec.mu.Unlock()
+
+	rec.logged, rec.rendered = nil, nil
+}
+
+// AssertCount fails t unless n records are collected
+func (rec *Recorder) AssertCount(t testing.TB, n int) {
+	t.Helper()
+	if got := len(rec.Records()); got != n {
+		t.Fatalf("\ngot  %d records\nwant %d", got, n)
+	}
+}
+
+// AssertContains fails t unless a collected record has level l and message msg
+func (rec *Recorder) AssertContains(t testing.TB, l slog.Level, msg string) {
+	t.Helper()
+	for _, r := range rec.Records() {
+		if r.Level == l && r.Message == msg {
+			return
+		}
+	}
+
+	t.Fatalf("\ngot  %s\nwant a %s record %q", rec.String(), l, msg)
+}
+
+// AssertLogged fails t unless the messages of the records received by Log are msgs, in order
+func (rec *Recorder) AssertLogged(t testing.TB, msgs ...string) {
+	t.Helper()
+	var got []string
+	for _, r := range rec.Logged() {
+		got = append(got, r.Message)
+	}
+
+	if !slices.Equal(got, msgs) {
+		t.Fatalf("\ngot  %q\nwant %q", got, msgs)
+	}
+}
+
+// recordSink is the route handler keeping the records received by Log
+type recordSink struct {
+	rec *Recorder
+}
+
+// Enabled accepts every level
+func (s recordSink) Enabled(context.Context, slog.Level) bool { return true }
+
+// Handle keeps a copy of r
+func (s recordSink) Handle(_ context.Context, r slog.Record) error {
+	s.rec.mu.Lock()
+	defer s.rec.mu.Unlock()
+
+	s.rec.logged = append(s.rec.logged, r.Clone())
+	return nil
+}
+
+// WithAttrs returns s, Log does not add attrs
+func (s recordSink) WithAttrs([]slog.Attr) slog.Handler { return s }
+
+// WithGroup returns s, Log does not open groups
+func (s recordSink) WithGroup(string) slog.Handler { return s }
+
+// renderSink is the log writer keeping the text of each record. The slog handlers write each
+// record with one call.
+type renderSink struct {
+	rec *Recorder
+}
+
+// Write keeps b as the text of one record
+func (s renderSink) Write(b []byte) (int, error) {
+	s.rec.mu.Lock()
+	defer s.rec.mu.Unlock()
+
+	s.rec.rendered = append(s.rec.rendered, string(b))
+	return len(b), nil
+}
@@ -0,0 +1,39 @@
+package serrorstest
+
+import (
+	"log/slog"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/chadeldridge/serrors"
+)
+
+func TestRecorder(t *testing.T) {
+	rec := NewRecorder(serrors.WithMinLevel(slog.LevelInfo))
+	rec.Debug(rec.Clock.Now(), "dropped")
+	rec.WarnNow("slow", slog.Int("ms", 900))
+	rec.Clock.Advance(time.Second)
+	rec.ErrorNow("failed")
+
+	rec.AssertCount(t, 2)
+	rec.AssertContains(t, slog.LevelError, "failed")
+	rec.AssertLogged(t)
+
+	if err := rec.Log(); err != nil {
+		t.Fatalf("\ngot  %s\nwant nil", err.Error())
+	}
+
+	rec.AssertLogged(t, "slow", "failed")
+	want := []string{
+		"time=2000-01-02T03:04:05.000Z level=WARN msg=slow ms=900\n",
+		"time=2000-01-02T03:04:06.000Z level=ERROR msg=failed\n",
+	}
+	if got := rec.Rendered(); !slices.Equal(got, want) {
+		t.Fatalf("\ngot  %q\nwant %q", got, want)
+	}
+
+	rec.ResetLogged()
+	rec.AssertLogged(t)
+	rec.AssertCount(t, 2)
+}
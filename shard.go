@@ -0,0 +1,324 @@
+package serrors
+
+import (
+	"cmp"
+	"log/slog"
+	"maps"
+	"slices"
+	"time"
+)
+
+// defaultShardWidth is the span of time a shard covers unless WithShardWidth is used
+const defaultShardWidth = time.Minute
+
+// WithShardWidth sets the span of time each shard of the time index covers, one minute by default.
+// The records in memory are indexed by time in shards of that width, so Window, Prune, Histogram
+// and WithRecordTTL only touch the shards in their range instead of every record. Wider shards
+// suit collectors queried over long ranges, narrower ones finer histograms.
+func WithShardWidth(d time.Duration) Option {
+	return func(e *SErrors) {
+		if d > 0 {
+			e.shards.width = d
+		}
+	}
+}
+
+// Bin counts the records of one shard, see Histogram
+type Bin struct {
+	// Start is the time the shard's span begins at
+	Start time.Time `json:"start"`
+	// Count is the number of records in the span
+	Count int `json:"count"`
+	// ByLevel is the number of records of each level
+	ByLevel map[slog.Level]int `json:"by_level"`
+}
+
+// shards indexes the records in memory by time: each shard holds the sequence numbers of the
+// records whose time falls in its span
+type shards struct {
+	// width is the span of each shard, defaultShardWidth if 0
+	width time.Duration
+	// list holds the shards with records, oldest first
+	list []*shard
+}
+
+// shard holds the records whose time falls in [start, start+width)
+type shard struct {
+	start time.Time
+	// min is the earliest time in the shard or an earlier one, so expiry knows when to look
+	min time.Time
+	// entries are the records in the shard by sequence number
+	entries []shardEntry
+	// levels counts the records of each level
+	levels map[slog.Level]int
+}
+
+// shardEntry is a record in a shard
+type shardEntry struct {
+	seq uint64
+	t   time.Time
+}
+
+// startOf returns the start of the span t falls in
+func (s *shards) startOf(t time.Time) time.Time {
+	w := s.width
+	if w <= 0 {
+		w = defaultShardWidth
+	}
+
+	return t.Truncate(w)
+}
+
+// find returns the index of the shard starting at start and whether it exists
+func (s *shards) find(start time.Time) (int, bool) {
+	return slices.BinarySearchFunc(s.list, start, func(sh *shard, t time.Time) int {
+		return sh.start.Compare(t)
+	})
+}
+
+// span returns the range of shards overlapping [from, to), to being open ended if zero
+func (s *shards) span(from, to time.Time) (int, int) {
+	i, _ := s.find(s.startOf(from))
+	j := len(s.list)
+	if !to.IsZero() {
+		j, _ = s.find(to)
+	}
+
+	return i, max(i, j)
+}
+
+// add indexes the record r with sequence number seq
+func (s *shards) add(seq uint64, r slog.Record) {
+	start := s.startOf(r.Time)
+	var sh *shard
+	if n := len(s.list); n > 0 && s.list[n-1].start.Equal(start) {
+		sh = s.list[n-1]
+	} else if i, ok := s.find(start); ok {
+		sh = s.list[i]
+	} else {
+		sh = &shard{start: start, min: r.Time, levels: map[slog.Level]int{}}
+		s.list = slices.Insert(s.list, i, sh)
+	}
+
+	// Records are added in sequence order, so the entry almost always goes last.
+	entry := shardEntry{seq: seq, t: r.Time}
+	if n := len(sh.entries); n == 0 || sh.entries[n-1].seq < seq {
+		sh.entries = append(sh.entries, entry)
+	} else {
+		i, _ := slices.BinarySearchFunc(sh.entries, seq, compareSeq)
+		sh.entries = slices.Insert(sh.entries, i, entry)
+	}
+
+	sh.min = minTime(sh.min, r.Time)
+	sh.levels[r.Level]++
+}
+
+// remove drops the record r with sequence number seq from the index
+func (s *shards) remove(seq uint64, r slog.Record) {
+	i, ok := s.find(s.startOf(r.Time))
+	if !ok {
+		return
+	}
+
+	sh := s.list[i]
+	j, ok := slices.BinarySearchFunc(sh.entries, seq, compareSeq)
+	if !ok {
+		return
+	}
+
+	// Dropping the oldest entry only moves the start, as deleteRecord does.
+	if j == 0 {
+		sh.entries = sh.entries[1:]
+	} else {
+		sh.entries = slices.Delete(sh.entries, j, j+1)
+	}
+
+	if sh.levels[r.Level]--; sh.levels[r.Level] == 0 {
+		delete(sh.levels, r.Level)
+	}
+
+	if len(sh.entries) > 0 {
+		return
+	}
+
+	if i == 0 {
+		s.list[0] = nil
+		s.list = s.list[1:]
+		return
+	}
+
+	s.list = slices.Delete(s.list, i, i+1)
+}
+
+// compareSeq orders shard entries by sequence number
+func compareSeq(e shardEntry, seq uint64) int {
+	return cmp.Compare(e.seq, seq)
+}
+
+// reindex rebuilds the time index from the records in memory. e.mu must be held for writing.
+func (e *SErrors) reindex() {
+	e.shards.list = nil
+	for i, r := range e.records {
+		e.shards.add(e.seqs[i], r)
+	}
+}
+
+// indexRecords adds the records rs with sequence numbers seqs to the time index. e.mu must be
+// held for writing.
+func (e *SErrors) indexRecords(rs []slog.Record, seqs []uint64) {
+	for i, r := range rs {
+		e.shards.add(seqs[i], r)
+	}
+}
+
+// Window returns copies of the records in memory whose time is in [from, to), in the order they
+// are held. A zero to leaves the window open ended. Only the shards the window overlaps are read,
+// so the cost does not grow with the records outside it. Spilled records are not included.
+func (e *SErrors) Window(from, to time.Time) []slog.Record {
+	e.expire()
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+
+	var seqs []uint64
+	i, j := e.shards.span(from, to)
+	for _, sh := range e.shards.list[i:j] {
+		for _, en := range sh.entries {
+			if !en.t.Before(from) && (to.IsZero() || en.t.Before(to)) {
+				seqs = append(seqs, en.seq)
+			}
+		}
+	}
+
+	idx, ok := e.indexOfSeqs(seqs)
+	if !ok {
+		idx = idx[:0]
+		for i, r := range e.records {
+			if !r.Time.Before(from) && (to.IsZero() || r.Time.Before(to)) {
+				idx = append(idx, i)
+			}
+		}
+	}
+
+	rs := make([]slog.Record, len(idx))
+	for n, i := range idx {
+		rs[n] = e.records[i].Clone()
+	}
+
+	return rs
+}
+
+// indexOfSeqs returns the indexes of the records with sequence numbers seqs, in order, finding
+// them by binary search. It returns false if one is not found, which happens once the records
+// are no longer in sequence order, after Stack, Merge or a sort. e.mu must be held.
+func (e *SErrors) indexOfSeqs(seqs []uint64) ([]int, bool) {
+	idx := make([]int, 0, len(seqs))
+	for _, seq := range seqs {
+		i, ok := slices.BinarySearch(e.seqs, seq)
+		if !ok {
+			return idx, false
+		}
+		idx = append(idx, i)
+	}
+	slices.Sort(idx)
+
+	return idx, true
+}
+
+// Prune drops the records in memory whose time is before t and returns how many were dropped,
+// lowering Level to the highest level of the records left. Only the shards before t are read.
+// Spilled records are not pruned.
+func (e *SErrors) Prune(t time.Time) int {
+	defer e.syncWAL()
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	return e.prune(t)
+}
+
+// prune is Prune with e.mu held for writing
+func (e *SErrors) prune(t time.Time) int {
+	defer e.resetShardMin(t)
+
+	n := 0
+	i, j := e.shards.span(time.Time{}, t)
+	for _, sh := range e.shards.list[i:j] {
+		for _, en := range sh.entries {
+			if en.t.Before(t) {
+				n++
+			}
+		}
+	}
+
+	if n == 0 {
+		return 0
+	}
+
+	// Records usually arrive in time order, so the pruned ones are the oldest, dropped in O(1)
+	// each. Otherwise every record is checked.
+	prefix := n <= len(e.records)
+	for _, r := range e.records[:min(n, len(e.records))] {
+		prefix = prefix && r.Time.Before(t)
+	}
+
+	if !prefix {
+		e.deleteRecordsFunc(func(r slog.Record) bool { return r.Time.Before(t) })
+		e.recomputeLevel()
+		e.invalidate()
+		return n
+	}
+
+	level := false
+	for range n {
+		level = level || e.records[0].Level >= e.level && !e.isResolved(0)
+		e.deleteRecord(0)
+		e.invalidateAt(0)
+	}
+
+	if level {
+		e.recomputeLevel()
+	}
+
+	return n
+}
+
+// resetShardMin recomputes the earliest time of the oldest shard once the records before t are
+// gone from it, so expiry does not look again until a record is due. e.mu must be held for
+// writing.
+func (e *SErrors) resetShardMin(t time.Time) {
+	if len(e.shards.list) == 0 || !e.shards.list[0].min.Before(t) {
+		return
+	}
+
+	sh := e.shards.list[0]
+	sh.min = sh.entries[0].t
+	for _, en := range sh.entries {
+		sh.min = minTime(sh.min, en.t)
+	}
+}
+
+// Histogram returns the number of records in memory of each shard overlapping [from, to), oldest
+// first, see WithShardWidth. A zero to leaves the range open ended. Shards without records are
+// left out. The counts are kept by the shards, so no record is read. Spilled records are not
+// included.
+func (e *SErrors) Histogram(from, to time.Time) []Bin {
+	e.expire()
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+
+	i, j := e.shards.span(from, to)
+	bins := make([]Bin, 0, j-i)
+	for _, sh := range e.shards.list[i:j] {
+		bins = append(bins, Bin{Start: sh.start, Count: len(sh.entries), ByLevel: maps.Clone(sh.levels)})
+	}
+
+	return bins
+}
+
+// minTime returns the earlier of a and b
+func minTime(a, b time.Time) time.Time {
+	if b.Before(a) {
+		return b
+	}
+
+	return a
+}
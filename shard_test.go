@@ -0,0 +1,112 @@
+package serrors
+
+import (
+	"log/slog"
+	"maps"
+	"slices"
+	"testing"
+	"time"
+)
+
+// recordMessages returns the messages of rs
+func recordMessages(rs []slog.Record) []string {
+	var s []string
+	for _, r := range rs {
+		s = append(s, r.Message)
+	}
+
+	return s
+}
+
+func TestSErrorsWindow(t *testing.T) {
+	newE := func() *SErrors {
+		e := NewTextHandler(nil, nil, WithShardWidth(10*time.Minute))
+		e.Info(testTime, "a")
+		e.Warn(testTime.Add(5*time.Minute), "b")
+		e.Error(testTime.Add(25*time.Minute), "c")
+		e.Info(testTime.Add(time.Minute), "d")
+		return e
+	}
+
+	tests := []struct {
+		name     string
+		change   func(e *SErrors)
+		from, to time.Time
+		want     []string
+	}{
+		{"all", nil, time.Time{}, time.Time{}, []string{"a", "b", "c", "d"}},
+		{"range", nil, testTime.Add(time.Minute), testTime.Add(25 * time.Minute), []string{"b", "d"}},
+		{"open ended", nil, testTime.Add(2 * time.Minute), time.Time{}, []string{"b", "c"}},
+		{"empty", nil, testTime.Add(10 * time.Minute), testTime.Add(20 * time.Minute), nil},
+		{"sorted", (*SErrors).SortByLevel, testTime, testTime.Add(10 * time.Minute), []string{"b", "a", "d"}},
+		{"removed", func(e *SErrors) { e.Remove(0) }, testTime, time.Time{}, []string{"b", "c", "d"}},
+		{"stacked", func(e *SErrors) {
+			s := NewTextHandler(nil, nil)
+			s.Info(testTime.Add(2*time.Minute), "s")
+			e.Stack(s)
+		}, testTime, testTime.Add(10 * time.Minute), []string{"s", "a", "b", "d"}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			e := newE()
+			if test.change != nil {
+				test.change(e)
+			}
+
+			if got := recordMessages(e.Window(test.from, test.to)); !slices.Equal(got, test.want) {
+				t.Fatalf("\ngot  %v\nwant %v", got, test.want)
+			}
+		})
+	}
+}
+
+func TestSErrorsPrune(t *testing.T) {
+	e := NewTextHandler(nil, nil)
+	e.Error(testTime, "a")
+	e.Info(testTime.Add(time.Minute), "b")
+	e.Warn(testTime.Add(-time.Hour), "c")
+	e.Info(testTime.Add(time.Hour), "d")
+
+	if n := e.Prune(testTime.Add(time.Minute)); n != 2 {
+		t.Fatalf("\ngot  %d\nwant 2", n)
+	}
+
+	if got := recordMessages(e.Records()); !slices.Equal(got, []string{"b", "d"}) {
+		t.Fatalf("\ngot  %v\nwant [b d]", got)
+	}
+
+	if e.Level() != slog.LevelInfo {
+		t.Fatalf("\ngot  %s\nwant %s", e.Level(), slog.LevelInfo)
+	}
+
+	if n := e.Prune(testTime); n != 0 {
+		t.Fatalf("\ngot  %d\nwant 0", n)
+	}
+}
+
+func TestSErrorsHistogram(t *testing.T) {
+	e := NewTextHandler(nil, nil, WithShardWidth(time.Hour), WithMaxRecords(4, DropOldest))
+	e.Info(testTime.Add(-time.Hour), "dropped")
+	e.Info(testTime, "a")
+	e.Error(testTime.Add(time.Minute), "b")
+	e.Error(testTime.Add(2*time.Hour), "c")
+	e.Info(testTime.Add(3*time.Hour), "d")
+
+	start := testTime.Truncate(time.Hour)
+	want := []Bin{
+		{Start: start, Count: 2, ByLevel: map[slog.Level]int{slog.LevelInfo: 1, slog.LevelError: 1}},
+		{Start: start.Add(2 * time.Hour), Count: 1, ByLevel: map[slog.Level]int{slog.LevelError: 1}},
+	}
+
+	got := e.Histogram(testTime.Add(-2*time.Hour), testTime.Add(2*time.Hour+time.Minute))
+	if len(got) != len(want) {
+		t.Fatalf("\ngot  %v\nwant %v", got, want)
+	}
+
+	for i := range got {
+		if !got[i].Start.Equal(want[i].Start) || got[i].Count != want[i].Count || !maps.Equal(got[i].ByLevel, want[i].ByLevel) {
+			t.Fatalf("\ngot  %v\nwant %v", got, want)
+		}
+	}
+}
@@ -0,0 +1,175 @@
+// Package slo turns the errors in a collection into error budget signals. A Tracker divides the
+// Error level records of the last window by the events observed in it and compares the ratio with
+// the error budget of an SLO target, exposing the burn rate and remaining budget as methods and as
+// Prometheus metrics.
+package slo
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/chadeldridge/serrors"
+)
+
+// buckets is how many slices the window is divided into when counting events
+const buckets = 60
+
+// Config configures a Tracker
+type Config struct {
+	// Name labels the metrics so several SLOs can share an endpoint
+	Name string
+	// Target is the fraction of events that must succeed, such as 0.999
+	Target float64
+	// Window is the period the error ratio is measured over, such as 30 days
+	Window time.Duration
+}
+
+// Tracker measures a collection against an SLO. Records at slog.LevelError or above whose time
+// is within the window count as errors. The events they are a share of, such as requests served,
+// are counted with Observe. It is safe for concurrent use.
+type Tracker struct {
+	cfg Config
+	e   *serrors.SErrors
+	// now returns the current time, replaced in tests
+	now func() time.Time
+
+	mu sync.Mutex
+	// events are counted per slice of the window, oldest first
+	events []bucket
+}
+
+// bucket counts the events observed in the slice of the window starting at start
+type bucket struct {
+	start time.Time
+	n     int
+}
+
+// New creates a Tracker of e against cfg. Target must be between 0 and 1 and Window positive.
+func New(e *serrors.SErrors, cfg Config) (*Tracker, error) {
+	if cfg.Target <= 0 || cfg.Target >= 1 {
+		return nil, fmt.Errorf("slo: target %g is not between 0 and 1", cfg.Target)
+	}
+
+	if cfg.Window <= 0 {
+		return nil, errors.New("slo: window must be positive")
+	}
+
+	return &Tracker{cfg: cfg, e: e, now: time.Now}, nil
+}
+
+// Observe counts n events, successful or not, at the current time
+func (t *Tracker) Observe(n int) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	now := t.now()
+	t.prune(now)
+	start := now.Truncate(t.cfg.Window / buckets)
+	if i := len(t.events) - 1; i >= 0 && t.events[i].start.Equal(start) {
+		t.events[i].n += n
+		return
+	}
+
+	t.events = append(t.events, bucket{start: start, n: n})
+}
+
+// prune drops the buckets that ended before the window. t.mu must be held.
+func (t *Tracker) prune(now time.Time) {
+	cutoff := now.Add(-t.cfg.Window)
+	i := 0
+	for i < len(t.events) && !t.events[i].start.Add(t.cfg.Window/buckets).After(cutoff) {
+		i++
+	}
+
+	t.events = t.events[i:]
+}
+
+// Counts returns the errors in the collection, spilled ones included, and the events observed
+// within the window
+func (t *Tracker) Counts() (errs, events int) {
+	now := t.now()
+	cutoff := now.Add(-t.cfg.Window)
+	for r, err := range t.e.Each() {
+		if err == nil && r.Level >= slog.LevelError && r.Time.After(cutoff) && !r.Time.After(now) {
+			errs++
+		}
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.prune(now)
+	for _, b := range t.events {
+		events += b.n
+	}
+
+	return errs, events
+}
+
+// ErrorRatio returns the share of events in the window that were errors, 0 when there were none
+func (t *Tracker) ErrorRatio() float64 {
+	errs, events := t.Counts()
+	if events == 0 {
+		return 0
+	}
+
+	return float64(errs) / float64(events)
+}
+
+// BurnRate returns how fast the error budget is being spent: 1 spends exactly the budget over the
+// window, 2 spends it in half the window
+func (t *Tracker) BurnRate() float64 {
+	return t.ErrorRatio() / (1 - t.cfg.Target)
+}
+
+// RemainingBudget returns the share of the error budget left in the window, 1 when no errors
+// occurred. It is negative once the SLO is breached.
+func (t *Tracker) RemainingBudget() float64 {
+	return 1 - t.BurnRate()
+}
+
+// WriteMetrics writes the Tracker's gauges to w in the Prometheus text exposition format
+func (t *Tracker) WriteMetrics(w io.Writer) error {
+	errs, events := t.Counts()
+	ratio := 0.0
+	if events > 0 {
+		ratio = float64(errs) / float64(events)
+	}
+	burn := ratio / (1 - t.cfg.Target)
+
+	metrics := []struct {
+		name, help string
+		v          float64
+	}{
+		{"serrors_slo_target", "Fraction of events that must succeed.", t.cfg.Target},
+		{"serrors_slo_window_seconds", "Period the SLO is measured over.", t.cfg.Window.Seconds()},
+		{"serrors_slo_errors", "Error records in the window.", float64(errs)},
+		{"serrors_slo_events", "Events observed in the window.", float64(events)},
+		{"serrors_slo_error_ratio", "Share of events in the window that were errors.", ratio},
+		{"serrors_slo_burn_rate", "Rate the error budget is spent at, 1 spends it over the window.", burn},
+		{"serrors_slo_budget_remaining", "Share of the error budget left in the window.", 1 - burn},
+	}
+
+	for _, m := range metrics {
+		_, err := fmt.Fprintf(w, "# HELP %s %s\n# TYPE %s gauge\n%s{slo=%q} %g\n",
+			m.name, m.help, m.name, m.name, t.cfg.Name, m.v)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Handler returns an http.Handler serving the metrics of the Tracker for Prometheus to scrape
+func (t *Tracker) Handler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+		t.WriteMetrics(w)
+	})
+}
@@ -0,0 +1,102 @@
+package slo
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/chadeldridge/serrors"
+)
+
+var testTime = time.Date(2000, 1, 2, 3, 4, 5, 0, time.UTC)
+
+func TestTracker(t *testing.T) {
+	e := serrors.New(io.Discard, nil)
+	tr, err := New(e, Config{Name: "api", Target: 0.99, Window: time.Hour})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	now := testTime
+	tr.now = func() time.Time { return now }
+
+	now = testTime.Add(-2 * time.Hour)
+	tr.Observe(1000)
+	e.Error(now, "outside the window")
+
+	now = testTime
+	tr.Observe(400)
+	now = testTime.Add(30 * time.Minute)
+	tr.Observe(600)
+	e.Error(testTime, "a")
+	e.Error(testTime.Add(time.Minute), "b")
+	e.Warn(testTime, "not an error")
+
+	if errs, events := tr.Counts(); errs != 2 || events != 1000 {
+		t.Fatalf("\ngot  %d errors, %d events\nwant 2, 1000", errs, events)
+	}
+
+	tests := []struct {
+		name string
+		got  float64
+		want float64
+	}{
+		{"error ratio", tr.ErrorRatio(), 0.002},
+		{"burn rate", tr.BurnRate(), 0.2},
+		{"remaining budget", tr.RemainingBudget(), 0.8},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if d := test.got - test.want; d > 1e-9 || d < -1e-9 {
+				t.Fatalf("\ngot  %g\nwant %g", test.got, test.want)
+			}
+		})
+	}
+
+	w := httptest.NewRecorder()
+	tr.Handler().ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	for _, want := range []string{
+		"# TYPE serrors_slo_burn_rate gauge\n",
+		`serrors_slo_errors{slo="api"} 2` + "\n",
+		`serrors_slo_error_ratio{slo="api"} 0.002` + "\n",
+	} {
+		if !strings.Contains(w.Body.String(), want) {
+			t.Fatalf("\ngot  %s\nwant %s", w.Body.String(), want)
+		}
+	}
+}
+
+func TestNewInvalid(t *testing.T) {
+	e := serrors.New(io.Discard, nil)
+	for _, cfg := range []Config{{Target: 1, Window: time.Hour}, {Target: 0.9}} {
+		if _, err := New(e, cfg); err == nil {
+			t.Fatalf("\ngot  nil\nwant error for %+v", cfg)
+		}
+	}
+}
+
+func TestTrackerSpilled(t *testing.T) {
+	e := serrors.New(io.Discard, nil, serrors.WithArena(2))
+	tr, err := New(e, Config{Name: "api", Target: 0.99, Window: time.Hour})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tr.now = func() time.Time { return testTime }
+	tr.Observe(100)
+	for range 5 {
+		e.Error(testTime, "failed")
+	}
+
+	if e.Spilled() == 0 {
+		t.Fatal("\ngot  0 spilled\nwant some")
+	}
+
+	if errs, events := tr.Counts(); errs != 5 || events != 100 {
+		t.Fatalf("\ngot  %d errors, %d events\nwant 5, 100", errs, events)
+	}
+}
@@ -0,0 +1,85 @@
+package serrors
+
+import (
+	"log/slog"
+	"maps"
+	"slices"
+)
+
+// ReadOnlySErrors is a frozen copy of an SErrors taken by Snapshot. Records added to the original
+// afterwards do not show up in it, its records do not expire with WithRecordTTL, and it is safe to
+// use from any number of goroutines.
+type ReadOnlySErrors struct {
+	e *SErrors
+}
+
+// Snapshot returns a read-only copy of the records in memory, with the same handlers and render
+// settings, to hand to other goroutines or long-lived reporters while e keeps collecting. Spilled
+// records are not included.
+func (e *SErrors) Snapshot() ReadOnlySErrors {
+	e.expire()
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+
+	c := e.emptyCopy()
+	// A snapshot is frozen, so its records must not expire after it is taken.
+	c.ttl, c.clock = 0, nil
+	c.routes = e.routes
+	c.level = e.level
+	c.records = e.copyRecords()
+	c.seqs = slices.Clone(e.seqs)
+	c.reindex()
+	return ReadOnlySErrors{e: c}
+}
+
+// emptyCopy returns a new SErrors without records that renders like e. Subscribers, spill files,
+// the WAL and payloads are not copied. e.mu must be held.
+func (e *SErrors) emptyCopy() *SErrors {
+	return &SErrors{
+		json:          e.json,
+		opts:          e.opts,
+		logger:        e.logger,
+		tees:          e.tees,
+		verbosity:     e.verbosity,
+		keyAttrs:      e.keyAttrs,
+		attrOrder:     e.attrOrder,
+		lineWidth:     e.lineWidth,
+		lineIndent:    e.lineIndent,
+		levelSymbols:  e.levelSymbols,
+		locale:        e.locale,
+		ttl:           e.ttl,
+		clock:         e.clock,
+		profile:       e.profile,
+		profileOpts:   e.profileOpts,
+		canonical:     e.canonical,
+		compression:   e.compression,
+		emptyJSON:     e.emptyJSON,
+		meta:          slices.Clone(e.meta),
+		metaBlock:     e.metaBlock,
+		minLevel:      e.minLevel,
+		redactKeys:    e.redactKeys,
+		fingerprinter: e.fingerprinter,
+		errFormat:     e.errFormat,
+		subs:          map[chan slog.Record]struct{}{},
+		done:          make(chan struct{}),
+		records:       []slog.Record{},
+		seq:           e.seq,
+		shards:        shards{width: e.shards.width},
+		resolved:      maps.Clone(e.resolved),
+	}
+}
+
+// Level returns the highest slog.Level of the records, see SErrors.Level
+func (s ReadOnlySErrors) Level() slog.Level { return s.e.Level() }
+
+// Records returns a copy of the records, see SErrors.Records
+func (s ReadOnlySErrors) Records() []slog.Record { return s.e.Records() }
+
+// String returns all records as a single string, see SErrors.String
+func (s ReadOnlySErrors) String() string { return s.e.String() }
+
+// MarshalJSON converts the records to a JSON array, see SErrors.MarshalJSON
+func (s ReadOnlySErrors) MarshalJSON() ([]byte, error) { return s.e.MarshalJSON() }
+
+// Log writes all records using the logger handler, tees and routes of the original SErrors
+func (s ReadOnlySErrors) Log() error { return s.e.Log() }
@@ -0,0 +1,101 @@
+package serrors
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestSErrorsSnapshot(t *testing.T) {
+	got := bytes.NewBuffer(nil)
+	e := NewTextHandler(got, nil)
+	e.RenderVerbosity(VerbosityQuiet)
+	e.Warn(testTime, "a")
+
+	s := e.Snapshot()
+	e.Error(testTime, "b")
+	e.Annotate(0, slog.Int("x", 1))
+
+	want := "level=WARN msg=a\n"
+	if s.String() != want {
+		t.Fatalf("\ngot  %s\nwant %s", s.String(), want)
+	}
+
+	if err := s.Log(); err != nil {
+		t.Fatalf("\ngot  %s\nwant nil", err.Error())
+	}
+
+	if got.String() != want {
+		t.Fatalf("\ngot  %s\nwant %s", got, want)
+	}
+}
+
+func TestSErrorsSnapshotConcurrent(t *testing.T) {
+	e := New(nil, nil)
+	e.Warn(testTime, "a")
+	s := e.Snapshot()
+
+	want := `[{"time":"2000-01-02T03:04:05Z","level":"WARN","msg":"a"}]`
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 50; j++ {
+				e.Error(testTime, "b")
+				b, err := json.Marshal(s)
+				if err != nil || string(b) != want {
+					t.Errorf("\ngot  %s %v\nwant %s", b, err, want)
+					return
+				}
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+func TestSErrorsSnapshotClock(t *testing.T) {
+	e := NewTextHandler(nil, nil, WithClock(fixedClock(testTime)), WithRecordTTL(time.Hour))
+	e.Warn(testTime, "a")
+
+	if n := len(e.Snapshot().Records()); n != 1 {
+		t.Fatalf("\ngot  %d snapshot records\nwant 1", n)
+	}
+
+	if n := len(e.Filter(func(slog.Record) bool { return true }).Records()); n != 1 {
+		t.Fatalf("\ngot  %d filtered records\nwant 1", n)
+	}
+}
+
+func TestSErrorsSnapshotFrozen(t *testing.T) {
+	clock := fixedClock(testTime)
+	routed := bytes.NewBuffer(nil)
+	all := func(slog.Record) bool { return true }
+	e := New(io.Discard, nil, WithClock(&clock), WithRecordTTL(time.Hour),
+		WithRoute(all, slog.NewTextHandler(routed, nil)))
+	e.Error(testTime, "a")
+	e.Warn(testTime.Add(30*time.Minute), "b")
+	s := e.Snapshot()
+
+	clock = fixedClock(testTime.Add(80 * time.Minute))
+	if n, l := len(s.Records()), s.Level(); n != 2 || l != slog.LevelError {
+		t.Fatalf("\ngot  %d records at %s\nwant 2 at %s", n, l, slog.LevelError)
+	}
+
+	if n := len(e.Records()); n != 1 {
+		t.Fatalf("\ngot  %d records\nwant 1", n)
+	}
+
+	if err := s.Log(); err != nil {
+		t.Fatal(err)
+	}
+
+	if n := strings.Count(routed.String(), "\n"); n != 2 {
+		t.Fatalf("\ngot  %s\nwant 2 routed records", routed)
+	}
+}
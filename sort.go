@@ -0,0 +1,80 @@
+package serrors
+
+import (
+	"cmp"
+	"log/slog"
+	"slices"
+)
+
+// SortByTime orders the records in memory from the oldest, keeping the order of records with the
+// same time. Spilled records are not moved.
+func (e *SErrors) SortByTime() {
+	e.sortFunc(func(a, b slog.Record) int { return a.Time.Compare(b.Time) })
+}
+
+// SortByLevel orders the records in memory from the highest level, keeping the order of records
+// with the same level. Spilled records are not moved.
+func (e *SErrors) SortByLevel() {
+	e.sortFunc(func(a, b slog.Record) int { return cmp.Compare(b.Level, a.Level) })
+}
+
+// sortFunc stably sorts the records in memory with fn
+func (e *SErrors) sortFunc(fn func(a, b slog.Record) int) {
+	e.expire()
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	idx := make([]int, len(e.records))
+	for i := range idx {
+		idx[i] = i
+	}
+	slices.SortStableFunc(idx, func(a, b int) int { return fn(e.records[a], e.records[b]) })
+
+	rs, seqs := make([]slog.Record, len(idx)), make([]uint64, len(idx))
+	for i, j := range idx {
+		rs[i], seqs[i] = e.records[j], e.seqs[j]
+	}
+	e.records, e.seqs = rs, seqs
+	e.invalidate()
+}
+
+// Merge adds the records of errs among the records of e by time, as Append does but interleaved,
+// so two collections that are each in time order stay in time order. A record of e comes first
+// when the times are equal. errs is not changed.
+func (e *SErrors) Merge(errs *SErrors) {
+	if e.discard {
+		return
+	}
+
+	rs, resolved, l := errs.recordsAndLevel()
+	rs = e.provenanced(rs, errs)
+
+	defer e.syncWAL()
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	rsSeqs := e.newSeqs(len(rs))
+	e.journalRecords(rs, rsSeqs)
+	e.markResolved(rsSeqs, resolved)
+	merged := make([]slog.Record, 0, len(e.records)+len(rs))
+	seqs := make([]uint64, 0, cap(merged))
+	i, j := 0, 0
+	for i < len(e.records) && j < len(rs) {
+		if rs[j].Time.Before(e.records[i].Time) {
+			merged, seqs = append(merged, rs[j]), append(seqs, rsSeqs[j])
+			j++
+			continue
+		}
+
+		merged, seqs = append(merged, e.records[i]), append(seqs, e.seqs[i])
+		i++
+	}
+	merged = append(append(merged, e.records[i:]...), rs[j:]...)
+	seqs = append(append(seqs, e.seqs[i:]...), rsSeqs[j:]...)
+
+	e.level = max(e.level, l)
+	e.records, e.seqs = merged, seqs
+	e.indexRecords(rs, rsSeqs)
+	e.invalidate()
+	e.publish(rs...)
+}
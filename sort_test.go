@@ -0,0 +1,56 @@
+package serrors
+
+import (
+	"log/slog"
+	"testing"
+	"time"
+)
+
+// messages returns the messages of the records of e
+func messages(e *SErrors) string {
+	var s string
+	for _, r := range e.Records() {
+		s += r.Message
+	}
+
+	return s
+}
+
+func TestSErrorsSort(t *testing.T) {
+	e := New(nil, nil)
+	e.Info(testTime.Add(2*time.Second), "a")
+	e.Error(testTime, "b")
+	e.Warn(testTime.Add(time.Second), "c")
+	e.Error(testTime.Add(time.Second), "d")
+
+	e.SortByTime()
+	if got := messages(e); got != "bcda" {
+		t.Fatalf("\ngot  %s\nwant bcda", got)
+	}
+
+	e.SortByLevel()
+	if got := messages(e); got != "bdca" {
+		t.Fatalf("\ngot  %s\nwant bdca", got)
+	}
+}
+
+func TestSErrorsMerge(t *testing.T) {
+	e := New(nil, nil)
+	e.Info(testTime, "a")
+	e.Info(testTime.Add(2*time.Second), "c")
+	e.Info(testTime.Add(4*time.Second), "e")
+
+	o := New(nil, nil)
+	o.Error(testTime.Add(time.Second), "b")
+	o.Info(testTime.Add(2*time.Second), "d")
+	o.Info(testTime.Add(5*time.Second), "f")
+
+	e.Merge(o)
+	if got := messages(e); got != "abcdef" {
+		t.Fatalf("\ngot  %s\nwant abcdef", got)
+	}
+
+	if e.Level() != slog.LevelError || messages(o) != "bdf" {
+		t.Fatalf("\ngot  %s, %s\nwant ERROR, bdf", e.Level(), messages(o))
+	}
+}
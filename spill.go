@@ -0,0 +1,251 @@
+package serrors
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+
+	"github.com/chadeldridge/serrors/internal/wire"
+)
+
+// spill holds the state of WithSpillDir
+type spill struct {
+	// dir the spill file is created in when store is nil
+	dir string
+	// limit is the most records kept in memory
+	limit int
+	// store holds the spilled records, oldest first
+	store Store
+	// temp is set when store is a temp file created in dir, which RemoveSpill deletes
+	temp bool
+	// count is the number of records in store
+	count int
+	// seqs holds the sequence number of each record in store, see Since
+	seqs []uint64
+	// level is the highest level in store
+	level slog.Level
+	// err is the first error spilling records. Once set, the records that would be spilled are
+	// dropped instead, so memory stays bounded.
+	err error
+	// dropped counts the records dropped because spilling failed, see Dropped
+	dropped int
+}
+
+// WithSpillDir keeps at most memLimit records in memory. When the limit is exceeded the oldest
+// records are written to a temp file in dir, leaving the newest memLimit/2 in memory. Log and
+// WriteNDJSON stream the spilled records back before the ones in memory, and Level includes them.
+// Other methods only see the records in memory. Call RemoveSpill to delete the file. If the file
+// cannot be written, the records that would be spilled are dropped and counted, see Dropped, and
+// the error is kept for SpillErr and Close.
+func WithSpillDir(dir string, memLimit int) Option {
+	return func(e *SErrors) {
+		e.spill = &spill{dir: dir, limit: max(memLimit, 1)}
+	}
+}
+
+// WithSpillStore is WithSpillDir writing the spilled records to s, which should be empty, instead
+// of a temp file, so they can be kept in a database. It replaces WithSpillDir and WithArena if
+// they are used.
+func WithSpillStore(s Store, memLimit int) Option {
+	return func(e *SErrors) {
+		e.spill = &spill{limit: max(memLimit, 1), store: s}
+	}
+}
+
+// Spilled returns the number of records written to the spill store
+func (e *SErrors) Spilled() int {
+	if e.spill == nil {
+		return 0
+	}
+
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+
+	return e.spill.count
+}
+
+// RemoveSpill prunes the spilled records from the spill store, deleting the temp file created by
+// WithSpillDir
+func (e *SErrors) RemoveSpill() error {
+	if e.spill == nil {
+		return nil
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	return e.removeSpill()
+}
+
+// removeSpill is RemoveSpill with e.mu held for writing
+func (e *SErrors) removeSpill() error {
+	if e.spill.store == nil {
+		return nil
+	}
+
+	n := e.spill.count
+	e.spill.count, e.spill.seqs = 0, nil
+	if !e.spill.temp {
+		return e.spill.store.Prune(n)
+	}
+
+	f := e.spill.store.(*FileStore)
+	e.spill.store, e.spill.temp = nil, false
+	err := f.Close()
+	if rerr := os.Remove(f.Name()); rerr != nil && !errors.Is(rerr, os.ErrNotExist) {
+		err = errors.Join(err, rerr)
+	}
+
+	return err
+}
+
+// spillOldest writes the oldest records to the spill store once the limit is exceeded, dropping
+// the ones that cannot be written. e.mu must be held.
+func (e *SErrors) spillOldest() {
+	if e.spill == nil || len(e.records) <= e.spill.limit {
+		return
+	}
+
+	if e.spill.store == nil && e.spill.err == nil {
+		f, err := newSpillFile(e.spill.dir)
+		if err != nil {
+			e.spill.err = err
+		}
+		e.spill.store, e.spill.temp = f, err == nil
+	}
+
+	cut := len(e.records) - e.spill.limit/2
+	n := 0
+	for _, r := range e.records[:cut] {
+		if e.spill.err != nil {
+			break
+		}
+
+		if err := e.spill.store.AppendRecord(r); err != nil {
+			e.spill.err = err
+			break
+		}
+
+		if e.spill.count == 0 || r.Level > e.spill.level {
+			e.spill.level = r.Level
+		}
+		e.spill.count++
+		n++
+	}
+
+	for i, r := range e.records[:cut] {
+		e.shards.remove(e.seqs[i], r)
+		if i >= n {
+			e.journalOp(walDelete, e.seqs[i])
+			delete(e.resolved, e.seqs[i])
+		}
+	}
+	e.spill.dropped += cut - n
+
+	// Copy the kept records so the spilled and dropped ones can be garbage collected.
+	e.spill.seqs = append(e.spill.seqs, e.seqs[:n]...)
+	e.records = append([]slog.Record{}, e.records[cut:]...)
+	e.seqs = append([]uint64{}, e.seqs[cut:]...)
+	if cut > n {
+		e.recomputeLevel()
+	}
+	e.invalidate()
+}
+
+// SpillErr returns the first error writing to the spill store, or nil. Once it is set, the records
+// that would be spilled are dropped instead, see Dropped. Reading the collection is not affected:
+// the records spilled before the error and the records in memory are still returned.
+func (e *SErrors) SpillErr() error {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+
+	if e.spill == nil || e.spill.err == nil {
+		return nil
+	}
+
+	return fmt.Errorf("serrors: spilling records: %w", e.spill.err)
+}
+
+// newSpillFile creates a temp file store in dir
+func newSpillFile(dir string) (*FileStore, error) {
+	f, err := os.CreateTemp(dir, "serrors-spill-*.ndjson")
+	if err != nil {
+		return nil, err
+	}
+	f.Close()
+
+	s, err := NewFileStore(f.Name(), false)
+	if err != nil {
+		os.Remove(f.Name())
+	}
+
+	return s, err
+}
+
+// eachRecord calls fn for the spilled records followed by the records in memory, stopping at the
+// first error fn returns. If the spilled records cannot be read, the records in memory are still
+// passed to fn and the read error is returned after them.
+func (e *SErrors) eachRecord(fn func(slog.Record) error) error {
+	e.expire()
+	e.mu.RLock()
+	rs := e.copyRecords()
+	var store Store
+	var count int
+	if e.spill != nil {
+		store, count = e.spill.store, e.spill.count
+	}
+	e.mu.RUnlock()
+
+	var readErr error
+	if store != nil && count > 0 {
+		var fnErr error
+		readErr = store.LoadRange(0, count, func(r slog.Record) error {
+			fnErr = fn(r)
+			return fnErr
+		})
+		if fnErr != nil {
+			return fnErr
+		}
+	}
+
+	for _, r := range rs {
+		if err := fn(r); err != nil {
+			return err
+		}
+	}
+
+	if readErr != nil {
+		return fmt.Errorf("serrors: reading spilled records: %w", readErr)
+	}
+
+	return nil
+}
+
+// allRecords returns the spilled records followed by copies of the records in memory. If the
+// spilled records cannot be read, the ones read and the records in memory are returned with the
+// error.
+func (e *SErrors) allRecords() ([]slog.Record, error) {
+	var rs []slog.Record
+	err := e.eachRecord(func(r slog.Record) error {
+		rs = append(rs, r)
+		return nil
+	})
+
+	return rs, err
+}
+
+// spilledRecord decodes the spilled record in line and passes it to fn
+func spilledRecord(line []byte, fn func(slog.Record) error) error {
+	var w wire.Record
+	if err := wire.Unmarshal(line, &w); err != nil {
+		return err
+	}
+
+	r, err := w.ToRecord()
+	if err != nil {
+		return err
+	}
+
+	return fn(r)
+}
@@ -0,0 +1,132 @@
+package serrors
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSErrorsWithSpillDir(t *testing.T) {
+	dir := t.TempDir()
+	got := bytes.NewBuffer(nil)
+	e := NewTextHandler(got, nil, WithSpillDir(dir, 4))
+
+	var want string
+	for i := 0; i < 11; i++ {
+		e.Info(testTime, "m", slog.Int("i", i), slog.Duration("d", time.Second), slog.Group("g", slog.Bool("b", true)))
+		want += fmt.Sprintf("time=2000-01-02T03:04:05.000Z level=INFO msg=m i=%d d=1s g.b=true\n", i)
+	}
+	e.Error(testTime, "m")
+	want += "time=2000-01-02T03:04:05.000Z level=ERROR msg=m\n"
+
+	if len(e.records) > 4 || e.Spilled()+len(e.records) != 12 {
+		t.Fatalf("\ngot  %d in memory, %d spilled\nwant at most 4 in memory, 12 total", len(e.records), e.Spilled())
+	}
+
+	if e.Level() != slog.LevelError {
+		t.Fatalf("\ngot  %s\nwant %s", e.Level(), slog.LevelError)
+	}
+
+	if err := e.Log(); err != nil {
+		t.Fatalf("\ngot  %s\nwant nil", err.Error())
+	}
+
+	if got.String() != want {
+		t.Fatalf("\ngot  %s\nwant %s", got, want)
+	}
+
+	got.Reset()
+	if err := e.WriteNDJSON(got); err != nil {
+		t.Fatalf("\ngot  %s\nwant nil", err.Error())
+	}
+
+	if n := bytes.Count(got.Bytes(), []byte("\n")); n != 12 {
+		t.Fatalf("\ngot  %d\nwant 12", n)
+	}
+
+	if err := e.RemoveSpill(); err != nil {
+		t.Fatalf("\ngot  %s\nwant nil", err.Error())
+	}
+
+	files, _ := os.ReadDir(dir)
+	if len(files) != 0 || e.Spilled() != 0 {
+		t.Fatalf("\ngot  %d files, %d spilled\nwant none", len(files), e.Spilled())
+	}
+}
+
+func TestSErrorsWithSpillDirError(t *testing.T) {
+	got := bytes.NewBuffer(nil)
+	e := NewTextHandler(got, nil, WithSpillDir("/nonexistent/serrors", 4))
+	for _, msg := range []string{"a", "b", "c", "d", "e"} {
+		e.Info(testTime, msg)
+	}
+
+	if e.Dropped() != 3 || e.Spilled() != 0 {
+		t.Fatalf("\ngot  %d dropped, %d spilled\nwant 3, 0", e.Dropped(), e.Spilled())
+	}
+
+	if err := e.SpillErr(); err == nil {
+		t.Fatalf("\ngot  nil\nwant spill error")
+	}
+
+	if err := e.Log(); err != nil {
+		t.Fatal(err)
+	}
+
+	want := "time=2000-01-02T03:04:05.000Z level=INFO msg=d\ntime=2000-01-02T03:04:05.000Z level=INFO msg=e\n"
+	if got.String() != want {
+		t.Fatalf("\ngot  %s\nwant %s", got, want)
+	}
+}
+
+// failingStore is a MemoryStore failing to append once it holds n records, and to load if
+// loadErr is set
+type failingStore struct {
+	*MemoryStore
+	n       int
+	loadErr error
+}
+
+func (s *failingStore) LoadRange(from, to int, fn func(slog.Record) error) error {
+	if s.loadErr != nil {
+		return s.loadErr
+	}
+
+	return s.MemoryStore.LoadRange(from, to, fn)
+}
+
+func (s *failingStore) AppendRecord(r slog.Record) error {
+	if s.n == 0 {
+		return errors.New("disk full")
+	}
+	s.n--
+
+	return s.MemoryStore.AppendRecord(r)
+}
+
+func TestSErrorsWithSpillStoreError(t *testing.T) {
+	e := New(io.Discard, nil, WithSpillStore(&failingStore{MemoryStore: NewMemoryStore(), n: 2}, 4))
+	e.Error(testTime, "a")
+	for i := range 99 {
+		e.Info(testTime, fmt.Sprint(i))
+	}
+
+	if len(e.records) > 4 || e.Spilled() != 2 || e.Dropped()+e.Spilled()+len(e.records) != 100 {
+		t.Fatalf("\ngot  %d in memory, %d spilled, %d dropped\nwant at most 4 in memory, 2 spilled, 100 total", len(e.records), e.Spilled(), e.Dropped())
+	}
+
+	if e.Level() != slog.LevelError {
+		t.Fatalf("\ngot  %s\nwant %s", e.Level(), slog.LevelError)
+	}
+
+	if err := e.Close(context.Background()); err == nil || !strings.Contains(err.Error(), "disk full") {
+		t.Fatalf("\ngot  %v\nwant disk full", err)
+	}
+}
@@ -0,0 +1,50 @@
+package serrors
+
+import (
+	"log/slog"
+	"strings"
+)
+
+// WithStackTraces adds a StackKey attr holding the stack trace of the goroutine adding the record
+// to every record kept at or above min, unless it already has one, for post-mortem debugging. The
+// trace starts at the code calling serrors. Capturing a trace is slow, so use a high min on hot
+// paths.
+func WithStackTraces(min slog.Level) Option {
+	return func(e *SErrors) {
+		e.stackTraces, e.stackMin = true, min
+	}
+}
+
+// withStack returns r with a stack trace if e uses WithStackTraces and r is at its level
+func (e *SErrors) withStack(r slog.Record) slog.Record {
+	if !e.stackTraces || r.Level < e.stackMin {
+		return r
+	}
+
+	if _, ok := GetAttrPath(r, StackKey); ok {
+		return r
+	}
+
+	r = r.Clone()
+	r.AddAttrs(slog.String(StackKey, stackFrom(2, isInternalFrame)))
+	return r
+}
+
+// isInternalFrame reports whether function is part of adding a record: a method of the serrors
+// types records are added through, slog passing records to Handler, or sync.Once running Op.End
+func isInternalFrame(function string) bool {
+	for _, p := range []string{
+		"github.com/chadeldridge/serrors.(*SErrors).",
+		"github.com/chadeldridge/serrors.(*Op).",
+		"github.com/chadeldridge/serrors.(*MultiTenant).",
+		"github.com/chadeldridge/serrors.(*collectHandler).",
+		"log/slog.",
+		"sync.",
+	} {
+		if strings.HasPrefix(function, p) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,35 @@
+package serrors
+
+import (
+	"log/slog"
+	"strings"
+	"testing"
+)
+
+func TestSErrorsWithStackTraces(t *testing.T) {
+	e := New(nil, nil, WithStackTraces(slog.LevelWarn))
+	e.Info(testTime, "a")
+	e.Warn(testTime, "b")
+	e.Error(testTime, "c", slog.String(StackKey, "given"))
+	slog.New(e.Handler()).Error("d")
+
+	stacks := make([]string, 0, 4)
+	for _, r := range e.Records() {
+		v, ok := GetAttrPath(r, StackKey)
+		if !ok {
+			v = slog.StringValue("")
+		}
+		stacks = append(stacks, v.String())
+	}
+
+	if stacks[0] != "" || stacks[2] != "given" {
+		t.Fatalf("\ngot  %q\nwant no stack on a and the given one on c", stacks)
+	}
+
+	for _, s := range []string{stacks[1], stacks[3]} {
+		first, _, _ := strings.Cut(s, "\n")
+		if first != "github.com/chadeldridge/serrors.TestSErrorsWithStackTraces" {
+			t.Fatalf("\ngot  %s\nwant the test as the first frame", s)
+		}
+	}
+}
@@ -0,0 +1,62 @@
+package serrors
+
+import (
+	"log/slog"
+	"time"
+)
+
+// Stats summarizes a collection for health endpoints, see SErrors.Stats
+type Stats struct {
+	// Total is the number of records, including spilled ones
+	Total int `json:"total"`
+	// Level is the highest level of the records, see SErrors.Level
+	Level slog.Level `json:"level"`
+	// ByLevel is the number of records of each level
+	ByLevel map[slog.Level]int `json:"by_level"`
+	// First and Last are the earliest and latest record times, zero if there are no records
+	First time.Time `json:"first"`
+	Last  time.Time `json:"last"`
+}
+
+// Count returns the number of records held, including spilled ones
+func (e *SErrors) Count() int {
+	e.expire()
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+
+	n := len(e.records)
+	if e.spill != nil {
+		n += e.spill.count
+	}
+
+	return n
+}
+
+// CountByLevel returns the number of records of each level, including spilled ones. Spilled
+// records that cannot be read are left out.
+func (e *SErrors) CountByLevel() map[slog.Level]int {
+	return e.Stats().ByLevel
+}
+
+// Stats returns the number of records, their highest level, their count per level and the times
+// of the first and last records, including spilled ones, without rendering them. Spilled records
+// that cannot be read are left out.
+func (e *SErrors) Stats() Stats {
+	s := Stats{ByLevel: map[slog.Level]int{}}
+	e.eachRecord(func(r slog.Record) error {
+		s.Total++
+		s.ByLevel[r.Level]++
+		if s.First.IsZero() || r.Time.Before(s.First) {
+			s.First = r.Time
+		}
+
+		if r.Time.After(s.Last) {
+			s.Last = r.Time
+		}
+
+		return nil
+	})
+	s.Level = e.Level()
+
+	return s
+}
@@ -0,0 +1,35 @@
+package serrors
+
+import (
+	"encoding/json"
+	"log/slog"
+	"testing"
+	"time"
+)
+
+func TestSErrorsStats(t *testing.T) {
+	e := New(nil, nil, WithArena(2))
+	e.Info(testTime.Add(time.Minute), "a")
+	e.Error(testTime, "b")
+	e.Info(testTime.Add(time.Hour), "c")
+	e.Warn(testTime.Add(time.Second), "d")
+
+	if e.Count() != 4 {
+		t.Fatalf("\ngot  %d\nwant 4", e.Count())
+	}
+
+	if got := e.CountByLevel(); len(got) != 3 || got[slog.LevelInfo] != 2 {
+		t.Fatalf("\ngot  %v\nwant INFO:2 WARN:1 ERROR:1", got)
+	}
+
+	got, _ := json.Marshal(e.Stats())
+	want := `{"total":4,"level":"ERROR","by_level":{"ERROR":1,"INFO":2,"WARN":1},` +
+		`"first":"2000-01-02T03:04:05Z","last":"2000-01-02T04:04:05Z"}`
+	if string(got) != want {
+		t.Fatalf("\ngot  %s\nwant %s", got, want)
+	}
+
+	if s := New(nil, nil).Stats(); s.Total != 0 || !s.First.IsZero() {
+		t.Fatalf("\ngot  %+v\nwant zero", s)
+	}
+}
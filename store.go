@@ -0,0 +1,355 @@
+package serrors
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/chadeldridge/serrors/internal/wire"
+)
+
+// Store persists records in the order they are appended. It backs spilled records, see
+// WithSpillStore, and the WAL, see WithWALStore, so collections can be kept in a database by
+// implementing it. Positions count from the oldest record not yet pruned. A Store must be safe to
+// call from multiple goroutines.
+type Store interface {
+	// AppendRecord stores r after the records already stored
+	AppendRecord(r slog.Record) error
+	// LoadRange calls fn for the records at positions from up to but not including to, oldest
+	// first, stopping at the first error. A negative to loads every record after from.
+	LoadRange(from, to int, fn func(slog.Record) error) error
+	// Prune removes the oldest n records
+	Prune(n int) error
+}
+
+// encodeRecord returns r as a line of NDJSON
+func encodeRecord(r slog.Record) ([]byte, error) {
+	b, err := json.Marshal(wire.FromRecord(r))
+	if err != nil {
+		return nil, err
+	}
+
+	return append(b, '\n'), nil
+}
+
+// MemoryStore is a Store holding records encoded in one contiguous buffer, so a large number of
+// them are a few allocations instead of the attrs of every record. It is used by WithArena.
+type MemoryStore struct {
+	mu sync.RWMutex
+	// buf holds the records as NDJSON
+	buf []byte
+	// ends are the offsets in buf where each record ends
+	ends []int
+}
+
+// NewMemoryStore creates an empty MemoryStore
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{}
+}
+
+// AppendRecord stores r, see Store
+func (s *MemoryStore) AppendRecord(r slog.Record) error {
+	b, err := encodeRecord(r)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.buf = append(s.buf, b...)
+	s.ends = append(s.ends, len(s.buf))
+	return nil
+}
+
+// LoadRange calls fn for the records from up to but not including to, see Store
+func (s *MemoryStore) LoadRange(from, to int, fn func(slog.Record) error) error {
+	s.mu.RLock()
+	// The buffer is only appended to, or replaced by Prune, so these do not change once released.
+	buf, ends := s.buf, s.ends
+	s.mu.RUnlock()
+
+	if to < 0 || to > len(ends) {
+		to = len(ends)
+	}
+
+	for i := max(from, 0); i < to; i++ {
+		start := 0
+		if i > 0 {
+			start = ends[i-1]
+		}
+
+		if err := spilledRecord(bytes.TrimSuffix(buf[start:ends[i]], []byte("\n")), fn); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Prune removes the oldest n records, see Store
+func (s *MemoryStore) Prune(n int) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	n = min(n, len(s.ends))
+	if n <= 0 {
+		return nil
+	}
+
+	if n == len(s.ends) {
+		s.buf, s.ends = nil, nil
+		return nil
+	}
+
+	cut := s.ends[n-1]
+	buf := append([]byte{}, s.buf[cut:]...)
+	ends := make([]int, 0, len(s.ends)-n)
+	for _, end := range s.ends[n:] {
+		ends = append(ends, end-cut)
+	}
+
+	s.buf, s.ends = buf, ends
+	return nil
+}
+
+// FileStore is a Store appending records as NDJSON to a file. It is used by WithSpillDir and
+// WithWAL.
+type FileStore struct {
+	mu   sync.Mutex
+	path string
+	f    *os.File
+	// fsync syncs the file after every record
+	fsync bool
+}
+
+// NewFileStore opens the file at path for appending, creating it if needed. Records already in
+// the file are kept, but a partial last line left by a crash mid-write is cut off so new records
+// start on a line of their own. If fsync is true the file is synced to disk after every record,
+// which survives power loss at the cost of speed. Call Close when done.
+func NewFileStore(path string, fsync bool) (*FileStore, error) {
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_RDWR|os.O_APPEND, 0o600)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := truncateTorn(f); err != nil {
+		f.Close()
+		return nil, err
+	}
+
+	return &FileStore{path: path, f: f, fsync: fsync}, nil
+}
+
+// truncateTorn cuts f after its last newline, dropping a partial line at the end
+func truncateTorn(f *os.File) error {
+	fi, err := f.Stat()
+	if err != nil {
+		return err
+	}
+
+	buf := make([]byte, 4096)
+	end := fi.Size()
+	for end > 0 {
+		n := min(end, int64(len(buf)))
+		if _, err := f.ReadAt(buf[:n], end-n); err != nil {
+			return err
+		}
+
+		if i := bytes.LastIndexByte(buf[:n], '\n'); i >= 0 {
+			end = end - n + int64(i) + 1
+			break
+		}
+		end -= n
+	}
+
+	if end == fi.Size() {
+		return nil
+	}
+
+	return f.Truncate(end)
+}
+
+// Name returns the path of the file
+func (s *FileStore) Name() string {
+	return s.path
+}
+
+// AppendRecord stores r, see Store
+func (s *FileStore) AppendRecord(r slog.Record) error {
+	b, err := encodeRecord(r)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.f == nil {
+		return os.ErrClosed
+	}
+
+	if _, err := s.f.Write(b); err != nil {
+		return err
+	}
+
+	if s.fsync {
+		return s.f.Sync()
+	}
+
+	return nil
+}
+
+// LoadRange calls fn for the records from up to but not including to, see Store. A partial last
+// line left by a crash mid-write is ignored.
+func (s *FileStore) LoadRange(from, to int, fn func(slog.Record) error) error {
+	return loadFile(s.path, from, to, fn)
+}
+
+// Prune removes the oldest n records by rewriting the file, see Store
+func (s *FileStore) Prune(n int) error {
+	if n <= 0 {
+		return nil
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.f == nil {
+		return os.ErrClosed
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(s.path), filepath.Base(s.path)+".prune-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	w := bufio.NewWriter(tmp)
+	err = eachLine(s.path, func(i int, line []byte) error {
+		if i < n {
+			return nil
+		}
+
+		_, err := w.Write(append(line, '\n'))
+		return err
+	})
+	if err == nil {
+		err = w.Flush()
+	}
+
+	if cerr := tmp.Close(); err == nil {
+		err = cerr
+	}
+
+	if err != nil {
+		return err
+	}
+
+	if err := os.Rename(tmp.Name(), s.path); err != nil {
+		return err
+	}
+
+	f, err := os.OpenFile(s.path, os.O_WRONLY|os.O_APPEND, 0o600)
+	if err != nil {
+		return err
+	}
+
+	s.f.Close()
+	s.f = f
+	return nil
+}
+
+// Sync commits the records appended so far to disk. The file is not locked while it syncs, so
+// records can be appended meanwhile.
+func (s *FileStore) Sync() error {
+	s.mu.Lock()
+	f := s.f
+	s.mu.Unlock()
+
+	if f == nil {
+		return nil
+	}
+
+	// A file Prune replaced meanwhile is closed. Its records were copied to the new file, which the
+	// next call syncs.
+	if err := f.Sync(); err != nil && !errors.Is(err, os.ErrClosed) {
+		return err
+	}
+
+	return nil
+}
+
+// Close closes the file. Records can still be loaded.
+func (s *FileStore) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.f == nil {
+		return nil
+	}
+
+	err := s.f.Close()
+	s.f = nil
+	return err
+}
+
+// loadFile calls fn for the records of the NDJSON file at path from up to but not including to
+func loadFile(path string, from, to int, fn func(slog.Record) error) error {
+	errStop := errors.New("stop")
+	err := eachLine(path, func(i int, line []byte) error {
+		if to >= 0 && i >= to {
+			return errStop
+		}
+
+		if i < from {
+			return nil
+		}
+
+		return spilledRecord(line, fn)
+	})
+	if errors.Is(err, errStop) {
+		return nil
+	}
+
+	return err
+}
+
+// eachLine calls fn with the index of each non-blank, complete line of the file at path. A last
+// line without its newline can be torn by a crash, so it is skipped.
+func eachLine(path string, fn func(i int, line []byte) error) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	br := bufio.NewReader(f)
+	i := 0
+	for {
+		line, err := br.ReadBytes('\n')
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+
+		if err != nil {
+			return err
+		}
+
+		line = bytes.TrimSpace(line)
+		if len(line) == 0 {
+			continue
+		}
+
+		if err := fn(i, line); err != nil {
+			return err
+		}
+		i++
+	}
+}
@@ -0,0 +1,97 @@
+package serrors
+
+import (
+	"log/slog"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+// loadMessages returns the messages of the records s loads from up to to
+func loadMessages(t *testing.T, s Store, from, to int) []string {
+	t.Helper()
+	var msgs []string
+	err := s.LoadRange(from, to, func(r slog.Record) error {
+		msgs = append(msgs, r.Message)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("\ngot  %s\nwant nil", err.Error())
+	}
+
+	return msgs
+}
+
+func TestStores(t *testing.T) {
+	file, err := NewFileStore(filepath.Join(t.TempDir(), "records.ndjson"), false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+
+	for name, s := range map[string]Store{"memory": NewMemoryStore(), "file": file} {
+		t.Run(name, func(t *testing.T) {
+			for _, msg := range []string{"a", "b", "c", "d"} {
+				if err := s.AppendRecord(slog.NewRecord(testTime, slog.LevelInfo, msg, 0)); err != nil {
+					t.Fatalf("\ngot  %s\nwant nil", err.Error())
+				}
+			}
+
+			if got := loadMessages(t, s, 1, 3); !slices.Equal(got, []string{"b", "c"}) {
+				t.Fatalf("\ngot  %v\nwant [b c]", got)
+			}
+
+			if err := s.Prune(2); err != nil {
+				t.Fatalf("\ngot  %s\nwant nil", err.Error())
+			}
+
+			if err := s.AppendRecord(slog.NewRecord(testTime, slog.LevelInfo, "e", 0)); err != nil {
+				t.Fatalf("\ngot  %s\nwant nil", err.Error())
+			}
+
+			if got := loadMessages(t, s, 0, -1); !slices.Equal(got, []string{"c", "d", "e"}) {
+				t.Fatalf("\ngot  %v\nwant [c d e]", got)
+			}
+		})
+	}
+}
+
+func TestFileStoreTornLine(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "records.ndjson")
+	s, err := NewFileStore(path, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	s.AppendRecord(slog.NewRecord(testTime, slog.LevelInfo, "a", 0))
+	s.Close()
+
+	f, _ := os.OpenFile(path, os.O_WRONLY|os.O_APPEND, 0o600)
+	f.WriteString(`{"time":"2000`)
+	f.Close()
+
+	if got := loadMessages(t, s, 0, -1); !slices.Equal(got, []string{"a"}) {
+		t.Fatalf("\ngot  %v\nwant [a]", got)
+	}
+}
+
+func TestSErrorsWithStores(t *testing.T) {
+	spilled, journal := NewMemoryStore(), NewMemoryStore()
+	e := NewTextHandler(nil, nil, WithSpillStore(spilled, 2), WithWALStore(journal))
+	for _, msg := range []string{"a", "b", "c"} {
+		e.Info(testTime, msg)
+	}
+
+	if got := loadMessages(t, spilled, 0, -1); !slices.Equal(got, []string{"a", "b"}) {
+		t.Fatalf("\ngot  %v\nwant [a b]", got)
+	}
+
+	r := NewTextHandler(nil, nil)
+	if err := r.RecoverStore(journal); err != nil {
+		t.Fatalf("\ngot  %s\nwant nil", err.Error())
+	}
+
+	if got := loadMessages(t, journal, 0, -1); len(r.records) != 3 || len(got) != 3 {
+		t.Fatalf("\ngot  %d records\nwant 3", len(r.records))
+	}
+}
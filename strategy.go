@@ -0,0 +1,79 @@
+package serrors
+
+import "log/slog"
+
+// IDKey is the attr holding the ID given to each record added by WithIDGenerator
+const IDKey = "id"
+
+// IDGenerator returns the ID of a record being added, see WithIDGenerator. It must be safe to call
+// from multiple goroutines.
+type IDGenerator interface {
+	NewID(r slog.Record) string
+}
+
+// IDGeneratorFunc adapts a function to an IDGenerator
+type IDGeneratorFunc func(r slog.Record) string
+
+// NewID returns f(r)
+func (f IDGeneratorFunc) NewID(r slog.Record) string { return f(r) }
+
+// Fingerprinter returns the key records are grouped by in LogSummary, Anonymized and
+// SErrors.Fingerprint, see WithFingerprinter. It must be safe to call from multiple goroutines.
+type Fingerprinter interface {
+	Fingerprint(r slog.Record) string
+}
+
+// FingerprinterFunc adapts a function to a Fingerprinter
+type FingerprinterFunc func(r slog.Record) string
+
+// Fingerprint returns f(r)
+func (f FingerprinterFunc) Fingerprint(r slog.Record) string { return f(r) }
+
+// WithIDGenerator gives every record kept when added an IDKey attr from g, so records can be
+// referenced by the IDs existing incident tooling uses. Records added by Stack, Append and Recover
+// keep the attrs they have.
+func WithIDGenerator(g IDGenerator) Option {
+	return func(e *SErrors) {
+		e.idGen = g
+	}
+}
+
+// WithFingerprinter groups records by the keys f returns instead of by message, so summaries line
+// up with the grouping of other error tooling
+func WithFingerprinter(f Fingerprinter) Option {
+	return func(e *SErrors) {
+		e.fingerprinter = f
+	}
+}
+
+// Fingerprint returns the key r is grouped by, its message unless WithFingerprinter is used
+func (e *SErrors) Fingerprint(r slog.Record) string {
+	return e.fingerprint(r)
+}
+
+// fingerprint returns the key records are grouped by in summaries
+func (e *SErrors) fingerprint(r slog.Record) string {
+	e.mu.RLock()
+	f := e.fingerprinter
+	e.mu.RUnlock()
+
+	if f == nil {
+		return r.Message
+	}
+
+	return f.Fingerprint(r)
+}
+
+// withID returns r with an IDKey attr if e uses WithIDGenerator
+func (e *SErrors) withID(r slog.Record) slog.Record {
+	e.mu.RLock()
+	g := e.idGen
+	e.mu.RUnlock()
+
+	if g != nil {
+		r = r.Clone()
+		r.AddAttrs(slog.String(IDKey, g.NewID(r)))
+	}
+
+	return r
+}
@@ -0,0 +1,50 @@
+package serrors
+
+import (
+	"bytes"
+	"fmt"
+	"log/slog"
+	"strings"
+	"sync/atomic"
+	"testing"
+)
+
+func TestSErrorsWithIDGenerator(t *testing.T) {
+	var n atomic.Int64
+	ids := IDGeneratorFunc(func(slog.Record) string { return fmt.Sprintf("E-%d", n.Add(1)) })
+	e := NewTextHandler(nil, nil, WithIDGenerator(ids))
+	e.Error(testTime, "a", slog.Int("i", 1))
+	e.Warn(testTime, "b")
+
+	want := "time=2000-01-02T03:04:05.000Z level=ERROR msg=a i=1 id=E-1\n" +
+		"time=2000-01-02T03:04:05.000Z level=WARN msg=b id=E-2\n"
+	if got := e.String(); got != want {
+		t.Fatalf("\ngot  %s\nwant %s", got, want)
+	}
+}
+
+func TestSErrorsWithFingerprinter(t *testing.T) {
+	byCode := FingerprinterFunc(func(r slog.Record) string {
+		v, _ := GetAttrPath(r, CodeKey)
+		return v.String()
+	})
+
+	got := bytes.NewBuffer(nil)
+	e := New(got, nil, WithFingerprinter(byCode))
+	e.Error(testTime, "db down", WithCode("DB1"))
+	e.Error(testTime, "database unreachable", WithCode("DB1"))
+	e.Warn(testTime, "slow", WithCode("SLOW"))
+
+	if fp := e.Fingerprint(e.First()); fp != "DB1" {
+		t.Fatalf("\ngot  %s\nwant DB1", fp)
+	}
+
+	if err := e.LogSummary(); err != nil {
+		t.Fatalf("\ngot  %s\nwant nil", err.Error())
+	}
+
+	want := `"top":[{"fingerprint":"DB1","count":2},{"fingerprint":"SLOW","count":1}]`
+	if !strings.Contains(got.String(), want) {
+		t.Fatalf("\ngot  %s\nwant %s", got, want)
+	}
+}
@@ -0,0 +1,116 @@
+package serrors
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"log/slog"
+	"net/http"
+)
+
+// subBuffer is how many records a subscriber may fall behind before records are dropped for it
+const subBuffer = 256
+
+// subscribe registers a channel that receives every record added from now on. If replay is true
+// the records already collected are returned, taken under the same lock so none are missed or
+// repeated. A subscriber that falls subBuffer records behind misses records rather than blocking
+// Add. cancel must be called to unregister the channel, which is then closed. Close closes every
+// channel, and after Close the channel returned is already closed.
+func (e *SErrors) subscribe(replay bool) (rs []slog.Record, ch chan slog.Record, cancel func()) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if replay {
+		e.expireLocked()
+		rs = e.copyRecords()
+	}
+
+	ch = make(chan slog.Record, subBuffer)
+	select {
+	case <-e.done:
+		close(ch)
+		return rs, ch, func() {}
+	default:
+	}
+
+	e.subs[ch] = struct{}{}
+
+	return rs, ch, func() {
+		e.mu.Lock()
+		defer e.mu.Unlock()
+
+		if _, ok := e.subs[ch]; ok {
+			delete(e.subs, ch)
+			close(ch)
+		}
+	}
+}
+
+// publish sends rs to every subscriber. e.mu must be held.
+func (e *SErrors) publish(rs ...slog.Record) {
+	for ch := range e.subs {
+		for _, r := range rs {
+			select {
+			case ch <- r:
+			default:
+			}
+		}
+	}
+}
+
+// StreamHandler returns an http.Handler that pushes each record added to e to the client as a
+// server-sent event whose data is the record in JSON. Add the query parameter replay=true to
+// receive the records already collected before the live ones. The stream ends when the client
+// disconnects or e is closed.
+func (e *SErrors) StreamHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		flusher, ok := w.(http.Flusher)
+		if !ok {
+			http.Error(w, "streaming unsupported", http.StatusInternalServerError)
+			return
+		}
+
+		rs, ch, cancel := e.subscribe(r.URL.Query().Get("replay") == "true")
+		defer cancel()
+
+		w.Header().Set("Content-Type", "text/event-stream")
+		w.Header().Set("Cache-Control", "no-cache")
+		w.Header().Set("Connection", "keep-alive")
+		w.WriteHeader(http.StatusOK)
+		flusher.Flush()
+
+		for _, rec := range rs {
+			if err := e.writeEvent(w, rec); err != nil {
+				return
+			}
+		}
+		flusher.Flush()
+
+		for {
+			select {
+			case <-r.Context().Done():
+				return
+			case rec, ok := <-ch:
+				if !ok {
+					return
+				}
+
+				if err := e.writeEvent(w, rec); err != nil {
+					return
+				}
+				flusher.Flush()
+			}
+		}
+	})
+}
+
+// writeEvent writes r to w as a server-sent event
+func (e *SErrors) writeEvent(w http.ResponseWriter, r slog.Record) error {
+	b := bytes.NewBuffer(nil)
+	if err := slog.NewJSONHandler(b, e.opts).Handle(context.Background(), r); err != nil {
+		return err
+	}
+
+	_, err := fmt.Fprintf(w, "data: %s\n\n", bytes.TrimSuffix(b.Bytes(), []byte("\n")))
+	return err
+}
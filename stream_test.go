@@ -0,0 +1,84 @@
+package serrors
+
+import (
+	"bufio"
+	"context"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestSErrorsStreamHandler(t *testing.T) {
+	e := New(nil, nil)
+	e.Error(testTime, "m", slog.Int("a", 1))
+
+	srv := httptest.NewServer(e.StreamHandler())
+	defer srv.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	req, _ := http.NewRequestWithContext(ctx, http.MethodGet, srv.URL+"?replay=true", nil)
+	res, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("\ngot  %s\nwant nil", err.Error())
+	}
+	defer res.Body.Close()
+
+	if got := res.Header.Get("Content-Type"); got != "text/event-stream" {
+		t.Fatalf("\ngot  %s\nwant text/event-stream", got)
+	}
+
+	scanner := bufio.NewScanner(res.Body)
+	next := func() string {
+		for scanner.Scan() {
+			if scanner.Text() != "" {
+				return scanner.Text()
+			}
+		}
+		return ""
+	}
+
+	want := `data: {"time":"2000-01-02T03:04:05Z","level":"ERROR","msg":"m","a":1}`
+	if got := next(); got != want {
+		t.Fatalf("\ngot  %s\nwant %s", got, want)
+	}
+
+	e.Warn(testTime, "m2")
+	want = `data: {"time":"2000-01-02T03:04:05Z","level":"WARN","msg":"m2"}`
+	if got := next(); got != want {
+		t.Fatalf("\ngot  %s\nwant %s", got, want)
+	}
+
+	cancel()
+	for i := 0; i < 100; i++ {
+		e.mu.RLock()
+		n := len(e.subs)
+		e.mu.RUnlock()
+		if n == 0 {
+			return
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	t.Fatalf("\ngot  subscriber\nwant none after disconnect")
+}
+
+func TestSErrorsSubscribeDropsWhenFull(t *testing.T) {
+	e := New(nil, nil)
+	_, ch, cancel := e.subscribe(false)
+
+	for i := 0; i < subBuffer+10; i++ {
+		e.Info(testTime, "m")
+	}
+
+	if len(ch) != subBuffer {
+		t.Fatalf("\ngot  %d\nwant %d", len(ch), subBuffer)
+	}
+
+	cancel()
+	cancel()
+	if _, ok := <-ch; !ok {
+		t.Fatalf("\ngot  closed\nwant buffered records")
+	}
+}
@@ -0,0 +1,42 @@
+package serrors
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+)
+
+// ArgsErrorKey is the attr key WithStrictArgs reports malformed args under
+const ArgsErrorKey = "args_error"
+
+// WithStrictArgs makes AddAny and its level variants check their args with ValidateArgs. Records
+// with malformed args, which slog renders under !BADKEY, get the error under ArgsErrorKey, so bugs
+// in call sites show up during development.
+func WithStrictArgs() Option {
+	return func(e *SErrors) {
+		e.strictArgs = true
+	}
+}
+
+// ValidateArgs returns an error for each arg slog would not read as a key-value pair or slog.Attr:
+// keys that are neither a string nor a slog.Attr and a final string key without a value
+func ValidateArgs(args ...any) error {
+	var errs []error
+	for i := 0; i < len(args); {
+		switch k := args[i].(type) {
+		case slog.Attr:
+			i++
+		case string:
+			if i+1 == len(args) {
+				errs = append(errs, fmt.Errorf("serrors: arg %d: key %q has no value", i, k))
+			}
+			i += 2
+		default:
+			errs = append(errs, fmt.Errorf("serrors: arg %d: key is a %T, not a string or slog.Attr",
+				i, k))
+			i++
+		}
+	}
+
+	return errors.Join(errs...)
+}
@@ -0,0 +1,45 @@
+package serrors
+
+import (
+	"log/slog"
+	"testing"
+)
+
+func TestValidateArgs(t *testing.T) {
+	tests := []struct {
+		name string
+		args []any
+		want string
+	}{
+		{"valid", []any{"a", 1, slog.Int("b", 2), "c", "d"}, ""},
+		{"no value", []any{"a", 1, "b"}, `serrors: arg 2: key "b" has no value`},
+		{"bad key", []any{1, "a", "b", "c"}, "serrors: arg 0: key is a int, not a string or slog.Attr\n" +
+			`serrors: arg 3: key "c" has no value`},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got := ""
+			if err := ValidateArgs(test.args...); err != nil {
+				got = err.Error()
+			}
+
+			if got != test.want {
+				t.Fatalf("\ngot  %s\nwant %s", got, test.want)
+			}
+		})
+	}
+}
+
+func TestSErrorsWithStrictArgs(t *testing.T) {
+	e := NewTextHandler(nil, nil, WithStrictArgs())
+	e.ErrorAny(testTime, "bad", "a", 1, "b")
+	e.AddAny(testTime, slog.LevelInfo, "good", "a", 1)
+
+	want := "time=2000-01-02T03:04:05.000Z level=ERROR msg=bad a=1 !BADKEY=b " +
+		"args_error=\"serrors: arg 2: key \\\"b\\\" has no value\"\n" +
+		"time=2000-01-02T03:04:05.000Z level=INFO msg=good a=1\n"
+	if got := e.String(); got != want {
+		t.Fatalf("\ngot  %s\nwant %s", got, want)
+	}
+}
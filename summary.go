@@ -0,0 +1,99 @@
+package serrors
+
+import (
+	"cmp"
+	"context"
+	"log/slog"
+	"slices"
+	"time"
+)
+
+// summaryTop is how many fingerprints LogSummary lists
+const summaryTop = 5
+
+// SummaryEntry is a fingerprint and how many records share it, listed by LogSummary
+type SummaryEntry struct {
+	Fingerprint string `json:"fingerprint"`
+	Count       int    `json:"count"`
+}
+
+// LogSummary writes a single roll-up record to the logger handler and tees instead of every record, for
+// high-volume jobs where logging each record is too expensive. The record has the highest level
+// and holds the total, the count per level, the duration between the first and last record, the
+// most common fingerprints and the number of records dropped by sampling or WithMaxRecords.
+// Spilled records count.
+func (e *SErrors) LogSummary() error {
+	r, err := e.summary()
+	if err != nil {
+		return err
+	}
+
+	return e.output(context.Background(), r)
+}
+
+// summary builds the record written by LogSummary
+func (e *SErrors) summary() (slog.Record, error) {
+	counts := map[slog.Level]int{}
+	prints := map[string]int{}
+	var first, last time.Time
+	total := 0
+	err := e.eachRecord(func(r slog.Record) error {
+		total++
+		counts[r.Level]++
+		prints[e.fingerprint(r)]++
+		if first.IsZero() || r.Time.Before(first) {
+			first = r.Time
+		}
+
+		if r.Time.After(last) {
+			last = r.Time
+		}
+
+		return nil
+	})
+	if err != nil {
+		return slog.Record{}, err
+	}
+
+	levels := make([]slog.Level, 0, len(counts))
+	for l := range counts {
+		levels = append(levels, l)
+	}
+	slices.Sort(levels)
+
+	byLevel := make([]any, 0, len(levels))
+	for _, l := range levels {
+		byLevel = append(byLevel, slog.Int(l.String(), counts[l]))
+	}
+
+	top := make([]SummaryEntry, 0, len(prints))
+	for fp, n := range prints {
+		top = append(top, SummaryEntry{Fingerprint: fp, Count: n})
+	}
+	slices.SortFunc(top, func(a, b SummaryEntry) int {
+		if c := cmp.Compare(b.Count, a.Count); c != 0 {
+			return c
+		}
+
+		return cmp.Compare(a.Fingerprint, b.Fingerprint)
+	})
+	top = top[:min(len(top), summaryTop)]
+
+	e.mu.RLock()
+	l, dropped := e.level, e.sampledOut
+	if e.capacity != nil {
+		dropped += e.capacity.dropped
+	}
+	e.mu.RUnlock()
+
+	r := slog.NewRecord(e.now(), l, "summary", 0)
+	r.AddAttrs(
+		slog.Int("total", total),
+		slog.Group("levels", byLevel...),
+		slog.Duration(DurationKey, last.Sub(first)),
+		slog.Any("top", top),
+		slog.Int("dropped", dropped),
+	)
+
+	return r, nil
+}
@@ -0,0 +1,60 @@
+package serrors
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"testing"
+	"time"
+)
+
+func TestSErrorsLogSummary(t *testing.T) {
+	got := bytes.NewBuffer(nil)
+	n := 0
+	e := New(got, nil, WithSampler(func(slog.Record) bool {
+		n++
+		return n%4 != 0
+	}))
+
+	for i := 0; i < 8; i++ {
+		e.Add(testTime.Add(time.Duration(i)*time.Second), slog.LevelInfo, "retry")
+	}
+	e.Add(testTime, slog.LevelError, "db down")
+	e.Add(testTime, slog.LevelError, "db down")
+	e.Add(testTime, slog.LevelWarn, "slow")
+
+	if err := e.LogSummary(); err != nil {
+		t.Fatalf("\ngot  %s\nwant nil", err)
+	}
+
+	var s struct {
+		Level    string
+		Msg      string
+		Total    int
+		Levels   map[string]int
+		Duration time.Duration
+		Top      []SummaryEntry
+		Dropped  int
+	}
+	if err := json.Unmarshal(got.Bytes(), &s); err != nil {
+		t.Fatalf("\ngot  %s\nwant nil", err)
+	}
+
+	// Every fourth record is sampled out: the fourth and eighth retry.
+	if s.Level != "ERROR" || s.Msg != "summary" || s.Total != 9 || s.Dropped != 2 {
+		t.Fatalf("\ngot  %+v\nwant ERROR summary, 9 total, 2 dropped", s)
+	}
+
+	if s.Levels["INFO"] != 6 || s.Levels["ERROR"] != 2 || s.Levels["WARN"] != 1 {
+		t.Fatalf("\ngot  %v\nwant INFO 6, WARN 1, ERROR 2", s.Levels)
+	}
+
+	if s.Duration != 6*time.Second {
+		t.Fatalf("\ngot  %s\nwant 6s", s.Duration)
+	}
+
+	want := []SummaryEntry{{"retry", 6}, {"db down", 2}, {"slow", 1}}
+	if len(s.Top) != len(want) || s.Top[0] != want[0] || s.Top[1] != want[1] || s.Top[2] != want[2] {
+		t.Fatalf("\ngot  %v\nwant %v", s.Top, want)
+	}
+}
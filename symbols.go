@@ -0,0 +1,33 @@
+package serrors
+
+import "log/slog"
+
+// DefaultLevelSymbols are friendly level prefixes for human-facing CLI output
+var DefaultLevelSymbols = map[slog.Level]string{
+	slog.LevelDebug: "·",
+	slog.LevelInfo:  "ℹ",
+	slog.LevelWarn:  "⚠",
+	slog.LevelError: "✖",
+}
+
+// WithLevelSymbols prefixes each record in text and pretty output with the symbol, or any string,
+// of its level. A level without an entry uses the symbol of the closest level below it, so
+// WARN+2 gets the WARN symbol. Use DefaultLevelSymbols for ✖, ⚠ and ℹ. JSON output is not
+// affected.
+func WithLevelSymbols(symbols map[slog.Level]string) Option {
+	return func(e *SErrors) {
+		e.levelSymbols = symbols
+	}
+}
+
+// levelSymbol returns the symbol for l, or "" if there is none
+func levelSymbol(symbols map[slog.Level]string, l slog.Level) string {
+	s, best, found := "", slog.Level(0), false
+	for sl, sym := range symbols {
+		if sl <= l && (!found || sl > best) {
+			s, best, found = sym, sl, true
+		}
+	}
+
+	return s
+}
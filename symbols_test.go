@@ -0,0 +1,50 @@
+package serrors
+
+import (
+	"bytes"
+	"log/slog"
+	"testing"
+)
+
+func TestSErrorsLevelSymbols(t *testing.T) {
+	got := bytes.NewBuffer(nil)
+	e := NewTextHandler(got, nil, WithLevelSymbols(DefaultLevelSymbols))
+	e.Add(testTime, slog.LevelError, "a")
+	e.Add(testTime, slog.LevelWarn+2, "b")
+	e.Add(testTime, slog.LevelInfo, "c")
+	e.Add(testTime, slog.LevelDebug-4, "d")
+
+	want := "✖ time=2000-01-02T03:04:05.000Z level=ERROR msg=a\n" +
+		"⚠ time=2000-01-02T03:04:05.000Z level=WARN+2 msg=b\n" +
+		"ℹ time=2000-01-02T03:04:05.000Z level=INFO msg=c\n" +
+		"time=2000-01-02T03:04:05.000Z level=DEBUG-4 msg=d\n"
+	if s := e.String(); s != want {
+		t.Fatalf("\ngot  %s\nwant %s", s, want)
+	}
+
+	if err := e.Log(); err != nil {
+		t.Fatalf("\ngot  %s\nwant nil", err)
+	}
+
+	if got.String() != want {
+		t.Fatalf("\ngot  %s\nwant %s", got, want)
+	}
+
+	wantPretty := "✖ 2000-01-02T03:04:05.000Z ERROR a\n"
+	if s := e.RtoPretty(e.First()); s != wantPretty {
+		t.Fatalf("\ngot  %s\nwant %s", s, wantPretty)
+	}
+}
+
+func TestSErrorsLevelSymbolsCustom(t *testing.T) {
+	symbols := map[slog.Level]string{slog.LevelError: "[FAIL]"}
+	e := NewTextHandler(nil, nil, WithLevelSymbols(symbols), WithLineWidth(40, "  "))
+	e.Add(testTime, slog.LevelError, "a", slog.Int("code", 1))
+	e.Add(testTime, slog.LevelWarn, "b")
+
+	want := "[FAIL] time=2000-01-02T03:04:05.000Z\n  level=ERROR msg=a code=1\n" +
+		"time=2000-01-02T03:04:05.000Z level=WARN\n  msg=b\n"
+	if s := e.String(); s != want {
+		t.Fatalf("\ngot  %s\nwant %s", s, want)
+	}
+}
@@ -0,0 +1,68 @@
+package serrors
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+)
+
+// tee is an extra destination of Log added with WithTee or WithTeeHandler
+type tee struct {
+	// min is the lowest level sent to h
+	min slog.Level
+	// w is rendered to with a handler matching e, kept in h. It is nil for WithTeeHandler.
+	w io.Writer
+	h slog.Handler
+}
+
+// WithTee makes Log and LogSummary also write the records at or above min to w, rendered like
+// the logger, e.g. every record to stderr and only errors to a file. It can be used once per
+// destination. Close does not shut down w.
+func WithTee(min slog.Level, w io.Writer) Option {
+	return func(e *SErrors) {
+		e.tees = append(e.tees, tee{min: min, w: w})
+	}
+}
+
+// WithTeeHandler is WithTee sending the records to h, prepared for the format of e as they are for
+// the logger
+func WithTeeHandler(min slog.Level, h slog.Handler) Option {
+	return func(e *SErrors) {
+		e.tees = append(e.tees, tee{min: min, h: h})
+	}
+}
+
+// renderTees creates the handlers of the tees writing to an io.Writer, matching the format of e.
+// The tees are replaced rather than changed in place, as copies of e may share them. e.mu must be
+// held for writing.
+func (e *SErrors) renderTees() {
+	if len(e.tees) == 0 {
+		return
+	}
+
+	tees := make([]tee, len(e.tees))
+	for i, t := range e.tees {
+		if t.w != nil {
+			t.h = e.newHandler(t.w)
+		}
+		tees[i] = t
+	}
+	e.tees = tees
+}
+
+// output prepares r and writes it to the logger and the tees enabled for its level
+func (e *SErrors) output(ctx context.Context, r slog.Record) error {
+	e.mu.RLock()
+	r, h, tees := e.prepare(r), e.logger, e.tees
+	e.mu.RUnlock()
+
+	errs := []error{h.Handle(ctx, r)}
+	for _, t := range tees {
+		if r.Level >= t.min {
+			errs = append(errs, t.h.Handle(ctx, r))
+		}
+	}
+
+	return errors.Join(errs...)
+}
@@ -0,0 +1,43 @@
+package serrors
+
+import (
+	"bytes"
+	"log/slog"
+	"testing"
+)
+
+func TestSErrorsWithTee(t *testing.T) {
+	all, errs, handler := bytes.NewBuffer(nil), bytes.NewBuffer(nil), bytes.NewBuffer(nil)
+	e := NewTextHandler(all, nil,
+		WithTee(slog.LevelError, errs),
+		WithTeeHandler(slog.LevelWarn, slog.NewJSONHandler(handler, nil)),
+	)
+	e.Info(testTime, "i")
+	e.Warn(testTime, "w")
+	e.Error(testTime, "e")
+
+	if err := e.Log(); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name string
+		got  *bytes.Buffer
+		want string
+	}{
+		{"logger", all, "time=2000-01-02T03:04:05.000Z level=INFO msg=i\n" +
+			"time=2000-01-02T03:04:05.000Z level=WARN msg=w\n" +
+			"time=2000-01-02T03:04:05.000Z level=ERROR msg=e\n"},
+		{"writer", errs, "time=2000-01-02T03:04:05.000Z level=ERROR msg=e\n"},
+		{"handler", handler, `{"time":"2000-01-02T03:04:05Z","level":"WARN","msg":"w"}` + "\n" +
+			`{"time":"2000-01-02T03:04:05Z","level":"ERROR","msg":"e"}` + "\n"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if test.got.String() != test.want {
+				t.Fatalf("\ngot  %s\nwant %s", test.got, test.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,141 @@
+package serrors
+
+import (
+	"io"
+	"log/slog"
+	"slices"
+	"sync"
+	"time"
+)
+
+// MultiTenant keeps an isolated collection per tenant ID with a record quota each, for services
+// where one tenant's error storm must not crowd out another's records. Records over a tenant's
+// quota are dropped and counted. Each tenant's collection is logged and exported on its own, see
+// Tenant. It is safe for concurrent use.
+type MultiTenant struct {
+	mu sync.RWMutex
+	// quota is the most records a tenant holds, 0 for no limit
+	quota int
+	// newCollector creates the collection of a new tenant
+	newCollector func() *SErrors
+	tenants      map[string]*tenant
+}
+
+// tenant is the collection of one tenant
+type tenant struct {
+	// mu serializes the quota check and add
+	mu      sync.Mutex
+	e       *SErrors
+	dropped int
+}
+
+// NewMultiTenant creates a MultiTenant holding at most quota records per tenant, or any number if
+// quota is 0. newCollector creates each tenant's collection, so it sets its handlers and options.
+// If newCollector is nil, collections use New(io.Discard, nil).
+func NewMultiTenant(quota int, newCollector func() *SErrors) *MultiTenant {
+	if newCollector == nil {
+		newCollector = func() *SErrors { return New(io.Discard, nil) }
+	}
+
+	return &MultiTenant{
+		quota:        max(quota, 0),
+		newCollector: newCollector,
+		tenants:      map[string]*tenant{},
+	}
+}
+
+// Add creates a record and adds it to the collection of id, see AddRecord
+func (m *MultiTenant) Add(
+	id string, t time.Time, l slog.Level, msg string, attrs ...slog.Attr,
+) bool {
+	r := slog.NewRecord(t, l, msg, 0)
+	r.AddAttrs(attrs...)
+	return m.AddRecord(id, r)
+}
+
+// AddRecord adds r to the collection of id, creating it if needed. It returns false and counts r
+// as dropped if the tenant is at its quota.
+func (m *MultiTenant) AddRecord(id string, r slog.Record) bool {
+	t := m.tenant(id)
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if m.quota > 0 && t.e.Count() >= m.quota {
+		t.dropped++
+		return false
+	}
+
+	t.e.AddRecord(r)
+	return true
+}
+
+// tenant returns the tenant id, creating it if needed
+func (m *MultiTenant) tenant(id string) *tenant {
+	m.mu.RLock()
+	t, ok := m.tenants[id]
+	m.mu.RUnlock()
+	if ok {
+		return t
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if t, ok := m.tenants[id]; ok {
+		return t
+	}
+
+	t = &tenant{e: m.newCollector()}
+	m.tenants[id] = t
+	return t
+}
+
+// Tenant returns the collection of id and false if nothing has been added for it. Use it to Log or
+// export the tenant's records. Records added to it directly are not held to the quota.
+func (m *MultiTenant) Tenant(id string) (*SErrors, bool) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	t, ok := m.tenants[id]
+	if !ok {
+		return nil, false
+	}
+
+	return t.e, true
+}
+
+// Tenants returns the IDs of every tenant in order
+func (m *MultiTenant) Tenants() []string {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	ids := make([]string, 0, len(m.tenants))
+	for id := range m.tenants {
+		ids = append(ids, id)
+	}
+	slices.Sort(ids)
+
+	return ids
+}
+
+// Dropped returns the number of records of id dropped because the tenant was at its quota
+func (m *MultiTenant) Dropped(id string) int {
+	m.mu.RLock()
+	t, ok := m.tenants[id]
+	m.mu.RUnlock()
+	if !ok {
+		return 0
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	return t.dropped
+}
+
+// RemoveTenant drops the collection of id, freeing its quota
+func (m *MultiTenant) RemoveTenant(id string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	delete(m.tenants, id)
+}
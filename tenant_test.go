@@ -0,0 +1,60 @@
+package serrors
+
+import (
+	"bytes"
+	"log/slog"
+	"reflect"
+	"testing"
+)
+
+func TestMultiTenant(t *testing.T) {
+	m := NewMultiTenant(2, func() *SErrors { return NewTextHandler(nil, nil) })
+	for range 5 {
+		m.Add("storm", testTime, slog.LevelError, "m")
+	}
+
+	if !m.Add("quiet", testTime, slog.LevelWarn, "w") {
+		t.Fatal("quiet tenant hit the quota")
+	}
+
+	if got, want := m.Tenants(), []string{"quiet", "storm"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("\ngot  %v\nwant %v", got, want)
+	}
+
+	tests := []struct {
+		id      string
+		records int
+		dropped int
+	}{
+		{"storm", 2, 3},
+		{"quiet", 1, 0},
+	}
+
+	for _, test := range tests {
+		t.Run(test.id, func(t *testing.T) {
+			e, ok := m.Tenant(test.id)
+			if !ok {
+				t.Fatal("tenant missing")
+			}
+
+			if got := len(e.Records()); got != test.records {
+				t.Fatalf("\ngot  %d records\nwant %d", got, test.records)
+			}
+
+			if got := m.Dropped(test.id); got != test.dropped {
+				t.Fatalf("\ngot  %d dropped\nwant %d", got, test.dropped)
+			}
+		})
+	}
+
+	var b bytes.Buffer
+	quiet, _ := m.Tenant("quiet")
+	if err := quiet.WriteNDJSON(&b); err != nil || bytes.Count(b.Bytes(), []byte("\n")) != 1 {
+		t.Fatalf("\ngot  %s, %v\nwant one record", b.String(), err)
+	}
+
+	m.RemoveTenant("storm")
+	if _, ok := m.Tenant("storm"); ok {
+		t.Fatal("tenant not removed")
+	}
+}
@@ -0,0 +1,54 @@
+package serrors
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"os"
+)
+
+// MarshalText renders the records in memory one per line with the slog text handler and the
+// HandlerOptions of e, regardless of whether e uses the JSON or text handler, so a collection can
+// be written to config dumps, flag values and other text formats. UnmarshalText reads it back.
+func (e *SErrors) MarshalText() ([]byte, error) {
+	e.expire()
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+
+	var b bytes.Buffer
+	h := slog.NewTextHandler(&b, e.opts)
+	for _, r := range e.records {
+		if err := h.Handle(context.Background(), r); err != nil {
+			return nil, err
+		}
+	}
+
+	return b.Bytes(), nil
+}
+
+// UnmarshalText replaces the records in memory of e with the ones in text, one per line as written
+// by MarshalText. Lines are parsed like ParseText and stored as they are, without the transforms,
+// remapping or sampling of Add. A zero SErrors is set up like NewTextHandler(os.Stderr, nil)
+// first.
+func (e *SErrors) UnmarshalText(text []byte) error {
+	var rs []slog.Record
+	err := scanLines(bytes.NewReader(text), func(line []byte) error {
+		r, err := parseTextRecord(string(line))
+		if err != nil {
+			return err
+		}
+
+		rs = append(rs, r)
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	e.setUp(func() *SErrors { return NewTextHandler(os.Stderr, nil) })
+	e.replaceRecords(rs)
+	return nil
+}
@@ -0,0 +1,62 @@
+package serrors
+
+import (
+	"encoding"
+	"log/slog"
+	"testing"
+)
+
+var (
+	_ encoding.TextMarshaler   = (*SErrors)(nil)
+	_ encoding.TextUnmarshaler = (*SErrors)(nil)
+)
+
+func TestSErrorsMarshalText(t *testing.T) {
+	src := New(nil, nil)
+	src.Debug(testTime, "d", slog.Group("g", slog.Int("a", 1), slog.String("b", "x y")))
+	src.Error(testTime, `say "hi"`, slog.Float64("f", 1.5), slog.Bool("ok", true))
+
+	want := "time=2000-01-02T03:04:05.000Z level=DEBUG msg=d g.a=1 g.b=\"x y\"\n" +
+		"time=2000-01-02T03:04:05.000Z level=ERROR msg=\"say \\\"hi\\\"\" f=1.5 ok=true\n"
+	got, err := src.MarshalText()
+	if err != nil || string(got) != want {
+		t.Fatalf("\ngot  %s, %v\nwant %s", got, err, want)
+	}
+
+	tests := []struct {
+		name string
+		e    *SErrors
+	}{
+		{"zero", &SErrors{}},
+		{"existing", NewTextHandler(nil, nil)},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			test.e.Add(testTime, slog.LevelInfo, "replaced")
+			if err := test.e.UnmarshalText(got); err != nil {
+				t.Fatal(err)
+			}
+
+			if s := test.e.String(); s != want {
+				t.Fatalf("\ngot  %s\nwant %s", s, want)
+			}
+
+			if l := test.e.Level(); l != slog.LevelError {
+				t.Fatalf("\ngot  %s\nwant %s", l, slog.LevelError)
+			}
+		})
+	}
+}
+
+func TestSErrorsUnmarshalTextError(t *testing.T) {
+	e := NewTextHandler(nil, nil)
+	e.Add(testTime, slog.LevelInfo, "kept")
+	if err := e.UnmarshalText([]byte("msg=\"unterminated\n")); err == nil {
+		t.Fatalf("\ngot  nil\nwant error")
+	}
+
+	if n := len(e.Records()); n != 1 {
+		t.Fatalf("\ngot  %d\nwant 1", n)
+	}
+}
@@ -0,0 +1,85 @@
+package serrors
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"log/slog"
+	"strings"
+	"sync"
+)
+
+// textHandler is a slog.TextHandler that decorates each record with a level symbol and wraps it,
+// see WithLevelSymbols and WithLineWidth
+type textHandler struct {
+	w       io.Writer
+	symbols map[slog.Level]string
+	width   int
+	indent  string
+	// th formats each record into buf. It is built once and shared with the handlers returned by
+	// WithAttrs and WithGroup, which derive from it.
+	th  slog.Handler
+	buf *lockedBuffer
+}
+
+// lockedBuffer is the buffer a textHandler formats records into, one at a time
+type lockedBuffer struct {
+	mu sync.Mutex
+	b  bytes.Buffer
+}
+
+// newTextHandler returns a textHandler writing to w
+func newTextHandler(w io.Writer, opts *slog.HandlerOptions, symbols map[slog.Level]string, width int, indent string) *textHandler {
+	buf := &lockedBuffer{}
+	return &textHandler{
+		w:       w,
+		symbols: symbols,
+		width:   width,
+		indent:  indent,
+		th:      slog.NewTextHandler(&buf.b, opts),
+		buf:     buf,
+	}
+}
+
+// Enabled implements slog.Handler
+func (h *textHandler) Enabled(ctx context.Context, l slog.Level) bool {
+	return h.th.Enabled(ctx, l)
+}
+
+// Handle formats r with a slog.TextHandler and writes the decorated line to h.w in one Write
+func (h *textHandler) Handle(ctx context.Context, r slog.Record) error {
+	h.buf.mu.Lock()
+	h.buf.b.Reset()
+	err := h.th.Handle(ctx, r)
+	line := strings.TrimSuffix(h.buf.b.String(), "\n")
+	h.buf.mu.Unlock()
+
+	if err != nil {
+		return err
+	}
+
+	if s := levelSymbol(h.symbols, r.Level); s != "" {
+		line = s + " " + line
+	}
+
+	if h.width > 0 {
+		line = wrapLine(line, h.width, h.indent)
+	}
+
+	_, err = io.WriteString(h.w, line+"\n")
+	return err
+}
+
+// WithAttrs implements slog.Handler
+func (h *textHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	c := *h
+	c.th = h.th.WithAttrs(attrs)
+	return &c
+}
+
+// WithGroup implements slog.Handler
+func (h *textHandler) WithGroup(name string) slog.Handler {
+	c := *h
+	c.th = h.th.WithGroup(name)
+	return &c
+}
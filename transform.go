@@ -0,0 +1,23 @@
+package serrors
+
+import "log/slog"
+
+// WithTransform adds fn to the pipeline run on each record added, before it is remapped, sampled
+// and stored. Unlike ReplaceAttr, which sees one attr at a time, fn sees the whole record, so it can
+// add attrs based on others, change the level or message, or replace *r with a new record to drop
+// attrs. Transforms run in the order they were given. fn must be safe to call from multiple
+// goroutines. Records added by Stack, Append and Recover are not transformed.
+func WithTransform(fn func(r *slog.Record)) Option {
+	return func(e *SErrors) {
+		e.transforms = append(e.transforms, fn)
+	}
+}
+
+// transform runs the transform pipeline on r
+func (e *SErrors) transform(r slog.Record) slog.Record {
+	for _, fn := range e.transforms {
+		fn(&r)
+	}
+
+	return r
+}
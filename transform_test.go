@@ -0,0 +1,39 @@
+package serrors
+
+import (
+	"log/slog"
+	"testing"
+)
+
+func TestSErrorsTransform(t *testing.T) {
+	hint := func(r *slog.Record) {
+		r.Attrs(func(a slog.Attr) bool {
+			if a.Key == "err" {
+				r.AddAttrs(slog.String("level_hint", "investigate"))
+				return false
+			}
+			return true
+		})
+	}
+
+	dropDebug := func(r *slog.Record) {
+		n := slog.NewRecord(r.Time, r.Level, r.Message, r.PC)
+		r.Attrs(func(a slog.Attr) bool {
+			if a.Key != "debug" {
+				n.AddAttrs(a)
+			}
+			return true
+		})
+		*r = n
+	}
+
+	e := NewTextHandler(nil, nil, WithTransform(hint), WithTransform(dropDebug))
+	e.Add(testTime, slog.LevelError, "a", slog.String("err", "boom"), slog.Group("debug", slog.Int("x", 1)))
+	e.Add(testTime, slog.LevelInfo, "b", slog.Int("n", 1))
+
+	want := "time=2000-01-02T03:04:05.000Z level=ERROR msg=a err=boom level_hint=investigate\n" +
+		"time=2000-01-02T03:04:05.000Z level=INFO msg=b n=1\n"
+	if got := e.String(); got != want {
+		t.Fatalf("\ngot  %s\nwant %s", got, want)
+	}
+}
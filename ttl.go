@@ -0,0 +1,45 @@
+package serrors
+
+import "time"
+
+// WithRecordTTL drops records whose time is more than d ago whenever the collection is read, and
+// lowers Level to the highest level of the records left. A continuously fed collector then reports
+// a healthy Level again once its errors have aged out. Spilled records do not expire.
+func WithRecordTTL(d time.Duration) Option {
+	return func(e *SErrors) {
+		e.ttl = d
+	}
+}
+
+// expire drops the expired records. It only takes the write lock when a record has expired, so
+// reads of a collection with nothing to expire do not block each other.
+func (e *SErrors) expire() {
+	if e.ttl <= 0 {
+		return
+	}
+
+	e.mu.RLock()
+	due := e.expiryDue(e.now().Add(-e.ttl))
+	e.mu.RUnlock()
+	if !due {
+		return
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	e.expireLocked()
+}
+
+// expireLocked drops the expired records, see prune. e.mu must be held for writing.
+func (e *SErrors) expireLocked() {
+	if e.ttl > 0 {
+		e.prune(e.now().Add(-e.ttl))
+	}
+}
+
+// expiryDue reports whether a record may be older than cutoff, from the earliest time of the
+// oldest shard. e.mu must be held.
+func (e *SErrors) expiryDue(cutoff time.Time) bool {
+	return len(e.shards.list) > 0 && e.shards.list[0].min.Before(cutoff)
+}
@@ -0,0 +1,51 @@
+package serrors
+
+import (
+	"log/slog"
+	"testing"
+	"time"
+)
+
+func TestSErrorsRecordTTL(t *testing.T) {
+	e := NewTextHandler(nil, nil, WithRecordTTL(time.Hour))
+	e.Add(testTime, slog.LevelError, "old")
+	e.Add(time.Now(), slog.LevelWarn, "new")
+
+	if e.Level() != slog.LevelWarn {
+		t.Fatalf("\ngot  %s\nwant %s", e.Level(), slog.LevelWarn)
+	}
+
+	rs := e.Records()
+	if len(rs) != 1 || rs[0].Message != "new" {
+		t.Fatalf("\ngot  %d records\nwant new", len(rs))
+	}
+
+	e = NewTextHandler(nil, nil, WithRecordTTL(time.Hour))
+	e.Add(testTime, slog.LevelError, "old")
+	if !e.IsEmpty() {
+		t.Fatalf("\ngot  %s\nwant empty", e)
+	}
+}
+
+func TestSErrorsRecordTTLOutOfOrder(t *testing.T) {
+	clock := fixedClock(testTime)
+	e := NewTextHandler(nil, nil, WithRecordTTL(time.Hour), WithClock(&clock))
+	e.Add(testTime.Add(-time.Minute), slog.LevelWarn, "first")
+	e.Add(testTime.Add(-50*time.Minute), slog.LevelError, "second")
+	e.Add(testTime.Add(-10*time.Minute), slog.LevelInfo, "third")
+
+	clock = fixedClock(testTime.Add(20 * time.Minute))
+	rs := e.Records()
+	if len(rs) != 2 || rs[0].Message != "first" || rs[1].Message != "third" {
+		t.Fatalf("\ngot  %d records\nwant first, third", len(rs))
+	}
+
+	if e.Level() != slog.LevelWarn {
+		t.Fatalf("\ngot  %s\nwant %s", e.Level(), slog.LevelWarn)
+	}
+
+	clock = fixedClock(testTime.Add(55 * time.Minute))
+	if rs = e.Records(); len(rs) != 1 || rs[0].Message != "first" {
+		t.Fatalf("\ngot  %d records\nwant first", len(rs))
+	}
+}
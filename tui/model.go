@@ -0,0 +1,316 @@
+package tui
+
+import (
+	"fmt"
+	"log/slog"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/chadeldridge/serrors"
+)
+
+// levels are the minimum levels cycled through by the level filter key
+var levels = []slog.Level{slog.LevelDebug, slog.LevelInfo, slog.LevelWarn, slog.LevelError}
+
+// KeyCode identifies a key press understood by Model
+type KeyCode int
+
+const (
+	KeyRune KeyCode = iota
+	KeyUp
+	KeyDown
+	KeyPageUp
+	KeyPageDown
+	KeyHome
+	KeyEnd
+	KeyEnter
+	KeyBackspace
+	KeyEsc
+	KeyCtrlC
+)
+
+// Key is a single key press. Rune is only set for KeyRune.
+type Key struct {
+	Code KeyCode
+	Rune rune
+}
+
+// Model holds the state of the viewer: the records, the active filters and the scroll position.
+// It does no I/O so it can be driven by Run or by tests.
+type Model struct {
+	// all records known to the viewer
+	all []slog.Record
+	// view holds the indexes of all that pass the level filter and search
+	view []int
+	// minLevel hides records below the level
+	minLevel slog.Level
+	// search hides records that do not contain the string
+	search string
+	// input is the search being typed while searching is true
+	input     string
+	searching bool
+	// cursor is the selected index in view
+	cursor int
+	// offset is the first line shown in the body
+	offset int
+	// expanded shows the attrs of the selected record
+	expanded bool
+	// follow keeps the cursor on the newest record as records are added
+	follow bool
+}
+
+// NewModel creates a Model showing all levels and following new records
+func NewModel() *Model {
+	return &Model{minLevel: slog.LevelDebug, follow: true}
+}
+
+// SetRecords replaces the records shown by the viewer, keeping the selection where possible
+func (m *Model) SetRecords(rs []slog.Record) {
+	m.all = rs
+	m.refilter()
+}
+
+// Selected returns the selected record and false if no record is shown
+func (m *Model) Selected() (slog.Record, bool) {
+	if len(m.view) == 0 {
+		return slog.Record{}, false
+	}
+
+	return m.all[m.view[m.cursor]], true
+}
+
+// Handle applies a key press and returns true when the viewer should quit
+func (m *Model) Handle(k Key) bool {
+	if m.searching {
+		m.handleSearch(k)
+		return false
+	}
+
+	switch k.Code {
+	case KeyCtrlC:
+		return true
+	case KeyUp:
+		m.move(-1)
+	case KeyDown:
+		m.move(1)
+	case KeyPageUp:
+		m.move(-10)
+	case KeyPageDown:
+		m.move(10)
+	case KeyHome:
+		m.move(-len(m.view))
+	case KeyEnd:
+		m.move(len(m.view))
+	case KeyEnter:
+		m.expanded = !m.expanded
+	case KeyEsc:
+		m.search = ""
+		m.refilter()
+	case KeyRune:
+		switch k.Rune {
+		case 'q':
+			return true
+		case 'k':
+			m.move(-1)
+		case 'j':
+			m.move(1)
+		case 'g':
+			m.move(-len(m.view))
+		case 'G':
+			m.move(len(m.view))
+		case '/':
+			m.searching = true
+			m.input = m.search
+		case 'l':
+			m.cycleLevel()
+		}
+	}
+
+	return false
+}
+
+// handleSearch edits the search input until it is applied with enter or dropped with esc
+func (m *Model) handleSearch(k Key) {
+	switch k.Code {
+	case KeyEnter:
+		m.searching = false
+		m.search = m.input
+		m.refilter()
+	case KeyEsc, KeyCtrlC:
+		m.searching = false
+	case KeyBackspace:
+		if m.input != "" {
+			_, size := utf8.DecodeLastRuneInString(m.input)
+			m.input = m.input[:len(m.input)-size]
+		}
+	case KeyRune:
+		m.input += string(k.Rune)
+	}
+}
+
+// cycleLevel raises the minimum level shown, wrapping back to debug after error
+func (m *Model) cycleLevel() {
+	next := levels[0]
+	for _, l := range levels {
+		if l > m.minLevel {
+			next = l
+			break
+		}
+	}
+
+	m.minLevel = next
+	m.refilter()
+}
+
+// move shifts the cursor by n records. Reaching the last record turns follow on.
+func (m *Model) move(n int) {
+	m.cursor = min(max(m.cursor+n, 0), max(len(m.view)-1, 0))
+	m.follow = m.cursor == len(m.view)-1
+}
+
+// refilter rebuilds view from all, the level filter and the search
+func (m *Model) refilter() {
+	selected := -1
+	if len(m.view) > 0 {
+		selected = m.view[m.cursor]
+	}
+
+	m.view = m.view[:0]
+	search := strings.ToLower(m.search)
+	for i, r := range m.all {
+		if r.Level < m.minLevel {
+			continue
+		}
+
+		if search != "" && !strings.Contains(strings.ToLower(line(r)), search) {
+			continue
+		}
+
+		m.view = append(m.view, i)
+	}
+
+	m.cursor = 0
+	for i, idx := range m.view {
+		if idx <= selected {
+			m.cursor = i
+		}
+	}
+
+	if m.follow {
+		m.cursor = max(len(m.view)-1, 0)
+	}
+}
+
+// View renders the model to width columns and height rows, one string per row
+func (m *Model) View(width, height int) []string {
+	rows := []string{m.header()}
+
+	var body []string
+	cursorRow := 0
+	for i, idx := range m.view {
+		r := m.all[idx]
+		if i == m.cursor {
+			cursorRow = len(body)
+			body = append(body, "\x1b[7m"+truncate("> "+line(r), width)+"\x1b[0m")
+			if m.expanded {
+				for _, a := range serrors.FlattenAttrs(r) {
+					body = append(body, truncate("    "+escapeControl(a), width))
+				}
+			}
+			continue
+		}
+
+		body = append(body, truncate("  "+line(r), width))
+	}
+
+	bodyHeight := max(height-2, 1)
+	if cursorRow < m.offset {
+		m.offset = cursorRow
+	}
+
+	if cursorRow >= m.offset+bodyHeight {
+		m.offset = cursorRow - bodyHeight + 1
+	}
+
+	m.offset = min(m.offset, max(len(body)-bodyHeight, 0))
+	for i := m.offset; i < len(body) && i < m.offset+bodyHeight; i++ {
+		rows = append(rows, body[i])
+	}
+
+	for len(rows) < height-1 {
+		rows = append(rows, "")
+	}
+
+	return append(rows, truncate(m.footer(), width))
+}
+
+// header describes the filters and how many records they show
+func (m *Model) header() string {
+	h := fmt.Sprintf("serrors  %d/%d records  level>=%s", len(m.view), len(m.all), m.minLevel)
+	if m.search != "" {
+		h += fmt.Sprintf("  search=%q", m.search)
+	}
+
+	if m.follow {
+		h += "  [follow]"
+	}
+
+	return h
+}
+
+// footer shows the search being typed or the key help
+func (m *Model) footer() string {
+	if m.searching {
+		return "/" + m.input
+	}
+
+	return "j/k move  enter attrs  / search  esc clear  l level  g/G top/end  q quit"
+}
+
+// line renders r on a single line
+func line(r slog.Record) string {
+	var b strings.Builder
+	b.WriteString(r.Time.Format("15:04:05.000"))
+	b.WriteString(" ")
+	b.WriteString(fmt.Sprintf("%-5s", r.Level))
+	b.WriteString(" ")
+	b.WriteString(r.Message)
+	for _, s := range serrors.FlattenAttrs(r) {
+		b.WriteString(" ")
+		b.WriteString(s)
+	}
+
+	return escapeControl(b.String())
+}
+
+// escapeControl replaces the control characters in s with Go escapes, so a record cannot move the
+// cursor or change the terminal with escape sequences
+func escapeControl(s string) string {
+	if !strings.ContainsFunc(s, unicode.IsControl) {
+		return s
+	}
+
+	var b strings.Builder
+	for _, r := range s {
+		if !unicode.IsControl(r) {
+			b.WriteRune(r)
+			continue
+		}
+
+		q := strconv.QuoteRune(r)
+		b.WriteString(q[1 : len(q)-1])
+	}
+
+	return b.String()
+}
+
+// truncate cuts s to width runes
+func truncate(s string, width int) string {
+	if width <= 0 || utf8.RuneCountInString(s) <= width {
+		return s
+	}
+
+	return string([]rune(s)[:width])
+}
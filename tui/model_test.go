@@ -0,0 +1,132 @@
+package tui
+
+import (
+	"log/slog"
+	"strings"
+	"testing"
+	"time"
+)
+
+var testTime = time.Date(2000, 1, 2, 3, 4, 5, 0, time.UTC)
+
+func testRecords() []slog.Record {
+	var rs []slog.Record
+	for i, l := range []slog.Level{slog.LevelDebug, slog.LevelInfo, slog.LevelWarn, slog.LevelError} {
+		r := slog.NewRecord(testTime, l, "m"+l.String(), 0)
+		r.AddAttrs(slog.Int("a", i), slog.Group("g", slog.String("b", "x"), slog.Group("", slog.String("c", "y"))))
+		rs = append(rs, r)
+	}
+
+	return rs
+}
+
+func TestModelLevelFilter(t *testing.T) {
+	m := NewModel()
+	m.SetRecords(testRecords())
+
+	for _, want := range []int{3, 2, 1, 4} {
+		m.Handle(Key{Code: KeyRune, Rune: 'l'})
+		if len(m.view) != want {
+			t.Fatalf("\ngot  %d\nwant %d", len(m.view), want)
+		}
+	}
+}
+
+func TestModelSearch(t *testing.T) {
+	m := NewModel()
+	m.SetRecords(testRecords())
+
+	for _, k := range ParseKeys([]byte("/mwarn\r")) {
+		m.Handle(k)
+	}
+
+	r, ok := m.Selected()
+	if !ok || r.Message != "mWARN" || len(m.view) != 1 {
+		t.Fatalf("\ngot  %s %d\nwant mWARN 1", r.Message, len(m.view))
+	}
+
+	m.Handle(Key{Code: KeyEsc})
+	if len(m.view) != 4 {
+		t.Fatalf("\ngot  %d\nwant 4", len(m.view))
+	}
+}
+
+func TestModelFollow(t *testing.T) {
+	m := NewModel()
+	rs := testRecords()
+	m.SetRecords(rs[:2])
+	m.SetRecords(rs)
+
+	r, _ := m.Selected()
+	if r.Message != "mERROR" {
+		t.Fatalf("\ngot  %s\nwant mERROR", r.Message)
+	}
+
+	m.Handle(Key{Code: KeyRune, Rune: 'g'})
+	m.SetRecords(append(rs, rs[0]))
+	r, _ = m.Selected()
+	if r.Message != "mDEBUG" || m.cursor != 0 {
+		t.Fatalf("\ngot  %s %d\nwant mDEBUG 0", r.Message, m.cursor)
+	}
+}
+
+func TestModelView(t *testing.T) {
+	m := NewModel()
+	m.SetRecords(testRecords())
+	m.Handle(Key{Code: KeyEnter})
+
+	rows := m.View(80, 10)
+	if len(rows) != 10 {
+		t.Fatalf("\ngot  %d\nwant 10", len(rows))
+	}
+
+	want := "\x1b[7m> 03:04:05.000 ERROR mERROR a=3 g.b=x g.c=y\x1b[0m"
+	if rows[4] != want {
+		t.Fatalf("\ngot  %q\nwant %q", rows[4], want)
+	}
+
+	if rows[5] != "    a=3" || rows[6] != "    g.b=x" || rows[7] != "    g.c=y" {
+		t.Fatalf("\ngot  %q %q %q\nwant attrs", rows[5], rows[6], rows[7])
+	}
+
+	rows = m.View(10, 3)
+	if len(rows) != 3 || !strings.HasPrefix(rows[1], "\x1b[7m> 03:04:05") {
+		t.Fatalf("\ngot  %q\nwant selected row", rows)
+	}
+}
+
+func TestModelViewEscapesControl(t *testing.T) {
+	m := NewModel()
+	r := slog.NewRecord(testTime, slog.LevelInfo, "m\x1b[2J", 0)
+	r.AddAttrs(slog.String("a", "x\ny"))
+	m.SetRecords([]slog.Record{r})
+	m.Handle(Key{Code: KeyEnter})
+
+	rows := m.View(80, 5)
+	want := "\x1b[7m> 03:04:05.000 INFO  m\\x1b[2J a=x\\ny\x1b[0m"
+	if rows[1] != want || rows[2] != `    a=x\ny` {
+		t.Fatalf("\ngot  %q\nwant %q", rows[1:3], want)
+	}
+}
+
+func TestParseKeys(t *testing.T) {
+	got := ParseKeys([]byte("q\x1b[A\x1b[6~\x1b[Z\x7fé\x1b"))
+	want := []Key{
+		{Code: KeyRune, Rune: 'q'},
+		{Code: KeyUp},
+		{Code: KeyPageDown},
+		{Code: KeyBackspace},
+		{Code: KeyRune, Rune: 'é'},
+		{Code: KeyEsc},
+	}
+
+	if len(got) != len(want) {
+		t.Fatalf("\ngot  %v\nwant %v", got, want)
+	}
+
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("\ngot  %v\nwant %v", got, want)
+		}
+	}
+}
@@ -0,0 +1,204 @@
+// Package tui is an interactive terminal viewer for a live serrors.SErrors collection. It shows a
+// scrollable list of records with a level filter, a search and an expandable attr view, and
+// refreshes as records are added so long-running local jobs can be watched while they run.
+package tui
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"os"
+	"time"
+	"unicode/utf8"
+
+	"github.com/chadeldridge/serrors"
+	"golang.org/x/term"
+)
+
+// Options configures Run. A nil *Options uses the defaults.
+type Options struct {
+	// In is the terminal keys are read from. Defaults to os.Stdin.
+	In *os.File
+	// Out is where the viewer is drawn. Defaults to os.Stdout.
+	Out io.Writer
+	// Refresh is how often new records are picked up. Defaults to 500ms.
+	Refresh time.Duration
+}
+
+// Run shows e in the terminal until q or ctrl-c is pressed or ctx is done. The terminal is put in
+// raw mode and restored before Run returns.
+func Run(ctx context.Context, e *serrors.SErrors, opts *Options) error {
+	if opts == nil {
+		opts = &Options{}
+	}
+
+	in := opts.In
+	if in == nil {
+		in = os.Stdin
+	}
+
+	out := opts.Out
+	if out == nil {
+		out = os.Stdout
+	}
+
+	refresh := opts.Refresh
+	if refresh <= 0 {
+		refresh = 500 * time.Millisecond
+	}
+
+	if term.IsTerminal(int(in.Fd())) {
+		old, err := term.MakeRaw(int(in.Fd()))
+		if err != nil {
+			return err
+		}
+		defer term.Restore(int(in.Fd()), old)
+	}
+
+	// Switch to the alternate screen and hide the cursor.
+	if _, err := io.WriteString(out, "\x1b[?1049h\x1b[?25l"); err != nil {
+		return err
+	}
+	defer io.WriteString(out, "\x1b[?25h\x1b[?1049l")
+
+	keys, done, stopped := make(chan []Key), make(chan struct{}), make(chan struct{})
+	go func() {
+		defer close(stopped)
+		readKeys(in, keys, done)
+	}()
+	defer func() {
+		close(done)
+		// Interrupt the blocked read, if in supports deadlines, so the caller's input is not read
+		// after Run returns, then clear the deadline for the caller.
+		if in.SetReadDeadline(time.Now()) == nil {
+			<-stopped
+			in.SetReadDeadline(time.Time{})
+		}
+	}()
+
+	m := NewModel()
+	m.SetRecords(e.Records())
+	ticker := time.NewTicker(refresh)
+	defer ticker.Stop()
+
+	for {
+		if err := draw(out, m); err != nil {
+			return err
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+			m.SetRecords(e.Records())
+		case ks, ok := <-keys:
+			if !ok {
+				return nil
+			}
+
+			for _, k := range ks {
+				if m.Handle(k) {
+					return nil
+				}
+			}
+		}
+	}
+}
+
+// draw clears the screen and writes the model sized to out, or 80x24 if out is not a terminal
+func draw(out io.Writer, m *Model) error {
+	width, height := 80, 24
+	if f, ok := out.(*os.File); ok {
+		if w, h, err := term.GetSize(int(f.Fd())); err == nil {
+			width, height = w, h
+		}
+	}
+
+	var b bytes.Buffer
+	b.WriteString("\x1b[H\x1b[2J")
+	for i, row := range m.View(width, height) {
+		if i > 0 {
+			// Raw mode does not translate \n to \r\n.
+			b.WriteString("\r\n")
+		}
+		b.WriteString(row)
+	}
+
+	_, err := out.Write(b.Bytes())
+	return err
+}
+
+// readKeys sends the keys read from in to keys until in returns an error or done is closed. A read
+// that cannot be interrupted ends the goroutine at the next key press, which is dropped.
+func readKeys(in io.Reader, keys chan<- []Key, done <-chan struct{}) {
+	defer close(keys)
+
+	buf := make([]byte, 64)
+	for {
+		n, err := in.Read(buf)
+		if n > 0 {
+			select {
+			case keys <- ParseKeys(buf[:n]):
+			case <-done:
+				return
+			}
+		}
+
+		if err != nil {
+			return
+		}
+	}
+}
+
+// escapes maps terminal escape sequences to keys
+var escapes = map[string]KeyCode{
+	"\x1b[A":  KeyUp,
+	"\x1b[B":  KeyDown,
+	"\x1b[5~": KeyPageUp,
+	"\x1b[6~": KeyPageDown,
+	"\x1b[H":  KeyHome,
+	"\x1b[F":  KeyEnd,
+	"\x1b[1~": KeyHome,
+	"\x1b[4~": KeyEnd,
+}
+
+// ParseKeys converts raw terminal input into keys. Unknown escape sequences are dropped.
+func ParseKeys(b []byte) []Key {
+	var keys []Key
+	for len(b) > 0 {
+		if b[0] == 0x1b && len(b) > 1 && b[1] == '[' {
+			end := bytes.IndexFunc(b[2:], func(r rune) bool { return r >= 0x40 && r <= 0x7e })
+			if end < 0 {
+				return keys
+			}
+
+			seq := string(b[:end+3])
+			if code, ok := escapes[seq]; ok {
+				keys = append(keys, Key{Code: code})
+			}
+
+			b = b[end+3:]
+			continue
+		}
+
+		switch b[0] {
+		case 0x1b:
+			keys = append(keys, Key{Code: KeyEsc})
+		case 0x03:
+			keys = append(keys, Key{Code: KeyCtrlC})
+		case '\r', '\n':
+			keys = append(keys, Key{Code: KeyEnter})
+		case 0x7f, 0x08:
+			keys = append(keys, Key{Code: KeyBackspace})
+		default:
+			r, size := utf8.DecodeRune(b)
+			keys = append(keys, Key{Code: KeyRune, Rune: r})
+			b = b[size:]
+			continue
+		}
+
+		b = b[1:]
+	}
+
+	return keys
+}
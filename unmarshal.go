@@ -0,0 +1,116 @@
+package serrors
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"os"
+)
+
+// UnmarshalJSON replaces the records in memory of e with the ones in data, as written by
+// MarshalJSON: an array of records, null, or an object with a meta block, whose fields are set with
+// SetMeta. Records are parsed like ParseJSON, so nested objects become groups. They are stored as
+// they are, without the transforms, remapping or sampling of Add. A zero SErrors, such as one
+// allocated by encoding/json for a *SErrors field, is set up like New(os.Stderr, nil) first.
+func (e *SErrors) UnmarshalJSON(data []byte) error {
+	var raw []json.RawMessage
+	var meta []metaField
+	switch data = bytes.TrimSpace(data); {
+	case bytes.Equal(data, []byte("null")):
+	case bytes.HasPrefix(data, []byte("{")):
+		var err error
+		if meta, raw, err = unmarshalMeta(data); err != nil {
+			return err
+		}
+	default:
+		if err := json.Unmarshal(data, &raw); err != nil {
+			return err
+		}
+	}
+
+	rs := make([]slog.Record, len(raw))
+	for i, b := range raw {
+		r, err := parseJSONRecord(b)
+		if err != nil {
+			return fmt.Errorf("serrors: record %d: %w", i, err)
+		}
+		rs[i] = r
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	e.setUp(func() *SErrors { return New(os.Stderr, nil) })
+	if meta != nil {
+		e.meta = meta
+	}
+
+	e.replaceRecords(rs)
+	return nil
+}
+
+// setUp initializes a zero SErrors, such as one allocated by encoding/json, from the collection
+// returned by newE. e.mu must be held.
+func (e *SErrors) setUp(newE func() *SErrors) {
+	if e.done != nil {
+		return
+	}
+
+	n := newE()
+	e.resetRender()
+	e.json, e.opts, e.verbosity, e.minLevel = n.json, n.opts, n.verbosity, n.minLevel
+	e.subs, e.out, e.done, e.logger = n.subs, n.out, n.done, n.logger
+}
+
+// replaceRecords replaces the records in memory with rs, stored as they are. e.mu must be held.
+func (e *SErrors) replaceRecords(rs []slog.Record) {
+	e.records, e.seqs, e.level = []slog.Record{}, nil, 0
+	clear(e.resolved)
+	e.shards.list = nil
+	for _, r := range rs {
+		e.store(r)
+	}
+	e.recomputeLevel()
+	e.invalidate()
+	e.checkpointWAL()
+}
+
+// unmarshalMeta splits a document written with WithMetaBlock into its meta fields, in order, and
+// its records
+func unmarshalMeta(data []byte) ([]metaField, []json.RawMessage, error) {
+	var doc struct {
+		Meta   json.RawMessage   `json:"meta"`
+		Errors []json.RawMessage `json:"errors"`
+	}
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, nil, err
+	}
+
+	meta := []metaField{}
+	if len(doc.Meta) == 0 || bytes.Equal(doc.Meta, []byte("null")) {
+		return meta, doc.Errors, nil
+	}
+
+	// Decode the fields one at a time to keep their order.
+	d := json.NewDecoder(bytes.NewReader(doc.Meta))
+	if tok, err := d.Token(); err != nil || tok != json.Delim('{') {
+		return nil, nil, fmt.Errorf("serrors: meta is not an object")
+	}
+
+	for d.More() {
+		tok, err := d.Token()
+		if err != nil {
+			return nil, nil, err
+		}
+
+		var v json.RawMessage
+		if err := d.Decode(&v); err != nil {
+			return nil, nil, err
+		}
+
+		meta = append(meta, metaField{key: tok.(string), value: v})
+	}
+
+	return meta, doc.Errors, nil
+}
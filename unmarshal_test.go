@@ -0,0 +1,68 @@
+package serrors
+
+import (
+	"encoding/json"
+	"log/slog"
+	"testing"
+)
+
+func TestSErrorsUnmarshalJSON(t *testing.T) {
+	src := New(nil, nil, WithMetaBlock())
+	src.SetMeta("job", "nightly")
+	src.SetMeta("attempt", 2)
+	src.Debug(testTime, "d", slog.Group("g", slog.Int("a", 1), slog.Group("h", slog.String("b", "x"))))
+	src.Warn(testTime, "w", slog.Float64("f", 1.5), slog.Bool("ok", true))
+
+	tests := []struct {
+		name  string
+		src   *SErrors
+		level slog.Level
+	}{
+		{"array", func() *SErrors { e := New(nil, nil); e.Append(src); return e }(), slog.LevelWarn},
+		{"meta", src, slog.LevelWarn},
+		{"null", New(nil, nil, WithEmptyJSON(EmptyNull)), 0},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			want, err := test.src.MarshalJSON()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			doc := struct {
+				Errors *SErrors `json:"errors"`
+			}{}
+			if err := json.Unmarshal([]byte(`{"errors":`+string(want)+`}`), &doc); err != nil {
+				t.Fatal(err)
+			}
+
+			e := doc.Errors
+			if test.name == "null" {
+				e = New(nil, nil, WithEmptyJSON(EmptyNull))
+				if err := e.UnmarshalJSON(want); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			e.metaBlock = test.src.metaBlock
+			e.emptyJSON = test.src.emptyJSON
+			got, err := e.MarshalJSON()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if string(got) != string(want) {
+				t.Fatalf("\ngot  %s\nwant %s", got, want)
+			}
+
+			if e.Level() != test.level {
+				t.Fatalf("\ngot  %s\nwant %s", e.Level(), test.level)
+			}
+		})
+	}
+
+	if err := New(nil, nil).UnmarshalJSON([]byte(`[{"level":"LOUD"}]`)); err == nil {
+		t.Fatal("want error for a bad level")
+	}
+}
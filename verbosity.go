@@ -0,0 +1,81 @@
+package serrors
+
+import (
+	"log/slog"
+	"slices"
+	"time"
+)
+
+// Verbosity levels used by SErrors.RenderVerbosity. Each level includes everything shown by the
+// levels below it.
+const (
+	// VerbosityQuiet renders only the level and message of each record
+	VerbosityQuiet = iota
+	// VerbosityKeys adds the key attrs set with SErrors.KeyAttrs
+	VerbosityKeys
+	// VerbosityAll adds all attrs except source and stack
+	VerbosityAll
+	// VerbosityDebug renders everything, including time, source and stack. This is the default.
+	VerbosityDebug
+)
+
+// StackKey is the attr key holding a stack trace. It is hidden below VerbosityDebug.
+const StackKey = "stack"
+
+// defaultKeyAttrs are the attr keys shown at VerbosityKeys when SErrors.KeyAttrs has not been called
+var defaultKeyAttrs = []string{CodeKey, ErrKey, "error"}
+
+// RenderVerbosity sets how much of each record is shown in text output. v is clamped between
+// VerbosityQuiet and VerbosityDebug so CLI flags such as -q/-v/-vv can be mapped with simple math.
+// JSON output is not affected.
+func (e *SErrors) RenderVerbosity(v int) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	e.verbosity = min(max(v, VerbosityQuiet), VerbosityDebug)
+	e.invalidate()
+}
+
+// KeyAttrs sets the attr keys shown at VerbosityKeys
+func (e *SErrors) KeyAttrs(keys ...string) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	e.keyAttrs = keys
+	e.invalidate()
+}
+
+// verbose returns r reduced to the current verbosity level. e.mu must be held.
+func (e *SErrors) verbose(r slog.Record) slog.Record {
+	if e.verbosity >= VerbosityDebug {
+		return r
+	}
+
+	// A zero time and PC are omitted by the slog handlers.
+	var t time.Time
+	if e.verbosity > VerbosityQuiet {
+		t = r.Time
+	}
+
+	n := slog.NewRecord(t, r.Level, r.Message, 0)
+	if e.verbosity == VerbosityQuiet {
+		return n
+	}
+
+	keys := e.keyAttrs
+	if keys == nil {
+		keys = defaultKeyAttrs
+	}
+
+	r.Attrs(func(a slog.Attr) bool {
+		switch {
+		case a.Key == StackKey || a.Key == slog.SourceKey:
+		case e.verbosity == VerbosityKeys && !slices.Contains(keys, a.Key):
+		default:
+			n.AddAttrs(a)
+		}
+		return true
+	})
+
+	return n
+}
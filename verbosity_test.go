@@ -0,0 +1,59 @@
+package serrors
+
+import (
+	"log/slog"
+	"testing"
+)
+
+func TestSErrorsRenderVerbosity(t *testing.T) {
+	tests := []struct {
+		name string
+		v    int
+		want string
+	}{
+		{"quiet", VerbosityQuiet, "level=ERROR msg=m\n"},
+		{"clampLow", -3, "level=ERROR msg=m\n"},
+		{"keys", VerbosityKeys, "time=2000-01-02T03:04:05.000Z level=ERROR msg=m code=500\n"},
+		{"all", VerbosityAll, "time=2000-01-02T03:04:05.000Z level=ERROR msg=m a=1 code=500\n"},
+		{"debug", VerbosityDebug, "time=2000-01-02T03:04:05.000Z level=ERROR msg=m a=1 code=500 stack=s\n"},
+		{"clampHigh", 9, "time=2000-01-02T03:04:05.000Z level=ERROR msg=m a=1 code=500 stack=s\n"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			e := NewTextHandler(nil, nil)
+			e.RenderVerbosity(test.v)
+			e.Error(testTime, "m", slog.Int("a", 1), slog.Int("code", 500), slog.String(StackKey, "s"))
+
+			got := e.String()
+			if got != test.want {
+				t.Fatalf("\ngot  %s\nwant %s", got, test.want)
+			}
+		})
+	}
+}
+
+func TestSErrorsKeyAttrs(t *testing.T) {
+	e := NewTextHandler(nil, nil)
+	e.RenderVerbosity(VerbosityKeys)
+	e.KeyAttrs("a")
+	e.Error(testTime, "m", slog.Int("a", 1), slog.Int("code", 500))
+
+	want := "time=2000-01-02T03:04:05.000Z level=ERROR msg=m a=1\n"
+	got := e.String()
+	if got != want {
+		t.Fatalf("\ngot  %s\nwant %s", got, want)
+	}
+}
+
+func TestSErrorsRenderVerbosityJSON(t *testing.T) {
+	e := New(nil, nil)
+	e.RenderVerbosity(VerbosityQuiet)
+	e.Error(testTime, "m", slog.Int("a", 1))
+
+	want := `{"time":"2000-01-02T03:04:05Z","level":"ERROR","msg":"m","a":1}` + "\n"
+	got := e.String()
+	if got != want {
+		t.Fatalf("\ngot  %s\nwant %s", got, want)
+	}
+}
@@ -0,0 +1,353 @@
+package serrors
+
+import (
+	"io"
+	"log/slog"
+	"sync"
+	"time"
+)
+
+const (
+	// walSeqKey is the attr the journal appends to each record, holding its sequence number
+	walSeqKey = "serrors.wal.seq"
+	// walOpKey marks a journal entry that is an operation rather than a record
+	walOpKey = "serrors.wal.op"
+)
+
+// The operations written to the journal besides records
+const (
+	// walDelete removes the record with the entry's sequence number
+	walDelete = "delete"
+	// walBegin starts a checkpoint: the records up to the matching walEnd replace every entry
+	// before it
+	walBegin = "begin"
+	// walEnd completes a checkpoint
+	walEnd = "end"
+)
+
+// walCompactMin is the number of journal entries below which the journal is not compacted
+const walCompactMin = 1024
+
+// wal holds the state of WithWAL
+type wal struct {
+	store Store
+	// count is the number of entries in the journal
+	count int
+	// err is the first error opening or writing the journal. Journaling stops after an error.
+	err error
+	// file is synced after records are journaled if fsync is set, outside e.mu
+	file  *FileStore
+	fsync bool
+	// syncMu serializes the syncs
+	syncMu sync.Mutex
+}
+
+// WithWAL journals every record entering the collection, and every record removed, to the file at
+// path, creating it if needed, so the collection can be rebuilt with Recover if the process dies.
+// If fsync is true the journal is synced to disk after every change, which survives power loss at
+// the cost of speed; the sync runs after the collection is unlocked, so other goroutines do not
+// wait on it. Records expired by WithRecordTTL are synced with the next change. The journal is
+// compacted once most of it is records since removed. Call CloseWAL when done.
+func WithWAL(path string, fsync bool) Option {
+	return func(e *SErrors) {
+		s, err := NewFileStore(path, false)
+		if err != nil {
+			e.wal = &wal{err: err}
+			return
+		}
+
+		e.wal = e.newWAL(s)
+		e.wal.file, e.wal.fsync = s, fsync
+	}
+}
+
+// WithWALStore is WithWAL journaling to s instead of a file. Rebuild the collection with
+// RecoverStore.
+func WithWALStore(s Store) Option {
+	return func(e *SErrors) {
+		e.wal = e.newWAL(s)
+	}
+}
+
+// newWAL returns a wal journaling to s, counting the entries s already holds. The sequence numbers
+// of e start after the ones in s, so the entries journaled next cannot be mistaken for them.
+func (e *SErrors) newWAL(s Store) *wal {
+	w := &wal{store: s}
+	w.err = s.LoadRange(0, -1, func(r slog.Record) error {
+		w.count++
+		if _, seq, _ := walEntry(r); seq > e.seq {
+			e.seq = seq
+		}
+		return nil
+	})
+
+	return w
+}
+
+// CloseWAL syncs and closes the journal, if it has a Close method, and returns the first error
+// opening or writing it
+func (e *SErrors) CloseWAL() error {
+	if e.wal == nil {
+		return nil
+	}
+
+	e.syncWAL()
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if c, ok := e.wal.store.(io.Closer); ok {
+		if err := c.Close(); err != nil && e.wal.err == nil {
+			e.wal.err = err
+		}
+	}
+	e.wal.store = nil
+
+	return e.wal.err
+}
+
+// journaling reports whether entries can be written to the journal. e.mu must be held.
+func (e *SErrors) journaling() bool {
+	return e.wal != nil && e.wal.store != nil && e.wal.err == nil
+}
+
+// appendEntry writes r to the journal. e.mu must be held.
+func (e *SErrors) appendEntry(r slog.Record) {
+	e.wal.err = e.wal.store.AppendRecord(r)
+	if e.wal.err == nil {
+		e.wal.count++
+	}
+}
+
+// journal appends the record r with sequence number seq to the WAL. e.mu must be held.
+func (e *SErrors) journal(seq uint64, r slog.Record) {
+	if !e.journaling() {
+		return
+	}
+
+	r = r.Clone()
+	r.AddAttrs(slog.Uint64(walSeqKey, seq))
+	e.appendEntry(r)
+}
+
+// journalRecords appends the records rs with sequence numbers seqs to the WAL. e.mu must be held.
+func (e *SErrors) journalRecords(rs []slog.Record, seqs []uint64) {
+	for i, r := range rs {
+		e.journal(seqs[i], r)
+	}
+}
+
+// journalOp appends the operation op on the record with sequence number seq to the WAL. e.mu must
+// be held.
+func (e *SErrors) journalOp(op string, seq uint64) {
+	if !e.journaling() {
+		return
+	}
+
+	r := slog.NewRecord(time.Time{}, 0, "", 0)
+	r.AddAttrs(slog.String(walOpKey, op), slog.Uint64(walSeqKey, seq))
+	e.appendEntry(r)
+}
+
+// compactWAL checkpoints the WAL once most of its entries are records since removed. e.mu must be
+// held.
+func (e *SErrors) compactWAL() {
+	if !e.journaling() {
+		return
+	}
+
+	live := len(e.records)
+	if e.spill != nil {
+		live += e.spill.count
+	}
+
+	if e.wal.count >= walCompactMin && e.wal.count > 2*live {
+		e.checkpointWAL()
+	}
+}
+
+// pruneJournal removes every entry from the WAL once the collection is emptied. e.mu must be held.
+func (e *SErrors) pruneJournal() {
+	if !e.journaling() || e.wal.count == 0 {
+		return
+	}
+
+	e.wal.err = e.wal.store.Prune(e.wal.count)
+	e.wal.count = 0
+}
+
+// checkpointWAL replaces the entries in the WAL with the records of e, spilled ones first. The
+// records are journaled between walBegin and walEnd before the old entries are pruned, so a crash
+// part way leaves a journal Recover still reads correctly. e.mu must be held.
+func (e *SErrors) checkpointWAL() {
+	if !e.journaling() {
+		return
+	}
+
+	old := e.wal.count
+	e.journalOp(walBegin, 0)
+	if e.spill != nil && e.spill.store != nil && e.spill.count > 0 {
+		i, seqs := 0, e.spill.seqs
+		err := e.spill.store.LoadRange(0, len(seqs), func(r slog.Record) error {
+			e.journal(seqs[i], r)
+			i++
+			return e.wal.err
+		})
+		if e.wal.err == nil {
+			e.wal.err = err
+		}
+	}
+
+	e.journalRecords(e.records, e.seqs)
+	e.journalOp(walEnd, 0)
+	if e.wal.err == nil {
+		e.wal.err = e.wal.store.Prune(old)
+		e.wal.count -= old
+	}
+}
+
+// syncWAL syncs the journal file to disk if WithWAL was used with fsync. It must be called without
+// e.mu held.
+func (e *SErrors) syncWAL() {
+	w := e.wal
+	if w == nil || w.file == nil || !w.fsync {
+		return
+	}
+
+	w.syncMu.Lock()
+	err := w.file.Sync()
+	w.syncMu.Unlock()
+	if err == nil {
+		return
+	}
+
+	e.mu.Lock()
+	if w.err == nil {
+		w.err = err
+	}
+	e.mu.Unlock()
+}
+
+// walEntry returns the operation of the journal entry r, "" for a record, its sequence number, 0
+// for a record journaled before they were, and r without the attrs the journal adds
+func walEntry(r slog.Record) (string, uint64, slog.Record) {
+	var attrs []slog.Attr
+	r.Attrs(func(a slog.Attr) bool {
+		attrs = append(attrs, a)
+		return true
+	})
+
+	n := len(attrs)
+	if n == 0 || attrs[n-1].Key != walSeqKey {
+		return "", 0, r
+	}
+
+	seq := attrs[n-1].Value.Resolve()
+	var s uint64
+	switch seq.Kind() {
+	case slog.KindUint64:
+		s = seq.Uint64()
+	case slog.KindInt64:
+		s = uint64(seq.Int64())
+	}
+
+	if n == 2 && attrs[0].Key == walOpKey && r.Message == "" && r.Time.IsZero() {
+		return attrs[0].Value.String(), s, r
+	}
+
+	c := slog.NewRecord(r.Time, r.Level, r.Message, r.PC)
+	c.AddAttrs(attrs[:n-1]...)
+	return "", s, c
+}
+
+// replayWAL returns the records left in a journal after applying its entries, passed to fn by
+// load, in the order they entered
+func replayWAL(load func(fn func(slog.Record) error) error) ([]slog.Record, error) {
+	type entry struct {
+		r       slog.Record
+		deleted bool
+	}
+
+	var live, pending []entry
+	var index, pendingIndex map[uint64]int
+	checkpoint := false
+	err := load(func(r slog.Record) error {
+		op, seq, r := walEntry(r)
+		switch op {
+		case "":
+			if checkpoint {
+				pending = append(pending, entry{r: r})
+				if seq > 0 {
+					pendingIndex[seq] = len(pending) - 1
+				}
+				return nil
+			}
+
+			live = append(live, entry{r: r})
+			if seq > 0 {
+				if index == nil {
+					index = map[uint64]int{}
+				}
+				index[seq] = len(live) - 1
+			}
+		case walDelete:
+			if i, ok := index[seq]; ok {
+				live[i].deleted = true
+				delete(index, seq)
+			}
+		case walBegin:
+			checkpoint, pending, pendingIndex = true, nil, map[uint64]int{}
+		case walEnd:
+			if checkpoint {
+				live, index, checkpoint = pending, pendingIndex, false
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// A checkpoint without its end was cut short by a crash, so the entries before it stand.
+	var rs []slog.Record
+	for _, en := range live {
+		if !en.deleted {
+			rs = append(rs, en.r)
+		}
+	}
+
+	return rs, nil
+}
+
+// Recover adds the records in the journal at path, written by WithWAL, to e, leaving out the ones
+// removed. A partial last line left by a crash mid-write is ignored. If e uses WithWAL, its
+// journal is rewritten to hold the records of e, so e may use WithWAL on the same path to carry on
+// where the crashed process stopped.
+func (e *SErrors) Recover(path string) error {
+	return e.recoverFrom(func(fn func(slog.Record) error) error { return loadFile(path, 0, -1, fn) })
+}
+
+// RecoverStore is Recover for a journal written by WithWALStore
+func (e *SErrors) RecoverStore(s Store) error {
+	return e.recoverFrom(func(fn func(slog.Record) error) error { return s.LoadRange(0, -1, fn) })
+}
+
+// recoverFrom stores the records left in the journal passed to fn by load and checkpoints the WAL
+// of e
+func (e *SErrors) recoverFrom(load func(fn func(slog.Record) error) error) error {
+	rs, err := replayWAL(load)
+	if err != nil {
+		return err
+	}
+
+	defer e.syncWAL()
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	for _, r := range rs {
+		e.store(r)
+	}
+	e.checkpointWAL()
+
+	return nil
+}
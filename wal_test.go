@@ -0,0 +1,198 @@
+package serrors
+
+import (
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestSErrorsWithWAL(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "errors.wal")
+	e := NewTextHandler(nil, nil, WithWAL(path, true))
+	e.Warn(testTime, "m", slog.Int("a", 1), slog.Group("g", slog.String("b", "x")))
+	e.Error(testTime, "m2")
+	want := e.String()
+
+	// Simulate a crash in the middle of writing a record.
+	f, _ := os.OpenFile(path, os.O_WRONLY|os.O_APPEND, 0)
+	f.WriteString(`{"time":"2000-01-02T03:0`)
+	f.Close()
+
+	if err := e.CloseWAL(); err != nil {
+		t.Fatalf("\ngot  %s\nwant nil", err.Error())
+	}
+
+	r := NewTextHandler(nil, nil)
+	if err := r.Recover(path); err != nil {
+		t.Fatalf("\ngot  %s\nwant nil", err.Error())
+	}
+
+	if got := r.String(); got != want {
+		t.Fatalf("\ngot  %s\nwant %s", got, want)
+	}
+
+	if r.Level() != slog.LevelError {
+		t.Fatalf("\ngot  %s\nwant %s", r.Level(), slog.LevelError)
+	}
+}
+
+func TestSErrorsRecoverContinue(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "errors.wal")
+	e := New(nil, nil, WithWAL(path, false))
+	e.Info(testTime, "a")
+	e.CloseWAL()
+
+	c := New(nil, nil, WithWAL(path, false))
+	if err := c.Recover(path); err != nil {
+		t.Fatalf("\ngot  %s\nwant nil", err.Error())
+	}
+	c.Info(testTime, "b")
+	c.CloseWAL()
+
+	r := New(nil, nil)
+	r.Recover(path)
+	if len(r.records) != 2 || r.records[0].Message != "a" || r.records[1].Message != "b" {
+		t.Fatalf("\ngot  %d records\nwant a and b", len(r.records))
+	}
+}
+
+func TestSErrorsWithWALError(t *testing.T) {
+	e := New(nil, nil, WithWAL("/nonexistent/errors.wal", false))
+	e.Info(testTime, "a")
+
+	if err := e.CloseWAL(); err == nil {
+		t.Fatalf("\ngot  nil\nwant open error")
+	}
+
+	if err := e.Recover("/nonexistent/errors.wal"); err == nil {
+		t.Fatalf("\ngot  nil\nwant open error")
+	}
+}
+
+func TestSErrorsRecoverTwice(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "errors.wal")
+	e := New(nil, nil, WithWAL(path, false))
+	e.Info(testTime, "a")
+	e.CloseWAL()
+
+	// Crash mid-write, restart, add a record and crash mid-write again.
+	torn := func() {
+		f, _ := os.OpenFile(path, os.O_WRONLY|os.O_APPEND, 0)
+		f.WriteString(`{"time":"2000-01-02T03:0`)
+		f.Close()
+	}
+	torn()
+
+	c := New(nil, nil, WithWAL(path, false))
+	if err := c.Recover(path); err != nil {
+		t.Fatalf("\ngot  %s\nwant nil", err.Error())
+	}
+	c.Info(testTime, "b")
+	c.CloseWAL()
+	torn()
+
+	r := New(nil, nil)
+	if err := r.Recover(path); err != nil {
+		t.Fatalf("\ngot  %s\nwant nil", err.Error())
+	}
+
+	if len(r.records) != 2 || r.records[0].Message != "a" || r.records[1].Message != "b" {
+		t.Fatalf("\ngot  %d records\nwant a and b", len(r.records))
+	}
+}
+
+func TestSErrorsResetPrunesWAL(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "errors.wal")
+	e := New(io.Discard, nil, WithWAL(path, false))
+	e.Info(testTime, "a")
+	e.Info(testTime, "b")
+	if err := e.LogAndClear(); err != nil {
+		t.Fatalf("\ngot  %s\nwant nil", err.Error())
+	}
+	e.Info(testTime, "c")
+	e.CloseWAL()
+
+	// A restarted collector carries on with the journal and is reset.
+	c := New(nil, nil, WithWAL(path, false))
+	r := New(nil, nil)
+	r.Recover(path)
+	if len(r.records) != 1 || r.records[0].Message != "c" {
+		t.Fatalf("\ngot  %d records\nwant c", len(r.records))
+	}
+
+	c.Recover(path)
+	c.Reset()
+	if err := c.CloseWAL(); err != nil {
+		t.Fatalf("\ngot  %s\nwant nil", err.Error())
+	}
+
+	r = New(nil, nil)
+	r.Recover(path)
+	if len(r.records) != 0 {
+		t.Fatalf("\ngot  %d records\nwant 0", len(r.records))
+	}
+}
+
+func TestSErrorsWALJournalsChanges(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "errors.wal")
+	clock := fixedClock(testTime)
+	e := NewTextHandler(nil, nil, WithWAL(path, true), WithRecordTTL(time.Hour), WithClock(&clock))
+	e.Info(testTime.Add(-2*time.Hour), "expired")
+	e.Info(testTime, "a")
+
+	s := NewTextHandler(nil, nil)
+	s.Info(testTime, "stacked")
+	e.Stack(s)
+	s.Reset()
+	s.Info(testTime, "appended")
+	e.Append(s)
+	e.RemoveIf(func(r slog.Record) bool { return r.Message == "a" })
+	e.Count()
+	e.Info(testTime, "b")
+	want := e.String()
+	e.CloseWAL()
+
+	r := NewTextHandler(nil, nil)
+	if err := r.Recover(path); err != nil {
+		t.Fatalf("\ngot  %s\nwant nil", err.Error())
+	}
+
+	if got := r.String(); got != want {
+		t.Fatalf("\ngot  %s\nwant %s", got, want)
+	}
+}
+
+func TestSErrorsWALCompacts(t *testing.T) {
+	journal := NewMemoryStore()
+	e := New(nil, nil, WithWALStore(journal))
+	for i := range walCompactMin {
+		e.Info(testTime, "m", slog.Int("i", i))
+	}
+	e.RemoveIf(func(r slog.Record) bool { return r.Message == "m" })
+	e.Info(testTime, "a")
+	e.Info(testTime, "b")
+	e.Remove(0)
+
+	// The removals compacted the journal to a checkpoint holding no records.
+	if got := loadMessages(t, journal, 0, -1); !slices.Equal(got, []string{"", "", "a", "b", ""}) {
+		t.Fatalf("\ngot  %q\nwant checkpoint, a, b and removal", got)
+	}
+
+	// A checkpoint cut short by a crash leaves the entries before it.
+	c := New(nil, nil, WithWALStore(journal))
+	c.journalOp(walBegin, 0)
+	c.Info(testTime, "c")
+
+	r := New(nil, nil)
+	if err := r.RecoverStore(journal); err != nil {
+		t.Fatalf("\ngot  %s\nwant nil", err.Error())
+	}
+
+	if got := recordMessages(r.Records()); !slices.Equal(got, []string{"b"}) {
+		t.Fatalf("\ngot  %v\nwant [b]", got)
+	}
+}
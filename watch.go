@@ -0,0 +1,86 @@
+package serrors
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+)
+
+// StalledKey is the attr key of the time since the last progress in Watch records
+const StalledKey = "stalled"
+
+// defaultStallIntervals is the number of quiet intervals before Watch warns unless
+// WithStallIntervals is used
+const defaultStallIntervals = 3
+
+// WithStallIntervals sets the number of intervals without a record being added before Watch warns
+// that the job has stalled, 3 by default
+func WithStallIntervals(n int) Option {
+	return func(e *SErrors) {
+		if n > 0 {
+			e.stallIntervals = n
+		}
+	}
+}
+
+// Watch checks e every interval until ctx is done. When no record has been added for the number of
+// intervals set by WithStallIntervals it adds a Warn record "no progress", once per stall, and when
+// ctx's deadline is exceeded it adds an Error record "deadline exceeded", both with how long the
+// job has been stalled. Any record added by other code counts as progress and ends the stall. The
+// records are timed by the clock of e, see WithClock. Watch blocks until ctx is done or e is
+// closed, so run it in its own goroutine.
+func (e *SErrors) Watch(ctx context.Context, every time.Duration) {
+	if !e.track() {
+		return
+	}
+	defer e.wg.Done()
+
+	ticker := time.NewTicker(every)
+	defer ticker.Stop()
+
+	stall := e.stallIntervals
+	if stall <= 0 {
+		stall = defaultStallIntervals
+	}
+
+	last, since := e.progress(), e.now()
+	quiet, warned := 0, false
+	for {
+		select {
+		case <-e.done:
+			return
+		case <-ctx.Done():
+			if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+				now := e.now()
+				r := slog.NewRecord(now, slog.LevelError, "deadline exceeded", 0)
+				r.AddAttrs(slog.Duration(StalledKey, now.Sub(since)))
+				e.add(r)
+			}
+			return
+		case <-ticker.C:
+			if n := e.progress(); n != last {
+				last, since, quiet, warned = n, e.now(), 0, false
+				continue
+			}
+
+			if quiet++; quiet < stall || warned {
+				continue
+			}
+
+			now := e.now()
+			r := slog.NewRecord(now, slog.LevelWarn, "no progress", 0)
+			r.AddAttrs(slog.Duration(StalledKey, now.Sub(since)))
+			// The watchdog's own record is not progress.
+			last, warned = e.add(r), true
+		}
+	}
+}
+
+// progress returns the number of records added so far
+func (e *SErrors) progress() uint64 {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+
+	return e.added
+}
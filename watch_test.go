@@ -0,0 +1,91 @@
+package serrors
+
+import (
+	"context"
+	"log/slog"
+	"testing"
+	"time"
+)
+
+func TestSErrorsWatch(t *testing.T) {
+	tests := []struct {
+		name  string
+		ctx   func() (context.Context, context.CancelFunc)
+		level slog.Level
+		msg   string
+		count int
+	}{
+		{
+			name: "deadline",
+			ctx: func() (context.Context, context.CancelFunc) {
+				return context.WithTimeout(context.Background(), 50*time.Millisecond)
+			},
+			level: slog.LevelError,
+			msg:   "deadline exceeded",
+			count: 2,
+		},
+		{
+			name: "canceled",
+			ctx: func() (context.Context, context.CancelFunc) {
+				ctx, cancel := context.WithCancel(context.Background())
+				time.AfterFunc(50*time.Millisecond, cancel)
+				return ctx, cancel
+			},
+			level: slog.LevelWarn,
+			msg:   "no progress",
+			count: 1,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctx, cancel := test.ctx()
+			defer cancel()
+
+			clock := fixedClock(testTime)
+			e := NewTextHandler(nil, nil, WithClock(clock), WithStallIntervals(2))
+			e.Watch(ctx, 10*time.Millisecond)
+
+			// The stall is warned about once however long it lasts.
+			rs := e.Records()
+			if len(rs) < 1 || rs[0].Message != "no progress" || rs[0].Level != slog.LevelWarn {
+				t.Fatalf("\ngot  %d records\nwant WARN no progress", len(rs))
+			}
+
+			last := rs[len(rs)-1]
+			if len(rs) != test.count || last.Message != test.msg || last.Level != test.level {
+				t.Fatalf("\ngot  %d %s %s\nwant %d %s %s", len(rs), last.Level, last.Message, test.count,
+					test.level, test.msg)
+			}
+
+			for _, r := range rs {
+				if !r.Time.Equal(testTime) {
+					t.Fatalf("\ngot  %s\nwant %s", r.Time, testTime)
+				}
+			}
+		})
+	}
+}
+
+func TestSErrorsWatchProgress(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	e := NewTextHandler(nil, nil)
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		e.Watch(ctx, 40*time.Millisecond)
+	}()
+
+	for i := 0; i < 20; i++ {
+		e.Add(testTime, slog.LevelInfo, "step")
+		time.Sleep(5 * time.Millisecond)
+	}
+	cancel()
+	<-done
+
+	for _, r := range e.Records() {
+		if r.Message != "step" {
+			t.Fatalf("\ngot  %s\nwant only steps", r.Message)
+		}
+	}
+}
@@ -0,0 +1,67 @@
+package serrors
+
+import (
+	"strings"
+	"unicode/utf8"
+)
+
+// WithLineWidth wraps text output at width characters, moving the attrs that overflow to
+// continuation lines starting with indent. Attrs are never split, so an attr longer than width gets
+// a line of its own. JSON output is not affected.
+func WithLineWidth(width int, indent string) Option {
+	return func(e *SErrors) {
+		e.lineWidth = width
+		e.lineIndent = indent
+	}
+}
+
+// wrapLine packs the space-separated tokens of line into lines of at most width characters
+func wrapLine(line string, width int, indent string) string {
+	var b strings.Builder
+	n := 0
+	for i, tok := range textTokens(line) {
+		l := utf8.RuneCountInString(tok)
+		switch {
+		case i == 0:
+		case n+1+l > width:
+			b.WriteString("\n")
+			b.WriteString(indent)
+			n = utf8.RuneCountInString(indent)
+		default:
+			b.WriteByte(' ')
+			n++
+		}
+
+		b.WriteString(tok)
+		n += l
+	}
+
+	return b.String()
+}
+
+// textTokens splits a text handler line on the spaces outside quoted values
+func textTokens(line string) []string {
+	var toks []string
+	start, quoted, escaped := 0, false, false
+	for i, c := range line {
+		switch {
+		case escaped:
+			escaped = false
+		case quoted && c == '\\':
+			escaped = true
+		case c == '"':
+			quoted = !quoted
+		case c == ' ' && !quoted:
+			if i > start {
+				toks = append(toks, line[start:i])
+			}
+			start = i + 1
+		}
+	}
+
+	if start < len(line) {
+		toks = append(toks, line[start:])
+	}
+
+	return toks
+}
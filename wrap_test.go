@@ -0,0 +1,103 @@
+package serrors
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"log/slog"
+	"sync"
+	"testing"
+)
+
+// writerFunc is an io.Writer calling the function
+type writerFunc func(p []byte) (int, error)
+
+func (f writerFunc) Write(p []byte) (int, error) { return f(p) }
+
+func TestSErrorsLineWidth(t *testing.T) {
+	tests := []struct {
+		name  string
+		width int
+		want  string
+	}{
+		{
+			name:  "wide",
+			width: 200,
+			want:  "time=2000-01-02T03:04:05.000Z level=ERROR msg=\"disk full\" path=\"/var/a b\" code=28 host=db1\n",
+		},
+		{
+			name:  "narrow",
+			width: 50,
+			want: "time=2000-01-02T03:04:05.000Z level=ERROR\n" +
+				"    msg=\"disk full\" path=\"/var/a b\" code=28\n" +
+				"    host=db1\n",
+		},
+		{
+			name:  "tiny",
+			width: 10,
+			want: "time=2000-01-02T03:04:05.000Z\n" +
+				"    level=ERROR\n" +
+				"    msg=\"disk full\"\n" +
+				"    path=\"/var/a b\"\n" +
+				"    code=28\n" +
+				"    host=db1\n",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got := bytes.NewBuffer(nil)
+			e := NewTextHandler(got, nil, WithLineWidth(test.width, "    "))
+			e.Add(testTime, slog.LevelError, "disk full",
+				slog.String("path", "/var/a b"), slog.Int("code", 28), slog.String("host", "db1"))
+
+			if s := e.String(); s != test.want {
+				t.Fatalf("\ngot  %s\nwant %s", s, test.want)
+			}
+
+			if err := e.Log(); err != nil {
+				t.Fatalf("\ngot  %s\nwant nil", err)
+			}
+
+			if got.String() != test.want {
+				t.Fatalf("\ngot  %s\nwant %s", got, test.want)
+			}
+		})
+	}
+}
+
+func TestTextHandlerConcurrent(t *testing.T) {
+	var mu sync.Mutex
+	var lines []string
+	w := writerFunc(func(p []byte) (int, error) {
+		mu.Lock()
+		defer mu.Unlock()
+		lines = append(lines, string(p))
+		return len(p), nil
+	})
+
+	h := newTextHandler(w, nil, nil, 200, "    ").WithGroup("g")
+	var wg sync.WaitGroup
+	for i := range 50 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			r := slog.NewRecord(testTime, slog.LevelError, "m", 0)
+			r.AddAttrs(slog.Int("b", i))
+			h.WithAttrs([]slog.Attr{slog.Int("a", i)}).Handle(context.Background(), r)
+		}()
+	}
+	wg.Wait()
+
+	for _, line := range lines {
+		var a, b int
+		_, err := fmt.Sscanf(line, "time=2000-01-02T03:04:05.000Z level=ERROR msg=m g.a=%d g.b=%d\n", &a, &b)
+		if err != nil || a != b {
+			t.Fatalf("\ngot  %q\nwant g.a equal to g.b", line)
+		}
+	}
+
+	if len(lines) != 50 {
+		t.Fatalf("\ngot  %d\nwant 50", len(lines))
+	}
+}